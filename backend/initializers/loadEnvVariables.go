@@ -29,11 +29,18 @@ type AppConfig struct {
 	REFRESH_LENGTH           time.Duration
 	API_SECRET               []byte
 	API_LENGTH               time.Duration
+	SHEET_SECRET             []byte // Signs identifiers printed on answer sheets, required and independent of token secrets
 	ACCESS_TOKEN_REVOKE_SYNC bool
 	UPLOADS_DESTINATION      string
 	INBUS_BASE_URL           string
 	INBUS_CLIENT_ID          string
 	INBUS_CLIENT_SECRET      string
+	FRONTEND_URL             string
+	PUBLIC_API_URL           string
+	PRINT_WORKERS            int64         // Maximal number of concurrent pdflatex processes
+	PRINT_RETENTION          time.Duration // How long are printed PDFs available for download
+	LATEX_TIMEOUT            time.Duration // Maximal duration of single LaTeX compilation
+	LATEX_MEMORY_MB          int64         // Memory limit of LaTeX processes (not applied on Windows)
 }
 
 func LoadEnvVariables() {
@@ -54,6 +61,14 @@ func LoadEnvVariables() {
 	if err != nil {
 		log.Fatalf("Invalid REFRESH_LENGTH format: %v", err)
 	}
+	printRetention, err := parseAdvancedDuration(getEnv("PRINT_RETENTION", "7d"))
+	if err != nil {
+		log.Fatalf("Invalid PRINT_RETENTION format: %v", err)
+	}
+	latexTimeout, err := parseAdvancedDuration(getEnv("LATEX_TIMEOUT", "2m"))
+	if err != nil {
+		log.Fatalf("Invalid LATEX_TIMEOUT format: %v", err)
+	}
 
 	GlobalAppConfig = &AppConfig{
 		PORT:                     getEnvInt("PORT", 8080),
@@ -70,11 +85,23 @@ func LoadEnvVariables() {
 		REFRESH_LENGTH:           refreshLen,
 		API_SECRET:               []byte(getEnv("REFRESH_SECRET", "")),
 		API_LENGTH:               apiLen,
+		SHEET_SECRET:             []byte(getEnv("SHEET_SECRET", "")),
 		ACCESS_TOKEN_REVOKE_SYNC: getEnvBool("ACCESS_TOKEN_REVOKE_SYNC", false),
 		UPLOADS_DESTINATION:      getEnv("UPLOADS_DESTINATION", "./uploads"),
 		INBUS_BASE_URL:           getEnv("INBUS_BASE_URL", "https://inbus.vsb.cz/"),
 		INBUS_CLIENT_ID:          getEnv("INBUS_CLIENT_ID", ""),
 		INBUS_CLIENT_SECRET:      getEnv("INBUS_CLIENT_SECRET", ""),
+		FRONTEND_URL:             getEnv("FRONTEND_URL", "https://elogika.vsb.cz"),
+		PUBLIC_API_URL:           getEnv("PUBLIC_API_URL", ""),
+		PRINT_WORKERS:            getEnvInt("PRINT_WORKERS", int64(runtime.NumCPU())),
+		PRINT_RETENTION:          printRetention,
+		LATEX_TIMEOUT:            latexTimeout,
+		LATEX_MEMORY_MB:          getEnvInt("LATEX_MEMORY_MB", 2048),
+	}
+
+	// Anyone could forge answer sheet identifiers signed by empty key
+	if len(GlobalAppConfig.SHEET_SECRET) == 0 {
+		log.Fatal("SHEET_SECRET must be set")
 	}
 }
 
@@ -11,6 +11,7 @@ import (
 	"elogika.vsb.cz/backend/initializers"
 	"elogika.vsb.cz/backend/middlewares"
 	"elogika.vsb.cz/backend/modules/activities"
+	"elogika.vsb.cz/backend/modules/appeals"
 	"elogika.vsb.cz/backend/modules/auth"
 	authCrons "elogika.vsb.cz/backend/modules/auth/crons"
 	"elogika.vsb.cz/backend/modules/auth/helpers"
@@ -27,6 +28,10 @@ import (
 	printCrons "elogika.vsb.cz/backend/modules/print/crons"
 	"elogika.vsb.cz/backend/modules/questions"
 	"elogika.vsb.cz/backend/modules/recognizer"
+	recognizerCrons "elogika.vsb.cz/backend/modules/recognizer/crons"
+	"elogika.vsb.cz/backend/modules/rubrics"
+	"elogika.vsb.cz/backend/modules/similarity"
+	similarityCrons "elogika.vsb.cz/backend/modules/similarity/crons"
 	"elogika.vsb.cz/backend/modules/support"
 	"elogika.vsb.cz/backend/modules/templates"
 	"elogika.vsb.cz/backend/modules/tests"
@@ -79,7 +84,7 @@ func main() {
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:5173", "http://localhost:8000", "https://elogika.vsb.cz", "http://elogika.vsb.cz"},
 		AllowMethods:     []string{"PUT", "PATCH", "POST", "GET", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-AS-ROLE", "X-URL"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-AS-ROLE", "X-URL", "X-SafeExamBrowser-ConfigKeyHash", "X-SafeExamBrowser-RequestHash"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
@@ -89,23 +94,21 @@ func main() {
 
 	testCrons.ExpireReadyTests()
 	testCrons.FinishActiveTests()
-	printCrons.ClearTempDir()
+	printCrons.CleanupPrintJobs()
 
 	c := cron.New()
-	c.AddFunc("0 0 0 0 0", func() {
-		log.Println("Running job: DeleteExpiredExpirations", time.Now())
-		go authCrons.DeleteExpiredExpirations()
-		log.Println("Running job: ClearTempDir", time.Now())
-		go printCrons.ClearTempDir()
-	})
-	c.AddFunc("* * * * 5", func() {
-		log.Println("Running job: ExpireReadyTests", time.Now())
-		go testCrons.ExpireReadyTests()
-	})
-	c.AddFunc("* * * * 5", func() {
-		log.Println("Running job: FinishActiveTests", time.Now())
-		go testCrons.FinishActiveTests()
-	})
+	scheduleJob(c, "@daily", "DeleteExpiredExpirations", authCrons.DeleteExpiredExpirations)
+	scheduleJob(c, "@daily", "CleanupPrintJobs", printCrons.CleanupPrintJobs)
+	scheduleJob(c, "@daily", "CleanupRenderCache", printCrons.CleanupRenderCache)
+	scheduleJob(c, "* * * * 5", "ExpireReadyTests", testCrons.ExpireReadyTests)
+	scheduleJob(c, "* * * * 5", "FinishActiveTests", testCrons.FinishActiveTests)
+	scheduleJob(c, "* * * * *", "AdvanceExpiredQuestions", testCrons.AdvanceExpiredQuestions)
+	scheduleJob(c, "* * * * *", "RunReevaluationJobs", testCrons.RunReevaluationJobs)
+	scheduleJob(c, "* * * * *", "ReleaseResults", testCrons.ReleaseResults)
+	scheduleJob(c, "* * * * *", "RunSimilarityJobs", similarityCrons.RunSimilarityJobs)
+	scheduleJob(c, "* * * * *", "RunRecognizerBatches", recognizerCrons.RunRecognizerBatches)
+	scheduleJob(c, "* * * * *", "RunRecognizerFiles", recognizerCrons.RunRecognizerFiles)
+	scheduleJob(c, "* * * * *", "RunPrintJobs", printCrons.RunPrintJobs)
 	c.Start()
 
 	v2api := r.Group("/api/v2")
@@ -133,6 +136,9 @@ func main() {
 			activities.RegisterRoutes(private)
 			recognizer.RegisterRoutes(private)
 			support.RegisterRoutes(private)
+			rubrics.RegisterRoutes(private)
+			similarity.RegisterRoutes(private)
+			appeals.RegisterRoutes(private)
 		}
 	}
 
@@ -179,3 +185,13 @@ func main() {
 		r.Run()
 	}
 }
+
+// scheduleJob runs job in background on given schedule, invalid schedule stops the server
+func scheduleJob(c *cron.Cron, spec string, name string, job func()) {
+	if _, err := c.AddFunc(spec, func() {
+		log.Println("Running job: "+name, time.Now())
+		go job()
+	}); err != nil {
+		log.Fatalf("Failed to schedule %s: %v", name, err)
+	}
+}
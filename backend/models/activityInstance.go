@@ -20,6 +20,9 @@ type ActivityInstance struct {
 	Content      *TipTapContent `gorm:"serializer:json;type:varbinary(max)"`
 	ContentFiles []*File        `gorm:"many2many:activity_instance_content_files;"`
 
+	RubricSelections []RubricSelection `gorm:"serializer:json;type:varbinary(max)"` // Rubric levels selected by grader
+	Feedback         *TipTapContent    `gorm:"serializer:json;type:varbinary(max)"` // Tutor comment on the result
+
 	Participant *User       ``
 	Term        *Term       ``
 	CourseItem  *CourseItem ``
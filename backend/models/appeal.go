@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// Appeal is a student request to regrade result or one question of a test
+type Appeal struct {
+	CommonModel
+	ID          uint      `gorm:"primarykey"`
+	CreatedAt   time.Time ``
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	CreatedByID uint      `` // Student who requested regrade
+
+	CourseID               uint                   ``
+	CourseItemID           uint                   ``
+	TermID                 uint                   ``
+	ResultID               uint                   ``
+	TestInstanceQuestionID *uint                  `` // Only one question is contested, otherwise whole result
+	Reason                 *TipTapContent         `gorm:"serializer:json;type:varbinary(max)"`
+	ReasonFiles            []*File                `gorm:"many2many:appeal_reason_files;"`
+	Status                 enums.AppealStatusEnum `gorm:"size:16"`
+
+	DecidedByID  *uint      ``
+	DecidedAt    *time.Time ``
+	PointsBefore float64    `` // Points of result when appeal was created
+	PointsAfter  *float64   `` // Points of result after decision
+
+	CreatedBy            *User                 ``
+	DecidedBy            *User                 ``
+	CourseItem           *CourseItem           ``
+	Term                 *Term                 ``
+	Result               *CourseItemResult     ``
+	TestInstanceQuestion *TestInstanceQuestion ``
+	Comments             []*AppealComment      ``
+}
+
+func (Appeal) TableName() string {
+	return "appeals"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// AppealComment is one message of appeal conversation, decisions are kept as comments with status
+type AppealComment struct {
+	CommonModel
+	ID          uint      `gorm:"primarykey"`
+	CreatedAt   time.Time ``
+	CreatedByID uint      ``
+
+	AppealID     uint                   ``
+	Content      *TipTapContent         `gorm:"serializer:json;type:varbinary(max)"`
+	ContentFiles []*File                `gorm:"many2many:appeal_comment_content_files;"`
+	Status       enums.AppealStatusEnum `gorm:"size:16"` // Status set by this comment, empty for plain messages
+
+	CreatedBy *User ``
+}
+
+func (AppealComment) TableName() string {
+	return "appeal_comments"
+}
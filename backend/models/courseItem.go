@@ -31,6 +31,7 @@ type CourseItem struct {
 	ManagedBy         enums.CourseUserRoleEnum    ``
 	EvaluateByAttempt enums.EvaluateByAttemptEnum ``
 	IncludeInResults  bool                        ``
+	AppealDays        uint                        `` // Days after results are shown when students may request regrade, 0 disables appeals
 
 	ActivityDetailID *uint ``
 	TestDetailID     *uint ``
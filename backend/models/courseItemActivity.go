@@ -8,6 +8,9 @@ type CourseItemActivity struct {
 	ExpectedResult      *TipTapContent `gorm:"serializer:json;type:varbinary(max)"`
 	DescriptionFiles    []*File        `gorm:"many2many:course_item_activity_files_description;"`
 	ExpectedResultFiles []*File        `gorm:"many2many:course_item_activity_files_result;"`
+	RubricID            *uint          `` // Rubric used when grading instances
+
+	Rubric *Rubric ``
 }
 
 func (CourseItemActivity) TableName() string {
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"elogika.vsb.cz/backend/modules/common/enums"
 	"gorm.io/gorm"
 )
 
@@ -22,9 +23,12 @@ type CourseItemResult struct {
 	TestInstanceID     *uint `` // If is a result of test. Will contain test reference
 	ActivityInstanceID *uint `` // If is a result of activity. Will contain test reference
 
-	Points   float64 ``
-	Final    bool    `` // Result is not waiting for manual intervention
-	Selected bool    `` // Result is selected as active
+	Points      float64    ``
+	Final       bool       `` // Result is not waiting for manual intervention
+	Selected    bool       `` // Result is selected as active
+	EvaluatedAt *time.Time `` // When result became final (test) or was graded (activity), starts regrade window
+	Adjustment  float64    `` // Points added by term scaling, already included in Points
+	ScalingID   *uint      `` // Active scaling, re-evaluation applies it on new raw points
 
 	CourseItem *CourseItem ``
 	Term       *Term       ``
@@ -33,8 +37,72 @@ type CourseItemResult struct {
 
 	TestInstance     *TestInstance     `` // If is a result of test. Will contain test reference
 	ActivityInstance *ActivityInstance `` // If is a result of activity. Will contain test reference
+	Scaling          *PointScaling     ``
 }
 
 func (CourseItemResult) TableName() string {
 	return "course_item_results"
 }
+
+// RawPoints returns evaluated points without term scaling
+func (r *CourseItemResult) RawPoints() float64 {
+	return roundPoints(r.Points - r.Adjustment)
+}
+
+// SetRawPoints stores evaluated points with active scaling of the result applied on top of them. Results created
+// after scaling was applied (late or paper instances) get the active scaling of their term.
+func (r *CourseItemResult) SetRawPoints(dbRef *gorm.DB, raw float64, pointsMax uint) error {
+	r.Points = raw
+	r.Adjustment = 0
+
+	var scaling PointScaling
+	if r.ScalingID != nil {
+		if err := dbRef.First(&scaling, *r.ScalingID).Error; err != nil {
+			return err
+		}
+	} else {
+		var scalings []*PointScaling
+		if err := dbRef.
+			Where("course_item_id = ?", r.CourseItemID).
+			Where("term_id = ?", r.TermID).
+			Where("active = ?", true).
+			Limit(1).
+			Find(&scalings).Error; err != nil {
+			return err
+		}
+		if len(scalings) == 0 {
+			return nil
+		}
+		scaling = *scalings[0]
+		r.ScalingID = &scaling.ID
+	}
+	r.Points = scaling.Scale(raw, pointsMax)
+	r.Adjustment = roundPoints(r.Points - raw)
+
+	return nil
+}
+
+// AppealDeadline returns end of regrade window or nil, when appeals are disabled or result is not shown yet.
+// CourseItem with TestDetail and Term with releases have to be loaded.
+func (r *CourseItemResult) AppealDeadline() *time.Time {
+	if r.CourseItem == nil || r.CourseItem.AppealDays == 0 || r.EvaluatedAt == nil {
+		return nil
+	}
+
+	shownAt := *r.EvaluatedAt
+	if r.CourseItem.Type == enums.CourseItemTypeTest {
+		if !r.Final || r.CourseItem.TestDetail == nil || r.Term == nil {
+			return nil
+		}
+		releasedAt := r.CourseItem.TestDetail.ReleasedAt(enums.ReleaseLevelResults, r.CourseItemID, r.Term)
+		if releasedAt == nil || releasedAt.After(time.Now()) {
+			return nil
+		}
+		if releasedAt.After(shownAt) {
+			shownAt = *releasedAt
+		}
+	}
+
+	deadline := shownAt.AddDate(0, 0, int(r.CourseItem.AppealDays))
+	return &deadline
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"elogika.vsb.cz/backend/modules/common/enums"
 )
 
@@ -18,9 +20,81 @@ type CourseItemTest struct {
 	IsPaper         bool                   ``
 	IPRanges        string                 ``
 
+	RequireSEB         bool   `` // Test can be written only inside Safe Exam Browser
+	SEBBrowserExamKeys string `` // Allowed browser exam keys separated by ";". When empty, only config key is validated
+	SEBQuitPassword    string ``
+
+	NavigationMode     enums.TestNavigationModeEnum ``
+	QuestionTimeLimits bool                         `` // Each question has its own time window, in free mode all questions share sum of the windows
+
+	BlindGrading bool `` // Student identity is hidden in grading queue
+
+	ModerationPercentage uint    `` // Percentage of instances double-marked by second grader, 0 disables moderation
+	ModerationThreshold  float64 `` // Difference of marks in percent which is flagged for moderator
+
+	// Release of visibility levels, manual mode keeps the Show* flags
+	ResultsReleaseMode     enums.ReleaseModeEnum `gorm:"size:16"`
+	ResultsReleaseAt       *time.Time            ``
+	TestReleaseMode        enums.ReleaseModeEnum `gorm:"size:16"`
+	TestReleaseAt          *time.Time            ``
+	CorrectnessReleaseMode enums.ReleaseModeEnum `gorm:"size:16"`
+	CorrectnessReleaseAt   *time.Time            ``
+
 	TestTemplate *Template ``
 }
 
 func (CourseItemTest) TableName() string {
 	return "course_item_tests"
 }
+
+// ReleaseMode returns mode, scheduled time and manual flag of visibility level
+func (t *CourseItemTest) ReleaseMode(level enums.ReleaseLevelEnum) (enums.ReleaseModeEnum, *time.Time, bool) {
+	mode, releaseAt, flag := enums.ReleaseModeManual, (*time.Time)(nil), false
+	switch level {
+	case enums.ReleaseLevelResults:
+		mode, releaseAt, flag = t.ResultsReleaseMode, t.ResultsReleaseAt, t.ShowResults
+	case enums.ReleaseLevelTest:
+		mode, releaseAt, flag = t.TestReleaseMode, t.TestReleaseAt, t.ShowTest
+	case enums.ReleaseLevelCorrectness:
+		mode, releaseAt, flag = t.CorrectnessReleaseMode, t.CorrectnessReleaseAt, t.ShowCorrectness
+	}
+
+	// Tests created before release modes existed are manual
+	if mode == "" {
+		mode = enums.ReleaseModeManual
+	}
+	return mode, releaseAt, flag
+}
+
+// ReleasedAt returns time since which visibility level of course item is shown to students of term, nil when it is
+// never released by its current settings. Releases of the term have to be loaded for ALL_FINAL mode, term can be shared
+// by tests of one group, so releases are matched by course item as well.
+func (t *CourseItemTest) ReleasedAt(level enums.ReleaseLevelEnum, courseItemID uint, term *Term) *time.Time {
+	mode, releaseAt, flag := t.ReleaseMode(level)
+	switch mode {
+	case enums.ReleaseModeScheduled:
+		return releaseAt
+	case enums.ReleaseModeAllFinal:
+		for _, release := range term.Releases {
+			if release.CourseItemID == courseItemID && release.Level == level {
+				return &release.CreatedAt
+			}
+		}
+		return nil
+	default:
+		if flag {
+			return &time.Time{}
+		}
+		// Hidden results are shown once the term ends
+		if level == enums.ReleaseLevelResults {
+			return &term.ActiveTo
+		}
+		return nil
+	}
+}
+
+// IsReleased reports whether visibility level of course item is shown to students of term at given time
+func (t *CourseItemTest) IsReleased(level enums.ReleaseLevelEnum, courseItemID uint, term *Term, now time.Time) bool {
+	releasedAt := t.ReleasedAt(level, courseItemID, term)
+	return releasedAt != nil && !now.Before(*releasedAt)
+}
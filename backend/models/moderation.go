@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"gorm.io/gorm"
+)
+
+// Moderation is second marking of open answers of one test instance selected into moderation sample
+type Moderation struct {
+	CommonModel
+	ID        uint           `gorm:"primarykey"`
+	CreatedAt time.Time      ``
+	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt ``
+
+	CourseItemID   uint                       ``
+	TermID         uint                       ``
+	TestInstanceID uint                       ``
+	SecondGraderID uint                       ``
+	Status         enums.ModerationStatusEnum `gorm:"size:16"`
+	CompletedAt    *time.Time                 ``
+
+	Marks        []*ModerationMark ``
+	TestInstance *TestInstance     ``
+	SecondGrader *User             ``
+}
+
+func (Moderation) TableName() string {
+	return "moderations"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+type ModerationMark struct {
+	CommonModel
+	ID        uint      `gorm:"primarykey"`
+	CreatedAt time.Time ``
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	ModerationID           uint     ``
+	TestInstanceQuestionID uint     ``
+	FirstPercentage        float64  `` // Percentage of first marker at the time of second marking
+	SecondPercentage       *float64 ``
+	Flagged                bool     `` // Difference of marks exceeds moderation threshold
+	ResolvedByID           *uint    ``
+	ResolvedPercentage     *float64 `` // Percentage decided by moderator
+
+	TestInstanceQuestion *TestInstanceQuestion ``
+}
+
+func (ModerationMark) TableName() string {
+	return "moderation_marks"
+}
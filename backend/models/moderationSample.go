@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// ModerationSample records that moderation sample of term was drawn, even when no instance was selected
+type ModerationSample struct {
+	CommonModel
+	ID        uint      `gorm:"primarykey"`
+	CreatedAt time.Time `` // Time of the first draw
+
+	CourseItemID uint `gorm:"uniqueIndex:ux_moderation_samples_item_term"`
+	TermID       uint `gorm:"uniqueIndex:ux_moderation_samples_item_term"`
+	DrawnByID    uint ``
+}
+
+func (ModerationSample) TableName() string {
+	return "moderation_samples"
+}
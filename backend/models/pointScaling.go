@@ -0,0 +1,61 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// PointScaling is adjustment of all results of a term. Adjustments are stored on results separately
+// from raw points, so scaling can be reverted without losing original evaluation.
+type PointScaling struct {
+	CommonModel
+	ID          uint      `gorm:"primarykey"`
+	CreatedAt   time.Time ``
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	CreatedByID uint      ``
+
+	CourseItemID uint                    ``
+	TermID       uint                    ``
+	Method       enums.ScalingMethodEnum `gorm:"size:16"`
+	Factor       float64                 `` // Multiplier of linear and percentile methods, percentile factor is computed when applied
+	Offset       float64                 ``
+	Percentile   float64                 `` // Percentile (0-100] mapped to threshold
+	Threshold    float64                 ``
+	CapToMax     bool                    `` // Scaled points never exceed maximum of the item
+	Affected     uint                    `` // Number of adjusted results
+
+	Active       bool       ``
+	RevertedAt   *time.Time ``
+	RevertedByID *uint      ``
+
+	CreatedBy  *User ``
+	RevertedBy *User ``
+}
+
+func (PointScaling) TableName() string {
+	return "point_scalings"
+}
+
+// Scale returns scaled points for raw points of the result
+func (s *PointScaling) Scale(raw float64, pointsMax uint) float64 {
+	scaled := raw
+	switch s.Method {
+	case enums.ScalingMethodLinear, enums.ScalingMethodPercentile:
+		scaled = raw * s.Factor
+	case enums.ScalingMethodOffset:
+		scaled = raw + s.Offset
+	}
+
+	if s.CapToMax || s.Method == enums.ScalingMethodCap {
+		scaled = math.Min(scaled, float64(pointsMax))
+	}
+
+	return roundPoints(scaled)
+}
+
+// roundPoints rounds points to two decimals like evaluation does
+func roundPoints(points float64) float64 {
+	return math.RoundToEven(points*100) / 100
+}
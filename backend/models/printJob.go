@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// PrintJob renders tests or answer keys of course item to single PDF in background
+type PrintJob struct {
+	CommonModel
+	ID          uint      `gorm:"primarykey"`
+	CreatedAt   time.Time ``
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	CreatedByID uint      ``
+
+	CourseID     uint                     ``
+	CourseItemID uint                     ``
+	UserRole     enums.CourseUserRoleEnum `` // Role of requester, permissions are checked again when the job runs
+	Status       enums.JobStatusEnum      ``
+	Error        string                   ``
+	FinishedAt   *time.Time               ``
+	Progress     uint                     `` // Number of rendered instances (or variants printed without instances)
+	Total        uint                     ``
+	Folder       string                   `` // Working folder of the job, removed by cleanup
+	OutputPath   string                   `` // Final PDF, empty until the job finishes and after cleanup
+
+	Options    PrintJobOptions  `gorm:"serializer:json;type:varbinary(max)"`
+	CompileLog *PrintCompileLog `gorm:"serializer:json;type:varbinary(max)"` // Set when LaTeX compilation failed
+
+	CourseItem *CourseItem ``
+	CreatedBy  *User       ``
+}
+
+// PrintJobOptions are options of print request
+type PrintJobOptions struct {
+	PrintAnswerSheets    bool  `json:"printAnswerSheets"`
+	SeparateAnswerSheets bool  `json:"separateAnswerSheets"`
+	PrintAnswerKeys      bool  `json:"printAnswerKeys"`
+	TestID               *uint `json:"testId"`
+	InstanceID           *uint `json:"instanceId"`
+	TermID               *uint `json:"termId"`
+
+	Booklets bool            `json:"booklets"` // Print personalized booklets of students joined to term instead of tests
+	Seats    map[uint]string `json:"seats"`    // Seat labels of booklets by test instance ID
+}
+
+// PrintCompileLog describes failed LaTeX compilation
+type PrintCompileLog struct {
+	Message string   `json:"message"`
+	Line    int      `json:"line"`    // Line of generated LaTeX source
+	Context string   `json:"context"` // Part of the line where compilation stopped
+	Source  string   `json:"source"`  // Offending question
+	Timeout bool     `json:"timeout"`
+	Log     []string `json:"log"` // End of compile log
+}
+
+func (PrintJob) TableName() string {
+	return "print_jobs"
+}
@@ -30,6 +30,7 @@ type Question struct {
 	ManagedBy          enums.CourseUserRoleEnum ``                                           // Role of user who manages it
 	Active             bool                     ``                                           // If the question can be picked during test generation
 	AnswerCount        uint                     ``
+	RubricID           *uint                    `` // Rubric used when grading open answers
 
 	QuestionGroup *QuestionGroup   ``
 	Answers       []QuestionAnswer ``
@@ -37,6 +38,7 @@ type Question struct {
 	CreatedBy     *User            ``
 	UpdatedBy     *User            ``
 	CourseLink    *CourseQuestion  ``
+	Rubric        *Rubric          ``
 }
 
 func (Question) TableName() string {
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"gorm.io/gorm"
+)
+
+// QuestionInvalidation changes scoring of broken question in all tests of a term, or only in one test
+type QuestionInvalidation struct {
+	CommonModel
+	ID          uint           `gorm:"primarykey"`
+	CreatedAt   time.Time      ``
+	CreatedByID uint           ``
+	DeletedAt   gorm.DeletedAt ``
+
+	CourseItemID uint                               ``
+	TermID       uint                               ``
+	TestID       *uint                              `` // When empty, invalidation applies to all tests of the term
+	QuestionID   uint                               ``
+	AnswerID     *uint                              `` // Only for answer accepting mode
+	Mode         enums.QuestionInvalidationModeEnum ``
+	Reason       string                             ``
+
+	CourseItem *CourseItem ``
+	Term       *Term       ``
+	Test       *Test       ``
+	Question   *Question   ``
+	Answer     *Answer     ``
+	CreatedBy  *User       ``
+}
+
+func (QuestionInvalidation) TableName() string {
+	return "question_invalidations"
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// RecognizerBatch splits bulk upload of scanned sheets to pages and attaches them to test instances
+type RecognizerBatch struct {
+	CommonModel
+	ID          uint      `gorm:"primarykey"`
+	CreatedAt   time.Time ``
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	CreatedByID uint      ``
+
+	CourseID   uint                     ``
+	UserRole   enums.CourseUserRoleEnum `` // Role of uploader, permissions are checked again for every identified test
+	FileID     uint                     ``
+	Status     enums.JobStatusEnum      ``
+	Error      string                   ``
+	FinishedAt *time.Time               ``
+
+	Report []RecognizerBatchPage `gorm:"serializer:json;type:varbinary(max)"`
+
+	File      *File             ``
+	CreatedBy *User             ``
+	Files     []*RecognizerFile `gorm:"foreignKey:BatchID"`
+}
+
+// RecognizerBatchPage describes how one page of the upload was identified
+type RecognizerBatchPage struct {
+	Page             uint   `json:"page"`
+	Variant          string `json:"variant"` // Content of test identifier QR code
+	Login            string `json:"login"`   // Content of participant QR code
+	TestInstanceID   *uint  `json:"testInstanceId"`
+	StudentName      string `json:"studentName"`
+	RecognizerFileID *uint  `json:"recognizerFileId"`
+	DuplicateOf      *uint  `json:"duplicateOf"` // Page of the same upload with the same sheet of the same instance
+	Error            string `json:"error"`
+}
+
+func (RecognizerBatch) TableName() string {
+	return "recognizer_batches"
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"elogika.vsb.cz/backend/modules/common/enums"
 	"gorm.io/gorm"
 )
 
@@ -18,4 +19,27 @@ type RecognizerFile struct {
 	TestInstanceID uint          ``
 	UniqueIdent    string        ``
 	TestInstance   *TestInstance ``
+
+	BatchID    *uint               `` // Set for pages split from bulk upload
+	Page       uint                `` // Page number within bulk upload
+	Status     enums.JobStatusEnum `` // Recognition state, empty for files uploaded together with recognized data
+	Error      string              ``
+	Confidence float64             `` // Lowest confidence of all marks on the page
+
+	NeedsReview  bool       `` // Page has ambiguous marks, answers are saved once tutor confirms them
+	ReviewedByID *uint      ``
+	ReviewedAt   *time.Time ``
+
+	Questions []RecognizedQuestion `gorm:"serializer:json;type:varbinary(max)"`
+
+	Batch      *RecognizerBatch ``
+	ReviewedBy *User            ``
+}
+
+// RecognizedQuestion holds marks of one question row read by built-in recognizer
+type RecognizedQuestion struct {
+	Order      uint      `json:"order"`
+	Answers    []bool    `json:"answers"`
+	Confidence []float64 `json:"confidence"` // Confidence of every answer between 0 and 1
+	Ambiguous  bool      `json:"ambiguous"`
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// ReevaluationJob recalculates results of all instances affected by scoring change
+type ReevaluationJob struct {
+	CommonModel
+	ID          uint      `gorm:"primarykey"`
+	CreatedAt   time.Time ``
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	CreatedByID uint      ``
+
+	CourseID     uint                `` // Needed to update selected results of students
+	CourseItemID uint                ``
+	TermID       uint                ``
+	TestID       *uint               ``
+	Status       enums.JobStatusEnum ``
+	Error        string              ``
+	FinishedAt   *time.Time          ``
+
+	Report []ReevaluationReportItem `gorm:"serializer:json;type:varbinary(max)"`
+
+	CourseItem *CourseItem ``
+	Term       *Term       ``
+	CreatedBy  *User       ``
+}
+
+// ReevaluationReportItem compares points of one student before and after reevaluation
+type ReevaluationReportItem struct {
+	TestInstanceID uint    `json:"testInstanceId"`
+	StudentID      uint    `json:"studentId"`
+	StudentName    string  `json:"studentName"`
+	PointsBefore   float64 `json:"pointsBefore"`
+	PointsAfter    float64 `json:"pointsAfter"`
+	Error          string  `json:"error,omitempty"`
+}
+
+func (ReevaluationJob) TableName() string {
+	return "reevaluation_jobs"
+}
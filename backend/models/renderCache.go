@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// RenderCache maps rendered LaTeX content to stored file, so identical content is not compiled again
+type RenderCache struct {
+	CommonModel
+	ID         uint      `gorm:"primarykey"`
+	CreatedAt  time.Time ``
+	LastUsedAt time.Time ``
+
+	Kind            string `gorm:"size:8;uniqueIndex:ux_render_caches_key"`  // SVG or PDF
+	ContentHash     string `gorm:"size:64;uniqueIndex:ux_render_caches_key"` // TipTapContent.Hash of rendered content
+	TemplateVersion uint   `gorm:"uniqueIndex:ux_render_caches_key"`         // Version of LaTeX template used for rendering
+	Pages           int    ``                                                // Number of pages of PDF renders
+	FileID          uint   ``
+
+	File *File ``
+}
+
+func (RenderCache) TableName() string {
+	return "render_caches"
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"gorm.io/gorm"
+)
+
+// ResultRelease records that visibility level of a test was released to students of a term. Tests of one group share
+// terms of the group, so releases are distinguished by course item as well.
+type ResultRelease struct {
+	CommonModel
+	ID        uint      `gorm:"primarykey"`
+	CreatedAt time.Time `` // Time of release
+
+	CourseItemID uint                   `gorm:"uniqueIndex:ux_result_releases_item_term_level"`
+	TermID       uint                   `gorm:"uniqueIndex:ux_result_releases_item_term_level"`
+	Level        enums.ReleaseLevelEnum `gorm:"uniqueIndex:ux_result_releases_item_term_level;size:16"`
+	Notified     uint                   `` // Number of notified students
+}
+
+func (ResultRelease) TableName() string {
+	return "result_releases"
+}
+
+// LoadTermReleases fills releases of given terms
+func LoadTermReleases(dbRef *gorm.DB, terms ...*Term) error {
+	termIDs := []uint{}
+	for _, term := range terms {
+		if term != nil {
+			termIDs = append(termIDs, term.ID)
+		}
+	}
+	if len(termIDs) == 0 {
+		return nil
+	}
+
+	var releases []*ResultRelease
+	if err := dbRef.Where("term_id IN ?", termIDs).Find(&releases).Error; err != nil {
+		return err
+	}
+
+	for _, term := range terms {
+		if term == nil {
+			continue
+		}
+		term.Releases = []*ResultRelease{}
+		for _, release := range releases {
+			if release.TermID == term.ID {
+				term.Releases = append(term.Releases, release)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common"
+	"gorm.io/gorm"
+)
+
+type RubricLevel struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Points      float64 `json:"points"`
+}
+
+type RubricCriterion struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Levels      []RubricLevel `json:"levels"`
+}
+
+// RubricSelection is level chosen by grader for one criterion. Names and points are copied
+// from rubric when grading, so later changes of rubric do not alter already graded answers.
+type RubricSelection struct {
+	Criterion     int     `json:"criterion"` // Index of criterion in rubric
+	Level         int     `json:"level"`     // Index of level in criterion
+	Comment       string  `json:"comment"`
+	CriterionName string  `json:"criterionName"`
+	LevelName     string  `json:"levelName"`
+	Points        float64 `json:"points"`
+}
+
+type Rubric struct {
+	CommonModel
+	ID        uint           `gorm:"primarykey"`
+	CreatedAt time.Time      ``
+	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt ``
+	Version   uint           ``
+
+	CourseID    uint              ``
+	CreatedByID uint              ``
+	Name        string            ``
+	Criteria    []RubricCriterion `gorm:"serializer:json;type:varbinary(max)"`
+
+	CreatedBy *User ``
+}
+
+func (Rubric) TableName() string {
+	return "rubrics"
+}
+
+// MaxPoints sums best level of each criterion
+func (r Rubric) MaxPoints() float64 {
+	total := 0.0
+	for _, criterion := range r.Criteria {
+		best := 0.0
+		for _, level := range criterion.Levels {
+			if level.Points > best {
+				best = level.Points
+			}
+		}
+		total += best
+	}
+	return total
+}
+
+// Evaluate validates selections against rubric and returns them filled with names and points
+// together with gained points. Every criterion must have exactly one level selected.
+func (r Rubric) Evaluate(selections []RubricSelection) ([]RubricSelection, float64, *common.ErrorResponse) {
+	if len(selections) != len(r.Criteria) {
+		return nil, 0, &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Level must be selected for every criterion",
+			FormErrors: common.ErrorObject{
+				"rubricSelections": "Level must be selected for every criterion",
+			},
+		}
+	}
+
+	result := make([]RubricSelection, len(selections))
+	used := make(map[int]bool)
+	total := 0.0
+	for i, selection := range selections {
+		if selection.Criterion < 0 || selection.Criterion >= len(r.Criteria) || used[selection.Criterion] {
+			return nil, 0, &common.ErrorResponse{
+				Code:    422,
+				Message: "Validation failed",
+				Details: fmt.Sprintf("Invalid criterion %d", selection.Criterion),
+				FormErrors: common.ErrorObject{
+					"rubricSelections": "Invalid criterion",
+				},
+			}
+		}
+		criterion := r.Criteria[selection.Criterion]
+		if selection.Level < 0 || selection.Level >= len(criterion.Levels) {
+			return nil, 0, &common.ErrorResponse{
+				Code:    422,
+				Message: "Validation failed",
+				Details: fmt.Sprintf("Invalid level %d of criterion %d", selection.Level, selection.Criterion),
+				FormErrors: common.ErrorObject{
+					"rubricSelections": "Invalid level",
+				},
+			}
+		}
+		used[selection.Criterion] = true
+
+		level := criterion.Levels[selection.Level]
+		result[i] = RubricSelection{
+			Criterion:     selection.Criterion,
+			Level:         selection.Level,
+			Comment:       selection.Comment,
+			CriterionName: criterion.Name,
+			LevelName:     level.Name,
+			Points:        level.Points,
+		}
+		total += level.Points
+	}
+
+	return result, total, nil
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// SimilarityJob compares open text answers of students within a term
+type SimilarityJob struct {
+	CommonModel
+	ID          uint      `gorm:"primarykey"`
+	CreatedAt   time.Time ``
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	CreatedByID uint      ``
+
+	CourseID     uint                ``
+	CourseItemID uint                ``
+	TermID       uint                ``
+	Threshold    float64             `` // Minimal similarity (0-1) of reported pairs
+	Status       enums.JobStatusEnum ``
+	Error        string              ``
+	FinishedAt   *time.Time          ``
+	Compared     uint                `` // Number of compared pairs
+
+	Report []SimilarityPair `gorm:"serializer:json;type:varbinary(max)"`
+
+	CourseItem *CourseItem ``
+	CreatedBy  *User       ``
+}
+
+// SimilarityPair is suspicious pair of answers to the same question (or activity)
+type SimilarityPair struct {
+	QuestionID    *uint               `json:"questionId"` // Empty for activities
+	QuestionTitle string              `json:"questionTitle"`
+	SourceAID     uint                `json:"sourceAId"` // Test instance question or activity instance
+	SourceBID     uint                `json:"sourceBId"`
+	StudentAID    uint                `json:"studentAId"`
+	StudentBID    uint                `json:"studentBId"`
+	StudentAName  string              `json:"studentAName"`
+	StudentBName  string              `json:"studentBName"`
+	Similarity    float64             `json:"similarity"`
+	TextA         string              `json:"textA"`
+	TextB         string              `json:"textB"`
+	Passages      []SimilarityPassage `json:"passages"`
+}
+
+// SimilarityPassage marks overlapping passage by UTF-16 offsets into TextA and TextB
+type SimilarityPassage struct {
+	StartA int `json:"startA"`
+	EndA   int `json:"endA"`
+	StartB int `json:"startB"`
+	EndB   int `json:"endB"`
+}
+
+func (SimilarityJob) TableName() string {
+	return "similarity_jobs"
+}
@@ -26,6 +26,8 @@ type TemplateBlock struct {
 	WrongAnswerPercentage uint                         ``
 	AllowEmptyAnswers     bool                         ``
 	MixInsideBlock        bool                         ``
+	QuestionTimeLimit     uint                         `` // Time window for each question in seconds, when 0 it is derived from question time to read and process
+	ScoringStrategy       enums.ScoringStrategyEnum    `` // How ABCD questions in block are scored
 	TemplateID            uint                         ``
 
 	Segments []TemplateBlockSegment `gorm:"foreignKey:TemplateBlockID"`
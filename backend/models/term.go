@@ -30,9 +30,14 @@ type Term struct {
 	PerClass     bool      ``
 	Tries        uint      ``
 
-	Students   []UserTerm  ``
-	Course     *Course     ``
-	CourseItem *CourseItem ``
+	RequiresAccessCode  bool      `` // Students must enter code revealed by supervisor to prepare/start test
+	AccessCode          string    ``
+	AccessCodeRotatedAt time.Time ``
+
+	Students   []UserTerm       ``
+	Course     *Course          ``
+	CourseItem *CourseItem      ``
+	Releases   []*ResultRelease `` // Visibility levels released by rule
 }
 
 func (Term) TableName() string {
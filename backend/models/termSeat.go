@@ -0,0 +1,32 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// TermSeat is seat and test variant assigned to student by seating plan of term. Booklets print the seat on cover
+// and generation creates instance of the variant for students without one.
+type TermSeat struct {
+	CommonModel
+	ID        uint      `gorm:"primarykey"`
+	CreatedAt time.Time ``
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	TermID  uint   `gorm:"uniqueIndex:ux_term_seats_term_user"`
+	UserID  uint   `gorm:"uniqueIndex:ux_term_seats_term_user"`
+	Row     uint   `gorm:"column:seat_row"`
+	Column  uint   `gorm:"column:seat_column"`
+	Variant string `` // Group of test variant, empty when term has no variants
+
+	User *User ``
+}
+
+func (TermSeat) TableName() string {
+	return "term_seats"
+}
+
+// Label returns label of the seat as row/column, the same as seating plan
+func (s TermSeat) Label() string {
+	return fmt.Sprintf("%d/%d", s.Row, s.Column)
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"elogika.vsb.cz/backend/modules/common/enums"
 	"gorm.io/gorm"
 )
 
@@ -36,4 +37,8 @@ type TestBlock struct {
 	Weight                uint   `json:"weight"`
 	WrongAnswerPercentage uint   `json:"wrongAnswerPercentage"`
 	AllowEmptyAnswers     bool   `json:"allowEmptyAnswers"`
+	QuestionTimeLimit     uint   `json:"questionTimeLimit"`
+
+	// Snapshot of block scoring, so later template changes do not alter results of already generated tests
+	ScoringStrategy enums.ScoringStrategyEnum `json:"scoringStrategy"`
 }
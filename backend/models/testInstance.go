@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"elogika.vsb.cz/backend/modules/common/enums"
@@ -13,14 +14,16 @@ type TestInstance struct {
 	CreatedAt time.Time      ``
 	DeletedAt gorm.DeletedAt ``
 
-	State     enums.TestInstanceStateEnum ``
+	State     enums.TestInstanceStateEnum `gorm:"size:16"` // Bounded size, so it can be used in filtered index
 	Form      enums.TestInstanceFormEnum  ``
 	StartedAt time.Time                   `` // Time that the test started at
 	EndsAt    time.Time                   `` // Time until the student must end the instance
 	EndedAt   time.Time                   `` // Time that the intance actually ended (Student finishes test or automatic job marks as finished)
 
+	CurrentQuestionID *uint `` // Question student is currently allowed to answer in sequential navigation modes
+
 	TestID        uint ``
-	ParticipantID uint ``
+	ParticipantID uint `gorm:"uniqueIndex:ux_test_instances_active_participant,where:state = 'ACTIVE' AND deleted_at IS NULL"` // At most one active instance per participant
 	TermID        uint ``
 	CourseItemID  uint ``
 
@@ -39,3 +42,68 @@ type TestInstance struct {
 func (ti TestInstance) IsExpired(timeNow time.Time) bool {
 	return ti.EndedAt.Add(time.Minute*1).Compare(timeNow) > 0
 }
+
+// OrderedQuestions returns questions in the order they are presented to student
+func (ti TestInstance) OrderedQuestions() []*TestInstanceQuestion {
+	questions := slices.Clone(ti.Questions)
+	slices.SortStableFunc(questions, func(a, b *TestInstanceQuestion) int {
+		return int(a.TestQuestion.Order) - int(b.TestQuestion.Order)
+	})
+	return questions
+}
+
+// UnlockedQuestions returns questions student can currently see and answer.
+// In block mode, block is a continuous run of questions with the same block ID.
+func (ti TestInstance) UnlockedQuestions(mode enums.TestNavigationModeEnum) []*TestInstanceQuestion {
+	questions := ti.OrderedQuestions()
+	if !mode.IsSequential() {
+		return questions
+	}
+	if ti.CurrentQuestionID == nil {
+		return []*TestInstanceQuestion{}
+	}
+
+	current := slices.IndexFunc(questions, func(q *TestInstanceQuestion) bool {
+		return q.ID == *ti.CurrentQuestionID
+	})
+	if current == -1 {
+		return []*TestInstanceQuestion{}
+	}
+
+	if mode == enums.TestNavigationModeSequential {
+		return questions[current : current+1]
+	}
+
+	start, end := current, current+1
+	for start > 0 && questions[start-1].TestQuestion.BlockID == questions[current].TestQuestion.BlockID {
+		start--
+	}
+	for end < len(questions) && questions[end].TestQuestion.BlockID == questions[current].TestQuestion.BlockID {
+		end++
+	}
+	return questions[start:end]
+}
+
+func (ti TestInstance) IsQuestionUnlocked(questionID uint, mode enums.TestNavigationModeEnum) bool {
+	return slices.ContainsFunc(ti.UnlockedQuestions(mode), func(q *TestInstanceQuestion) bool {
+		return q.ID == questionID
+	})
+}
+
+// NextQuestion returns first question after currently unlocked ones or nil if student is at the end
+func (ti TestInstance) NextQuestion(mode enums.TestNavigationModeEnum) *TestInstanceQuestion {
+	questions := ti.OrderedQuestions()
+	unlocked := ti.UnlockedQuestions(mode)
+	if len(unlocked) == 0 {
+		if len(questions) == 0 {
+			return nil
+		}
+		return questions[0]
+	}
+
+	last := slices.Index(questions, unlocked[len(unlocked)-1])
+	if last+1 >= len(questions) {
+		return nil
+	}
+	return questions[last+1]
+}
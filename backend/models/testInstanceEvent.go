@@ -14,8 +14,9 @@ type TestInstanceEvent struct {
 	CreatedAt time.Time      ``
 	DeletedAt gorm.DeletedAt ``
 
-	TestInstanceID uint ``
-	UserID         uint ``
+	TestInstanceID *uint `` // Can be empty for events occuring before instance is created
+	TermID         *uint ``
+	UserID         uint  ``
 
 	OccuredAt   time.Time                         ``
 	ReceivedAt  time.Time                         ``
@@ -24,6 +25,7 @@ type TestInstanceEvent struct {
 	EventData   json.RawMessage                   ``
 	PageID      string                            ``
 
-	TestInstance TestInstance ``
-	User         User         ``
+	TestInstance *TestInstance ``
+	Term         *Term         ``
+	User         User          ``
 }
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type TestInstanceQuestion struct {
 	CommonModel
 	ID             uint `gorm:"primarykey"`
@@ -9,9 +11,18 @@ type TestInstanceQuestion struct {
 	TextAnswer             *TipTapContent                `gorm:"serializer:json;type:varbinary(max)"`
 	TextAnswerReviewedByID *uint                         ``
 	TextAnswerPercentage   float64                       ``
+	Feedback               *TipTapContent                `gorm:"serializer:json;type:varbinary(max)"` // Grader comment on the answer
+	FeedbackFiles          []*File                       `gorm:"many2many:test_instance_question_feedback_files;"`
+	AssignedGraderID       *uint                         ``                                           // Grader the answer was assigned to when grading queue is split
+	RubricSelections       []RubricSelection             `gorm:"serializer:json;type:varbinary(max)"` // Rubric levels selected by grader
 	Answers                []*TestInstanceQuestionAnswer ``
 
+	OpenedAt   *time.Time `` // Time the question was unlocked for student in sequential navigation modes
+	DeadlineAt *time.Time `` // Time until answer for question can be saved when question time limits are enabled
+	TimeSpent  uint       `` // Seconds student actually spent on question, in block mode time spent on whole block
+
 	// TextAnswerReviewedBy *User         ``
-	TestInstance *TestInstance ``
-	TestQuestion *TestQuestion ``
+	AssignedGrader *User         ``
+	TestInstance   *TestInstance ``
+	TestQuestion   *TestQuestion ``
 }
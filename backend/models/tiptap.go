@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"gorm.io/gorm/schema"
 )
@@ -52,3 +53,50 @@ func (ttc *TipTapContent) Value(ctx context.Context, field *schema.Field, dst re
 	}
 	return json.Marshal(ttc)
 }
+
+// Equal compares content by its serialized form, nil equals only nil
+func (ttc *TipTapContent) Equal(other *TipTapContent) bool {
+	if ttc == nil || other == nil {
+		return ttc == other
+	}
+	a, errA := ttc.Hash()
+	b, errB := other.Hash()
+	return errA == nil && errB == nil && a == b
+}
+
+// HasText reports if content contains any text. Empty editor document has only empty paragraphs.
+func (ttc *TipTapContent) HasText() bool {
+	if ttc == nil {
+		return false
+	}
+	if ttc.Text != "" {
+		return true
+	}
+	for _, child := range ttc.Content {
+		if child.HasText() {
+			return true
+		}
+	}
+	return false
+}
+
+// PlainText returns text of content, block nodes are separated by new lines
+func (ttc *TipTapContent) PlainText() string {
+	var builder strings.Builder
+	ttc.writePlainText(&builder)
+	return strings.TrimSpace(builder.String())
+}
+
+func (ttc *TipTapContent) writePlainText(builder *strings.Builder) {
+	if ttc == nil {
+		return
+	}
+	builder.WriteString(ttc.Text)
+	for _, child := range ttc.Content {
+		child.writePlainText(builder)
+	}
+	switch ttc.Type {
+	case "paragraph", "heading", "listItem", "codeBlock", "blockquote", "hardBreak":
+		builder.WriteString("\n")
+	}
+}
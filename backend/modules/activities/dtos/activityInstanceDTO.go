@@ -12,9 +12,11 @@ type ActivityInstanceDTO struct {
 	AssignmentDescription    *models.TipTapContent `json:"assignmentDescription" ts_type:"JSONContent"`
 	AssignmentExpectedResult *models.TipTapContent `json:"assignmentExpectedResult" ts_type:"JSONContent"`
 
-	Points    float64 `json:"points"`
-	PointsMin uint    `json:"pointsMin"`
-	PointsMax uint    `json:"pointsMax"`
+	Points           float64                  `json:"points"`
+	RubricSelections []models.RubricSelection `json:"rubricSelections"`
+	Feedback         *models.TipTapContent    `json:"feedback" ts_type:"JSONContent"`
+	PointsMin        uint                     `json:"pointsMin"`
+	PointsMax        uint                     `json:"pointsMax"`
 
 	Editable bool `json:"editable"`
 }
@@ -27,6 +29,8 @@ func (m ActivityInstanceDTO) From(d *models.ActivityInstance, editable bool) Act
 		AssignmentDescription:    d.CourseItem.ActivityDetail.Description,
 		AssignmentExpectedResult: d.CourseItem.ActivityDetail.ExpectedResult,
 		Points:                   d.Result.Points,
+		RubricSelections:         d.RubricSelections,
+		Feedback:                 d.Feedback,
 		PointsMin:                d.CourseItem.PointsMin,
 		PointsMax:                d.CourseItem.PointsMax,
 		Editable:                 editable,
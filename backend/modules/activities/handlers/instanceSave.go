@@ -11,6 +11,7 @@ import (
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
 	"elogika.vsb.cz/backend/repositories"
+	"elogika.vsb.cz/backend/services"
 	services_course_item "elogika.vsb.cz/backend/services/courseItem"
 	"elogika.vsb.cz/backend/utils"
 	"elogika.vsb.cz/backend/utils/tiptap"
@@ -19,8 +20,11 @@ import (
 )
 
 type ActivityInstanceSaveRequest struct {
-	Points  *float64              `json:"points"`
-	Content *models.TipTapContent `json:"content"`
+	Points           *float64                 `json:"points"`
+	Content          *models.TipTapContent    `json:"content"`
+	RubricSelections []models.RubricSelection `json:"rubricSelections"` // Points are computed from rubric when set
+	Feedback         *models.TipTapContent    `json:"feedback"`         // Tutor comment on the result
+	NotifyFeedback   bool                     `json:"notifyFeedback"`   // Notify student when new feedback was added
 }
 
 type ActivityInstanceSaveResponse struct {
@@ -143,8 +147,51 @@ func elevatedSave(dbRef *gorm.DB, activityInstance *models.ActivityInstance, req
 		}
 	}
 
+	if reqData.RubricSelections != nil {
+		points, err := evaluateRubric(dbRef, activityInstance, reqData.RubricSelections)
+		if err != nil {
+			return err
+		}
+		reqData.Points = &points
+
+		if err := dbRef.Model(&activityInstance).Select("rubric_selections").Updates(&activityInstance).Error; err != nil {
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to save rubric selections",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	if reqData.Feedback != nil && !activityInstance.Feedback.Equal(reqData.Feedback) {
+		activityInstance.Feedback = reqData.Feedback
+		if err := dbRef.Model(&activityInstance).Select("feedback").Updates(&activityInstance).Error; err != nil {
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to save feedback",
+				Details: err.Error(),
+			}
+		}
+
+		if reqData.NotifyFeedback && reqData.Feedback.HasText() {
+			if err := services.NewNotificationService().QueueFeedbackNotification(dbRef, activityInstance.ParticipantID, activityInstance.CourseItem.Name); err != nil {
+				return err
+			}
+		}
+	}
+
 	if reqData.Points != nil {
-		activityInstance.Result.Points = *reqData.Points
+		if activityInstance.Result.EvaluatedAt == nil {
+			evaluatedAt := time.Now()
+			activityInstance.Result.EvaluatedAt = &evaluatedAt
+		}
+		if err := activityInstance.Result.SetRawPoints(dbRef, *reqData.Points, activityInstance.CourseItem.PointsMax); err != nil {
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to apply point scaling",
+				Details: err.Error(),
+			}
+		}
 		activityInstance.Result.UpdatedByID = &userId
 		if err := dbRef.Save(&activityInstance.Result).Error; err != nil {
 			return &common.ErrorResponse{
@@ -162,6 +209,41 @@ func elevatedSave(dbRef *gorm.DB, activityInstance *models.ActivityInstance, req
 	return nil
 }
 
+// evaluateRubric stores selections on instance and scales gained rubric points to points of the activity
+func evaluateRubric(dbRef *gorm.DB, activityInstance *models.ActivityInstance, selections []models.RubricSelection) (float64, *common.ErrorResponse) {
+	if activityInstance.CourseItem.ActivityDetail.RubricID == nil {
+		return 0, &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Activity has no rubric",
+			FormErrors: common.ErrorObject{
+				"rubricSelections": "Activity has no rubric",
+			},
+		}
+	}
+
+	var rubric models.Rubric
+	if err := dbRef.Unscoped().First(&rubric, *activityInstance.CourseItem.ActivityDetail.RubricID).Error; err != nil {
+		return 0, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load rubric",
+			Details: err.Error(),
+		}
+	}
+
+	evaluated, points, err := rubric.Evaluate(selections)
+	if err != nil {
+		return 0, err
+	}
+	activityInstance.RubricSelections = evaluated
+
+	maxPoints := rubric.MaxPoints()
+	if maxPoints == 0 {
+		return 0, nil
+	}
+	return points / maxPoints * float64(activityInstance.CourseItem.PointsMax), nil
+}
+
 func studentSave(dbRef *gorm.DB, activityInstance *models.ActivityInstance, reqData *ActivityInstanceSaveRequest, userId uint) *common.ErrorResponse {
 	if activityInstance.ParticipantID != userId {
 		return &common.ErrorResponse{
@@ -0,0 +1,31 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type AppealCommentDTO struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	CreatedBy UserDTO   `json:"createdBy"`
+
+	Content *models.TipTapContent  `json:"content"`
+	Status  enums.AppealStatusEnum `json:"status"` // Set only on decision entries
+}
+
+func (m AppealCommentDTO) From(d *models.AppealComment) AppealCommentDTO {
+	dto := AppealCommentDTO{
+		ID:        d.ID,
+		CreatedAt: d.CreatedAt,
+		Content:   d.Content,
+		Status:    d.Status,
+	}
+	if d.CreatedBy != nil {
+		dto.CreatedBy = UserDTO{}.From(d.CreatedBy)
+	}
+
+	return dto
+}
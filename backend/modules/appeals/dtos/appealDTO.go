@@ -0,0 +1,83 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type AppealDTO struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Student   UserDTO   `json:"student"`
+
+	CourseItemID           uint                   `json:"courseItemId"`
+	CourseItemName         string                 `json:"courseItemName"`
+	TermID                 uint                   `json:"termId"`
+	TermName               string                 `json:"termName"`
+	ResultID               uint                   `json:"resultId"`
+	TestInstanceID         *uint                  `json:"testInstanceId"`
+	ActivityInstanceID     *uint                  `json:"activityInstanceId"`
+	TestInstanceQuestionID *uint                  `json:"testInstanceQuestionId"`
+	QuestionTitle          string                 `json:"questionTitle"`
+	Reason                 *models.TipTapContent  `json:"reason"`
+	Status                 enums.AppealStatusEnum `json:"status"`
+	Deadline               *time.Time             `json:"deadline"` // End of regrade window of the result
+	Editable               bool                   `json:"editable"` // User may decide the appeal
+
+	DecidedBy     *UserDTO   `json:"decidedBy"`
+	DecidedAt     *time.Time `json:"decidedAt"`
+	PointsBefore  float64    `json:"pointsBefore"`
+	PointsAfter   *float64   `json:"pointsAfter"`
+	CurrentPoints float64    `json:"currentPoints"`
+
+	Comments []AppealCommentDTO `json:"comments"`
+}
+
+func (m AppealDTO) From(d *models.Appeal, editable bool) AppealDTO {
+	dto := AppealDTO{
+		ID:                     d.ID,
+		CreatedAt:              d.CreatedAt,
+		UpdatedAt:              d.UpdatedAt,
+		CourseItemID:           d.CourseItemID,
+		TermID:                 d.TermID,
+		ResultID:               d.ResultID,
+		TestInstanceQuestionID: d.TestInstanceQuestionID,
+		Reason:                 d.Reason,
+		Status:                 d.Status,
+		Editable:               editable,
+		DecidedAt:              d.DecidedAt,
+		PointsBefore:           d.PointsBefore,
+		PointsAfter:            d.PointsAfter,
+		Comments:               []AppealCommentDTO{},
+	}
+	if d.CreatedBy != nil {
+		dto.Student = UserDTO{}.From(d.CreatedBy)
+	}
+	if d.CourseItem != nil {
+		dto.CourseItemName = d.CourseItem.Name
+	}
+	if d.Term != nil {
+		dto.TermName = d.Term.Name
+	}
+	if d.Result != nil {
+		dto.TestInstanceID = d.Result.TestInstanceID
+		dto.ActivityInstanceID = d.Result.ActivityInstanceID
+		dto.CurrentPoints = d.Result.Points
+		dto.Deadline = d.Result.AppealDeadline()
+	}
+	if d.TestInstanceQuestion != nil && d.TestInstanceQuestion.TestQuestion != nil && d.TestInstanceQuestion.TestQuestion.Question != nil {
+		dto.QuestionTitle = d.TestInstanceQuestion.TestQuestion.Question.Title
+	}
+	if d.DecidedBy != nil {
+		decidedBy := UserDTO{}.From(d.DecidedBy)
+		dto.DecidedBy = &decidedBy
+	}
+	for _, comment := range d.Comments {
+		dto.Comments = append(dto.Comments, AppealCommentDTO{}.From(comment))
+	}
+
+	return dto
+}
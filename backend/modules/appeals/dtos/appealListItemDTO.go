@@ -0,0 +1,53 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type AppealListItemDTO struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Student   UserDTO   `json:"student"`
+
+	CourseItemID           uint                   `json:"courseItemId"`
+	CourseItemName         string                 `json:"courseItemName"`
+	TermID                 uint                   `json:"termId"`
+	TermName               string                 `json:"termName"`
+	ResultID               uint                   `json:"resultId"`
+	TestInstanceQuestionID *uint                  `json:"testInstanceQuestionId"`
+	Status                 enums.AppealStatusEnum `json:"status"`
+	DecidedAt              *time.Time             `json:"decidedAt"`
+	PointsBefore           float64                `json:"pointsBefore"`
+	PointsAfter            *float64               `json:"pointsAfter"`
+}
+
+func (m AppealListItemDTO) From(d *models.Appeal) AppealListItemDTO {
+	dto := AppealListItemDTO{
+		ID:                     d.ID,
+		CreatedAt:              d.CreatedAt,
+		UpdatedAt:              d.UpdatedAt,
+		CourseItemID:           d.CourseItemID,
+		TermID:                 d.TermID,
+		ResultID:               d.ResultID,
+		TestInstanceQuestionID: d.TestInstanceQuestionID,
+		Status:                 d.Status,
+		DecidedAt:              d.DecidedAt,
+		PointsBefore:           d.PointsBefore,
+		PointsAfter:            d.PointsAfter,
+	}
+	if d.CreatedBy != nil {
+		dto.Student = UserDTO{}.From(d.CreatedBy)
+	}
+	if d.CourseItem != nil {
+		dto.CourseItemName = d.CourseItem.Name
+	}
+	if d.Term != nil {
+		dto.TermName = d.Term.Name
+	}
+
+	return dto
+}
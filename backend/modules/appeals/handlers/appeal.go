@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"gorm.io/gorm"
+)
+
+// loadAppeal loads appeal with conversation. Students see only their own appeals, staff appeals
+// of course items they can edit. Returned flag tells whether user may decide the appeal.
+func loadAppeal(dbRef *gorm.DB, courseID uint, appealID uint, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) (*models.Appeal, bool, *common.ErrorResponse) {
+	var appeal models.Appeal
+	if err := dbRef.
+		Preload("CreatedBy").
+		Preload("DecidedBy").
+		Preload("CourseItem").
+		Preload("Term").
+		Preload("Result.CourseItem.TestDetail").
+		Preload("Result.Term.Releases").
+		Preload("TestInstanceQuestion.TestQuestion.Question", dbRef.Unscoped()).
+		Preload("Comments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Comments.CreatedBy").
+		Where("course_id = ?", courseID).
+		First(&appeal, appealID).Error; err != nil {
+		return nil, false, &common.ErrorResponse{
+			Code:    404,
+			Message: "Appeal not found",
+			Details: err.Error(),
+		}
+	}
+
+	switch userRole {
+	case enums.CourseUserRoleStudent:
+		if appeal.CreatedByID != userData.ID {
+			return nil, false, &common.ErrorResponse{
+				Code:    403,
+				Message: "Not enough permissions",
+			}
+		}
+		return &appeal, false, nil
+	case enums.CourseUserRoleAdmin, enums.CourseUserRoleGarant, enums.CourseUserRoleTutor:
+		courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+		courseItem, err := courseItemService.GetCourseItemByID(dbRef, courseID, appeal.CourseItemID, userData.ID, userRole, nil, false, nil)
+		if err != nil {
+			return nil, false, err
+		}
+		if !courseItem.Editable {
+			return nil, false, &common.ErrorResponse{
+				Code:    403,
+				Message: "Not enough permissions",
+			}
+		}
+		return &appeal, true, nil
+	default:
+		return nil, false, &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+}
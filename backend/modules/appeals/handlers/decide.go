@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/appeals/dtos"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	testHandlers "elogika.vsb.cz/backend/modules/tests/handlers"
+	"elogika.vsb.cz/backend/services"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"elogika.vsb.cz/backend/utils/tiptap"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// @Description Tutor decision of regrade request
+type AppealDecisionRequest struct {
+	Status               enums.AppealStatusEnum `json:"status" binding:"required"`                // ACCEPTED or REJECTED
+	Comment              *models.TipTapContent  `json:"comment" ts_type:"JSONContent"`            // Explanation kept in conversation
+	TextAnswerPercentage *float64               `json:"textAnswerPercentage" validate:"optional"` // New mark of contested open question
+	Points               *float64               `json:"points" validate:"optional"`               // New raw points of whole result before term scaling, for tests the difference is added to bonus points
+}
+
+type AppealDecisionResponse struct {
+	Data dtos.AppealDTO `json:"data"`
+}
+
+// @Summary Decides regrade request. Accepted request may change points, which re-evaluates the result.
+// @Tags Appeals
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param appealId path int true "ID of the appeal"
+// @Param body body AppealDecisionRequest true "Decision"
+// @Success 200 {object} AppealDecisionResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Appeal not found"
+// @Failure 409 {object} common.ErrorResponse "Appeal is already decided"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/appeals/{appealId}/decision [put]
+func Decide(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			AppealID uint `uri:"appealId" binding:"required"`
+		},
+		AppealDecisionRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	appeal, editable, err := loadAppeal(initializers.DB, params.CourseID, params.AppealID, userData, userRole)
+	if err != nil {
+		return err
+	}
+	if !editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+	if appeal.Status != enums.AppealStatusOpen {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Appeal is already decided",
+		}
+	}
+
+	if err := validateDecision(appeal, reqData); err != nil {
+		return err
+	}
+
+	transaction := initializers.DB.Begin()
+
+	// Appeal is claimed by conditional status update, so concurrent decisions cannot both apply marks and points
+	decidedAt := time.Now()
+	appeal.Status = reqData.Status
+	appeal.DecidedByID = &userData.ID
+	appeal.DecidedAt = &decidedAt
+	claim := transaction.
+		Model(appeal).
+		Where("status = ?", enums.AppealStatusOpen).
+		Select("status", "decided_by_id", "decided_at").
+		Updates(appeal)
+	if claim.Error != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save decision",
+			Details: claim.Error.Error(),
+		}
+	}
+	if claim.RowsAffected == 0 {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Appeal is already decided",
+		}
+	}
+
+	if reqData.Status == enums.AppealStatusAccepted {
+		if err := applyDecision(transaction, appeal, reqData, userData); err != nil {
+			transaction.Rollback()
+			return err
+		}
+	}
+
+	var result models.CourseItemResult
+	if err := transaction.First(&result, appeal.ResultID).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load result",
+			Details: err.Error(),
+		}
+	}
+
+	appeal.PointsAfter = &result.Points
+	if err := transaction.
+		Model(appeal).
+		Select("points_after").
+		Updates(appeal).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save decision",
+			Details: err.Error(),
+		}
+	}
+
+	// Decision is part of conversation, so the audit trail reads in order
+	comment := models.AppealComment{
+		CreatedByID: userData.ID,
+		AppealID:    appeal.ID,
+		Content:     reqData.Comment,
+		Status:      reqData.Status,
+	}
+	if err := transaction.Create(&comment).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save appeal comment",
+			Details: err.Error(),
+		}
+	}
+
+	if reqData.Comment != nil {
+		if err := tiptap.FindAndSaveRelations(transaction, userData.ID, reqData.Comment, &comment, "ContentFiles"); err != nil {
+			transaction.Rollback()
+			return err
+		}
+
+		if err := transaction.Model(&comment).Select("content").Updates(&comment).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to update appeal comment",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	if err := services.NewNotificationService().QueueAppealDecisionNotification(transaction, appeal.CreatedByID, appeal.CourseItem.Name, reqData.Status); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	appeal, editable, err = loadAppeal(initializers.DB, params.CourseID, params.AppealID, userData, userRole)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(200, AppealDecisionResponse{
+		Data: dtos.AppealDTO{}.From(appeal, editable),
+	})
+
+	return nil
+}
+
+func validateDecision(appeal *models.Appeal, reqData *AppealDecisionRequest) *common.ErrorResponse {
+	formErrors := common.ErrorObject{}
+
+	if reqData.Status != enums.AppealStatusAccepted && reqData.Status != enums.AppealStatusRejected {
+		formErrors["status"] = "Appeal can be only accepted or rejected"
+	}
+
+	if reqData.TextAnswerPercentage != nil {
+		if reqData.Status != enums.AppealStatusAccepted {
+			formErrors["textAnswerPercentage"] = "Mark can be changed only when appeal is accepted"
+		} else if appeal.TestInstanceQuestion == nil || appeal.TestInstanceQuestion.TestQuestion.Question.QuestionFormat != enums.QuestionFormatOpen {
+			formErrors["textAnswerPercentage"] = "Mark can be changed only for open question"
+		} else if *reqData.TextAnswerPercentage < 0 || *reqData.TextAnswerPercentage > 100 {
+			formErrors["textAnswerPercentage"] = "Percentage must be between 0 and 100"
+		}
+	}
+
+	if reqData.Points != nil && reqData.Status != enums.AppealStatusAccepted {
+		formErrors["points"] = "Points can be changed only when appeal is accepted"
+	}
+
+	if len(formErrors) > 0 {
+		return &common.ErrorResponse{
+			Code:       422,
+			Message:    "Validation failed",
+			FormErrors: formErrors,
+		}
+	}
+
+	return nil
+}
+
+// applyDecision changes marks and points of accepted appeal and re-evaluates affected result
+func applyDecision(dbRef *gorm.DB, appeal *models.Appeal, reqData *AppealDecisionRequest, userData authdtos.LoggedUserDTO) *common.ErrorResponse {
+	result := appeal.Result
+
+	if reqData.TextAnswerPercentage != nil {
+		ti_q := appeal.TestInstanceQuestion
+		ti_q.TextAnswerPercentage = *reqData.TextAnswerPercentage
+		ti_q.TextAnswerReviewedByID = &userData.ID
+		if err := dbRef.
+			Model(ti_q).
+			Select("text_answer_percentage", "text_answer_reviewed_by_id").
+			Updates(ti_q).Error; err != nil {
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to save mark",
+				Details: err.Error(),
+			}
+		}
+
+		if err := testHandlers.EvaluateTestInstance(dbRef, ti_q.TestInstanceID, &userData, false); err != nil {
+			return err
+		}
+	}
+
+	if reqData.Points != nil {
+		if result.TestInstanceID != nil {
+			if err := adjustBonusPoints(dbRef, appeal, *reqData.Points, userData); err != nil {
+				return err
+			}
+		} else {
+			if err := result.SetRawPoints(dbRef, *reqData.Points, appeal.CourseItem.PointsMax); err != nil {
+				return &common.ErrorResponse{
+					Code:    500,
+					Message: "Failed to apply point scaling",
+					Details: err.Error(),
+				}
+			}
+			result.UpdatedByID = &userData.ID
+			if err := dbRef.
+				Model(result).
+				Select("points", "adjustment", "scaling_id", "updated_by_id").
+				Updates(result).Error; err != nil {
+				return &common.ErrorResponse{
+					Code:    500,
+					Message: "Failed to save result",
+					Details: err.Error(),
+				}
+			}
+		}
+	}
+
+	rootCourseItem := appeal.CourseItem.ID
+	if appeal.CourseItem.ParentID != nil {
+		rootCourseItem = *appeal.CourseItem.ParentID
+	}
+
+	return services_course_item.UpdateSelectedResults(dbRef, appeal.CourseID, rootCourseItem, appeal.CreatedByID)
+}
+
+// adjustBonusPoints moves test result to requested points through bonus points, so evaluation of questions stays intact
+func adjustBonusPoints(dbRef *gorm.DB, appeal *models.Appeal, points float64, userData authdtos.LoggedUserDTO) *common.ErrorResponse {
+	var testInstance models.TestInstance
+	if err := dbRef.
+		Joins("Result").
+		First(&testInstance, *appeal.Result.TestInstanceID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load test instance",
+			Details: err.Error(),
+		}
+	}
+
+	testInstance.BonusPoints = utils.RoundToEven(testInstance.BonusPoints+points-testInstance.Result.RawPoints(), 2)
+	reason := fmt.Sprintf("Regrade request #%d", appeal.ID)
+	if testInstance.BonusPointsReason != "" {
+		reason = testInstance.BonusPointsReason + "; " + reason
+	}
+	testInstance.BonusPointsReason = reason
+
+	if err := dbRef.
+		Model(&testInstance).
+		Select("bonus_points", "bonus_points_reason").
+		Updates(&testInstance).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save bonus points",
+			Details: err.Error(),
+		}
+	}
+
+	rawData, _ := json.Marshal(map[string]interface{}{
+		"points": testInstance.BonusPoints,
+		"reason": testInstance.BonusPointsReason,
+	})
+	event := models.TestInstanceEvent{
+		TestInstanceID: &testInstance.ID,
+		UserID:         userData.ID,
+		OccuredAt:      time.Now(),
+		ReceivedAt:     time.Now(),
+		EventSource:    enums.TestInstanceEventSourceServer,
+		EventType:      enums.TestInstanceEventTypeBonusPointsModified,
+		EventData:      rawData,
+	}
+	if err := dbRef.Create(&event).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save test event",
+			Details: err.Error(),
+		}
+	}
+
+	return testHandlers.EvaluateTestInstance(dbRef, testInstance.ID, &userData, false)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/modules/appeals/dtos"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AppealGetByIdResponse struct {
+	Data dtos.AppealDTO `json:"data"`
+}
+
+// @Summary Gets regrade request with whole conversation
+// @Tags Appeals
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param appealId path int true "ID of the appeal"
+// @Success 200 {object} AppealGetByIdResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Appeal not found"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/appeals/{appealId} [get]
+func GetByID(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			AppealID uint `uri:"appealId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	appeal, editable, err := loadAppeal(initializers.DB, params.CourseID, params.AppealID, userData, userRole)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(200, AppealGetByIdResponse{
+		Data: dtos.AppealDTO{}.From(appeal, editable),
+	})
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils"
+	"elogika.vsb.cz/backend/utils/tiptap"
+	"github.com/gin-gonic/gin"
+)
+
+// @Description Request to regrade result or one of its questions
+type AppealInsertRequest struct {
+	ResultID               uint                  `json:"resultId" binding:"required"`
+	TestInstanceQuestionID *uint                 `json:"testInstanceQuestionId"` // Contested question, whole result when empty
+	Reason                 *models.TipTapContent `json:"reason" binding:"required" ts_type:"JSONContent"`
+}
+
+// @Description Newly created appeal
+type AppealInsertResponse struct {
+	ID uint `json:"id"`
+}
+
+// @Summary Creates regrade request of own result
+// @Tags Appeals
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param body body AppealInsertRequest true "Contested result and reason"
+// @Success 200 {object} AppealInsertResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Result not found"
+// @Failure 409 {object} common.ErrorResponse "Regrade window is closed or appeal already exists"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/appeals [post]
+func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		AppealInsertRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole != enums.CourseUserRoleStudent {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Only students can request regrade",
+		}
+	}
+
+	var result models.CourseItemResult
+	if err := initializers.DB.
+		InnerJoins("CourseItem", initializers.DB.Where("CourseItem.course_id = ?", params.CourseID)).
+		Preload("CourseItem.TestDetail").
+		InnerJoins("Term").
+		Where("course_item_results.student_id = ?", userData.ID).
+		First(&result, reqData.ResultID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Result not found",
+			Details: err.Error(),
+		}
+	}
+
+	if err := models.LoadTermReleases(initializers.DB, result.Term); err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to fetch result releases",
+			Details: err.Error(),
+		}
+	}
+
+	deadline := result.AppealDeadline()
+	if deadline == nil {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Regrade is not available for this result",
+		}
+	}
+	if time.Now().After(*deadline) {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Regrade window is closed",
+		}
+	}
+
+	if !reqData.Reason.HasText() {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			FormErrors: common.ErrorObject{
+				"reason": "Reason is required",
+			},
+		}
+	}
+
+	if reqData.TestInstanceQuestionID != nil {
+		var count int64
+		if result.TestInstanceID != nil {
+			initializers.DB.
+				Model(&models.TestInstanceQuestion{}).
+				Where("id = ?", *reqData.TestInstanceQuestionID).
+				Where("test_instance_id = ?", *result.TestInstanceID).
+				Count(&count)
+		}
+		if count == 0 {
+			return &common.ErrorResponse{
+				Code:    422,
+				Message: "Validation failed",
+				FormErrors: common.ErrorObject{
+					"testInstanceQuestionId": "Question does not belong to the result",
+				},
+			}
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	var openCount int64
+	openQuery := transaction.
+		Model(&models.Appeal{}).
+		Where("result_id = ?", result.ID).
+		Where("status = ?", enums.AppealStatusOpen)
+	if reqData.TestInstanceQuestionID != nil {
+		openQuery = openQuery.Where("test_instance_question_id = ?", *reqData.TestInstanceQuestionID)
+	} else {
+		openQuery = openQuery.Where("test_instance_question_id IS NULL")
+	}
+	if err := openQuery.Count(&openCount).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load appeals",
+			Details: err.Error(),
+		}
+	}
+	if openCount > 0 {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Appeal is already open",
+		}
+	}
+
+	appeal := models.Appeal{
+		CreatedByID:            userData.ID,
+		CourseID:               params.CourseID,
+		CourseItemID:           result.CourseItemID,
+		TermID:                 result.TermID,
+		ResultID:               result.ID,
+		TestInstanceQuestionID: reqData.TestInstanceQuestionID,
+		Reason:                 reqData.Reason,
+		Status:                 enums.AppealStatusOpen,
+		PointsBefore:           result.Points,
+	}
+	if err := transaction.Create(&appeal).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to create appeal",
+			Details: err.Error(),
+		}
+	}
+
+	if err := tiptap.FindAndSaveRelations(transaction, userData.ID, reqData.Reason, &appeal, "ReasonFiles"); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Model(&appeal).Select("reason").Updates(&appeal).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to update appeal",
+			Details: err.Error(),
+		}
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, AppealInsertResponse{
+		ID: appeal.ID,
+	})
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils"
+	"elogika.vsb.cz/backend/utils/tiptap"
+	"github.com/gin-gonic/gin"
+)
+
+// @Description Message added to appeal conversation
+type AppealCommentInsertRequest struct {
+	Content *models.TipTapContent `json:"content" binding:"required" ts_type:"JSONContent"`
+}
+
+type AppealCommentInsertResponse struct {
+	Success bool `json:"success"`
+}
+
+// @Summary Adds message to conversation of open regrade request
+// @Tags Appeals
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param appealId path int true "ID of the appeal"
+// @Param body body AppealCommentInsertRequest true "Message"
+// @Success 200 {object} AppealCommentInsertResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Appeal not found"
+// @Failure 409 {object} common.ErrorResponse "Appeal is already decided"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/appeals/{appealId}/comment [put]
+func CommentInsert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			AppealID uint `uri:"appealId" binding:"required"`
+		},
+		AppealCommentInsertRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	appeal, _, err := loadAppeal(initializers.DB, params.CourseID, params.AppealID, userData, userRole)
+	if err != nil {
+		return err
+	}
+	if appeal.Status != enums.AppealStatusOpen {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Appeal is already decided",
+		}
+	}
+	if !reqData.Content.HasText() {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			FormErrors: common.ErrorObject{
+				"content": "Message is required",
+			},
+		}
+	}
+
+	comment := models.AppealComment{
+		CreatedByID: userData.ID,
+		AppealID:    appeal.ID,
+		Content:     reqData.Content,
+	}
+	transaction := initializers.DB.Begin()
+
+	if err := transaction.Create(&comment).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save appeal comment",
+			Details: err.Error(),
+		}
+	}
+
+	if err := tiptap.FindAndSaveRelations(transaction, userData.ID, reqData.Content, &comment, "ContentFiles"); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Model(&comment).Select("content").Updates(&comment).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to update appeal comment",
+			Details: err.Error(),
+		}
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, AppealCommentInsertResponse{
+		Success: true,
+	})
+
+	return nil
+}
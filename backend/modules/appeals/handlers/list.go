@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"slices"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/appeals/dtos"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AppealListResponse struct {
+	Items []dtos.AppealListItemDTO `json:"items"`
+}
+
+// @Summary Lists regrade requests of course. Students get their own requests, staff requests of course items they can edit.
+// @Tags Appeals
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param status query string false "Only appeals in given status"
+// @Success 200 {object} AppealListResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/appeals [get]
+func List(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	query := initializers.DB.
+		Preload("CreatedBy").
+		Preload("CourseItem").
+		Preload("Term").
+		Where("course_id = ?", params.CourseID).
+		Order("created_at DESC")
+
+	if status := enums.AppealStatusEnum(c.Query("status")); status != "" {
+		if !slices.Contains(enums.AppealStatusEnumAll, status) {
+			return &common.ErrorResponse{
+				Code:    422,
+				Message: "Validation failed",
+				FormErrors: common.ErrorObject{
+					"status": "Unknown appeal status",
+				},
+			}
+		}
+		query = query.Where("status = ?", status)
+	}
+
+	switch userRole {
+	case enums.CourseUserRoleStudent:
+		query = query.Where("created_by_id = ?", userData.ID)
+	case enums.CourseUserRoleAdmin, enums.CourseUserRoleGarant, enums.CourseUserRoleTutor:
+	default:
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	var appeals []*models.Appeal
+	if err := query.Find(&appeals).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load appeals",
+			Details: err.Error(),
+		}
+	}
+
+	// Staff sees only appeals of course items they manage
+	editable := map[uint]bool{}
+	if userRole != enums.CourseUserRoleStudent {
+		courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+		for _, appeal := range appeals {
+			if _, ok := editable[appeal.CourseItemID]; ok {
+				continue
+			}
+			courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, appeal.CourseItemID, userData.ID, userRole, nil, false, nil)
+			editable[appeal.CourseItemID] = err == nil && courseItem.Editable
+		}
+	}
+
+	items := []dtos.AppealListItemDTO{}
+	for _, appeal := range appeals {
+		if userRole != enums.CourseUserRoleStudent && !editable[appeal.CourseItemID] {
+			continue
+		}
+		items = append(items, dtos.AppealListItemDTO{}.From(appeal))
+	}
+
+	c.JSON(200, AppealListResponse{
+		Items: items,
+	})
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package appeals
+
+import (
+	"elogika.vsb.cz/backend/modules/appeals/handlers"
+	"elogika.vsb.cz/backend/modules/auth/wrappers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("courses/:courseId/appeals", wrappers.WithUserDataRole(handlers.List))
+	rg.POST("courses/:courseId/appeals", wrappers.WithUserDataRole(handlers.Insert))
+	rg.GET("courses/:courseId/appeals/:appealId", wrappers.WithUserDataRole(handlers.GetByID))
+	rg.PUT("courses/:courseId/appeals/:appealId/comment", wrappers.WithUserDataRole(handlers.CommentInsert))
+	rg.PUT("courses/:courseId/appeals/:appealId/decision", wrappers.WithUserDataRole(handlers.Decide))
+}
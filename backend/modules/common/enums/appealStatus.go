@@ -0,0 +1,28 @@
+package enums
+
+type AppealStatusEnum string
+
+const (
+	AppealStatusOpen     AppealStatusEnum = "OPEN"     // Waiting for tutor decision
+	AppealStatusAccepted AppealStatusEnum = "ACCEPTED" // Tutor agreed, points may have changed
+	AppealStatusRejected AppealStatusEnum = "REJECTED" // Tutor kept original points
+)
+
+var AppealStatusEnumAll = []AppealStatusEnum{
+	AppealStatusOpen,
+	AppealStatusAccepted,
+	AppealStatusRejected,
+}
+
+func (w AppealStatusEnum) TSName() string {
+	switch w {
+	case AppealStatusOpen:
+		return "OPEN"
+	case AppealStatusAccepted:
+		return "ACCEPTED"
+	case AppealStatusRejected:
+		return "REJECTED"
+	default:
+		return "???"
+	}
+}
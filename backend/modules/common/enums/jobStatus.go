@@ -0,0 +1,32 @@
+package enums
+
+type JobStatusEnum string
+
+const (
+	JobStatusPending  JobStatusEnum = "PENDING"
+	JobStatusRunning  JobStatusEnum = "RUNNING"
+	JobStatusFinished JobStatusEnum = "FINISHED"
+	JobStatusFailed   JobStatusEnum = "FAILED"
+)
+
+var JobStatusEnumAll = []JobStatusEnum{
+	JobStatusPending,
+	JobStatusRunning,
+	JobStatusFinished,
+	JobStatusFailed,
+}
+
+func (w JobStatusEnum) TSName() string {
+	switch w {
+	case JobStatusPending:
+		return "PENDING"
+	case JobStatusRunning:
+		return "RUNNING"
+	case JobStatusFinished:
+		return "FINISHED"
+	case JobStatusFailed:
+		return "FAILED"
+	default:
+		return "???"
+	}
+}
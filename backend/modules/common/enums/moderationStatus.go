@@ -0,0 +1,28 @@
+package enums
+
+type ModerationStatusEnum string
+
+const (
+	ModerationStatusPending   ModerationStatusEnum = "PENDING"   // Waiting for second marker
+	ModerationStatusFlagged   ModerationStatusEnum = "FLAGGED"   // Markers disagree, waiting for moderator
+	ModerationStatusCompleted ModerationStatusEnum = "COMPLETED" // Marks agreed or moderator resolved disagreement
+)
+
+var ModerationStatusEnumAll = []ModerationStatusEnum{
+	ModerationStatusPending,
+	ModerationStatusFlagged,
+	ModerationStatusCompleted,
+}
+
+func (w ModerationStatusEnum) TSName() string {
+	switch w {
+	case ModerationStatusPending:
+		return "PENDING"
+	case ModerationStatusFlagged:
+		return "FLAGGED"
+	case ModerationStatusCompleted:
+		return "COMPLETED"
+	default:
+		return "???"
+	}
+}
@@ -0,0 +1,28 @@
+package enums
+
+type QuestionInvalidationModeEnum string
+
+const (
+	QuestionInvalidationModeDrop         QuestionInvalidationModeEnum = "DROP"          // Question is not scored at all
+	QuestionInvalidationModeFullCredit   QuestionInvalidationModeEnum = "FULL_CREDIT"   // Everyone gets full score for question
+	QuestionInvalidationModeAcceptAnswer QuestionInvalidationModeEnum = "ACCEPT_ANSWER" // Answer is accepted as correct, whether it is selected or not
+)
+
+var QuestionInvalidationModeEnumAll = []QuestionInvalidationModeEnum{
+	QuestionInvalidationModeDrop,
+	QuestionInvalidationModeFullCredit,
+	QuestionInvalidationModeAcceptAnswer,
+}
+
+func (w QuestionInvalidationModeEnum) TSName() string {
+	switch w {
+	case QuestionInvalidationModeDrop:
+		return "DROP"
+	case QuestionInvalidationModeFullCredit:
+		return "FULL_CREDIT"
+	case QuestionInvalidationModeAcceptAnswer:
+		return "ACCEPT_ANSWER"
+	default:
+		return "???"
+	}
+}
@@ -0,0 +1,28 @@
+package enums
+
+type ReleaseLevelEnum string
+
+const (
+	ReleaseLevelResults     ReleaseLevelEnum = "RESULTS"     // Points of the test
+	ReleaseLevelTest        ReleaseLevelEnum = "TEST"        // Questions and given answers
+	ReleaseLevelCorrectness ReleaseLevelEnum = "CORRECTNESS" // Which answers were correct
+)
+
+var ReleaseLevelEnumAll = []ReleaseLevelEnum{
+	ReleaseLevelResults,
+	ReleaseLevelTest,
+	ReleaseLevelCorrectness,
+}
+
+func (w ReleaseLevelEnum) TSName() string {
+	switch w {
+	case ReleaseLevelResults:
+		return "RESULTS"
+	case ReleaseLevelTest:
+		return "TEST"
+	case ReleaseLevelCorrectness:
+		return "CORRECTNESS"
+	default:
+		return "???"
+	}
+}
@@ -0,0 +1,28 @@
+package enums
+
+type ReleaseModeEnum string
+
+const (
+	ReleaseModeManual    ReleaseModeEnum = "MANUAL"    // Visibility follows the boolean flag
+	ReleaseModeScheduled ReleaseModeEnum = "SCHEDULED" // Released at given time
+	ReleaseModeAllFinal  ReleaseModeEnum = "ALL_FINAL" // Released once all results of the term are final
+)
+
+var ReleaseModeEnumAll = []ReleaseModeEnum{
+	ReleaseModeManual,
+	ReleaseModeScheduled,
+	ReleaseModeAllFinal,
+}
+
+func (w ReleaseModeEnum) TSName() string {
+	switch w {
+	case ReleaseModeManual:
+		return "MANUAL"
+	case ReleaseModeScheduled:
+		return "SCHEDULED"
+	case ReleaseModeAllFinal:
+		return "ALL_FINAL"
+	default:
+		return "???"
+	}
+}
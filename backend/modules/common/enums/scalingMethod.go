@@ -0,0 +1,32 @@
+package enums
+
+type ScalingMethodEnum string
+
+const (
+	ScalingMethodLinear     ScalingMethodEnum = "LINEAR"     // Raw points multiplied by factor
+	ScalingMethodOffset     ScalingMethodEnum = "OFFSET"     // Offset added to raw points
+	ScalingMethodCap        ScalingMethodEnum = "CAP"        // Raw points capped to maximum of the item
+	ScalingMethodPercentile ScalingMethodEnum = "PERCENTILE" // Points of percentile are rescaled to threshold
+)
+
+var ScalingMethodEnumAll = []ScalingMethodEnum{
+	ScalingMethodLinear,
+	ScalingMethodOffset,
+	ScalingMethodCap,
+	ScalingMethodPercentile,
+}
+
+func (w ScalingMethodEnum) TSName() string {
+	switch w {
+	case ScalingMethodLinear:
+		return "LINEAR"
+	case ScalingMethodOffset:
+		return "OFFSET"
+	case ScalingMethodCap:
+		return "CAP"
+	case ScalingMethodPercentile:
+		return "PERCENTILE"
+	default:
+		return "???"
+	}
+}
@@ -0,0 +1,32 @@
+package enums
+
+type ScoringStrategyEnum string
+
+const (
+	ScoringStrategyPerAnswer          ScoringStrategyEnum = "PER_ANSWER"           // Every answer is scored, wrong ones are penalized over whole block
+	ScoringStrategyAllOrNothing       ScoringStrategyEnum = "ALL_OR_NOTHING"       // Question is scored only when all its answers are correct
+	ScoringStrategyPerQuestionPartial ScoringStrategyEnum = "PER_QUESTION_PARTIAL" // Per answer scoring inside question, question cannot go below zero
+	ScoringStrategySingleChoice       ScoringStrategyEnum = "SINGLE_CHOICE"        // Student must choose exactly one answer
+)
+
+var ScoringStrategyEnumAll = []ScoringStrategyEnum{
+	ScoringStrategyPerAnswer,
+	ScoringStrategyAllOrNothing,
+	ScoringStrategyPerQuestionPartial,
+	ScoringStrategySingleChoice,
+}
+
+func (w ScoringStrategyEnum) TSName() string {
+	switch w {
+	case ScoringStrategyPerAnswer:
+		return "PER_ANSWER"
+	case ScoringStrategyAllOrNothing:
+		return "ALL_OR_NOTHING"
+	case ScoringStrategyPerQuestionPartial:
+		return "PER_QUESTION_PARTIAL"
+	case ScoringStrategySingleChoice:
+		return "SINGLE_CHOICE"
+	default:
+		return "???"
+	}
+}
@@ -29,6 +29,8 @@ const (
 	TestInstanceEventTypeQuestionSwitched    TestInstanceEventTypeEnum = "QUESTIONSWITCHED"
 	TestInstanceEventTypeQuestionInvalidIP   TestInstanceEventTypeEnum = "INVALIDIP"
 	TestInstanceEventTypeBonusPointsModified TestInstanceEventTypeEnum = "BONUSPOINTS"
+	TestInstanceEventTypeAccessCodeInvalid   TestInstanceEventTypeEnum = "ACCESSCODEINVALID"
+	TestInstanceEventTypeInvalidSEB          TestInstanceEventTypeEnum = "INVALIDSEB"
 )
 
 var TestInstanceEventTypeEnumAll = []TestInstanceEventTypeEnum{
@@ -56,6 +58,8 @@ var TestInstanceEventTypeEnumAll = []TestInstanceEventTypeEnum{
 	TestInstanceEventTypeQuestionSwitched,
 	TestInstanceEventTypeQuestionInvalidIP,
 	TestInstanceEventTypeBonusPointsModified,
+	TestInstanceEventTypeAccessCodeInvalid,
+	TestInstanceEventTypeInvalidSEB,
 }
 
 func (w TestInstanceEventTypeEnum) TSName() string {
@@ -108,6 +112,10 @@ func (w TestInstanceEventTypeEnum) TSName() string {
 		return "INVALIDIP"
 	case TestInstanceEventTypeBonusPointsModified:
 		return "BONUSPOINTS"
+	case TestInstanceEventTypeAccessCodeInvalid:
+		return "ACCESSCODEINVALID"
+	case TestInstanceEventTypeInvalidSEB:
+		return "INVALIDSEB"
 	default:
 		return "???"
 	}
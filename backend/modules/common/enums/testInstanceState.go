@@ -30,3 +30,16 @@ func (w TestInstanceStateEnum) TSName() string {
 		return "???"
 	}
 }
+
+// CanTransitionTo defines allowed state machine of test instance.
+// Paper tests and tutor modifications may finish instance directly from ready state.
+func (w TestInstanceStateEnum) CanTransitionTo(to TestInstanceStateEnum) bool {
+	switch w {
+	case TestInstanceStateReady:
+		return to == TestInstanceStateActive || to == TestInstanceStateFinished || to == TestInstanceStateExpired
+	case TestInstanceStateActive:
+		return to == TestInstanceStateFinished
+	default:
+		return false
+	}
+}
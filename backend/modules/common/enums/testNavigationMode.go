@@ -0,0 +1,32 @@
+package enums
+
+type TestNavigationModeEnum string
+
+const (
+	TestNavigationModeFree            TestNavigationModeEnum = "FREE"             // Student can move between questions freely
+	TestNavigationModeSequential      TestNavigationModeEnum = "SEQUENTIAL"       // One question at a time without returning
+	TestNavigationModeSequentialBlock TestNavigationModeEnum = "SEQUENTIAL_BLOCK" // One block at a time without returning
+)
+
+var TestNavigationModeEnumAll = []TestNavigationModeEnum{
+	TestNavigationModeFree,
+	TestNavigationModeSequential,
+	TestNavigationModeSequentialBlock,
+}
+
+func (w TestNavigationModeEnum) TSName() string {
+	switch w {
+	case TestNavigationModeFree:
+		return "FREE"
+	case TestNavigationModeSequential:
+		return "SEQUENTIAL"
+	case TestNavigationModeSequentialBlock:
+		return "SEQUENTIAL_BLOCK"
+	default:
+		return "???"
+	}
+}
+
+func (w TestNavigationModeEnum) IsSequential() bool {
+	return w == TestNavigationModeSequential || w == TestNavigationModeSequentialBlock
+}
@@ -24,6 +24,9 @@ type TermDTO struct {
 	StudentsMax    uint   `json:"studentsMax"`
 	StudentsJoined uint   `json:"studentsJoined"`
 	Tries          uint   `json:"tries"`
+
+	RequiresAccessCode  bool      `json:"requiresAccessCode"`
+	AccessCodeRotatedAt time.Time `json:"accessCodeRotatedAt"`
 }
 
 func (m TermDTO) From(d *models.Term) TermDTO {
@@ -46,6 +49,9 @@ func (m TermDTO) From(d *models.Term) TermDTO {
 		StudentsMax:    d.StudentsMax,
 		StudentsJoined: uint(len(d.Students)),
 		Tries:          d.Tries,
+
+		RequiresAccessCode:  d.RequiresAccessCode,
+		AccessCodeRotatedAt: d.AccessCodeRotatedAt,
 	}
 
 	return dto
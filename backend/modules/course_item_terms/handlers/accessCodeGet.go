@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/services"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Description Access code of the term
+type TermsAccessCodeResponse struct {
+	RequiresAccessCode bool      `json:"requiresAccessCode"`
+	AccessCode         string    `json:"accessCode"`
+	RotatedAt          time.Time `json:"rotatedAt"`
+}
+
+// @Summary Reveal access code of the term
+// @Tags Terms
+// @Security ApiKeyAuth
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the requested item"
+// @Success 200 {object} TermsAccessCodeResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/items/{courseItemId}/terms/{termId}/accesscode [get]
+func AccessCodeGet(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	termService := services.TermService{}
+	term, err := termService.GetTermByID(initializers.DB, params.CourseID, params.CourseItemID, params.TermID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(200, TermsAccessCodeResponse{
+		RequiresAccessCode: term.RequiresAccessCode,
+		AccessCode:         term.AccessCode,
+		RotatedAt:          term.AccessCodeRotatedAt,
+	})
+	return nil
+}
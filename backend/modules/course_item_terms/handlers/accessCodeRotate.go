@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/course_item_terms/helpers"
+	"elogika.vsb.cz/backend/services"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Generate new access code for the term
+// @Tags Terms
+// @Security ApiKeyAuth
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the requested item"
+// @Success 200 {object} TermsAccessCodeResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/items/{courseItemId}/terms/{termId}/accesscode [post]
+func AccessCodeRotate(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	termService := services.TermService{}
+	term, err := termService.GetTermByID(initializers.DB, params.CourseID, params.CourseItemID, params.TermID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+
+	// Rotating does not bump version, so supervisor does not break concurrent edits of the term
+	term.RequiresAccessCode = true
+	term.AccessCode = helpers.GenerateAccessCode()
+	term.AccessCodeRotatedAt = time.Now()
+
+	if err := initializers.DB.
+		Model(&term).
+		Select("requires_access_code", "access_code", "access_code_rotated_at").
+		Updates(&term).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to rotate access code",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, TermsAccessCodeResponse{
+		RequiresAccessCode: term.RequiresAccessCode,
+		AccessCode:         term.AccessCode,
+		RotatedAt:          term.AccessCodeRotatedAt,
+	})
+	return nil
+}
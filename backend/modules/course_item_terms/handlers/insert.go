@@ -10,6 +10,7 @@ import (
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
 	"elogika.vsb.cz/backend/modules/course_item_terms/dtos"
+	"elogika.vsb.cz/backend/modules/course_item_terms/helpers"
 	"elogika.vsb.cz/backend/repositories"
 	"elogika.vsb.cz/backend/services"
 	services_course_item "elogika.vsb.cz/backend/services/courseItem"
@@ -31,6 +32,8 @@ type TermsInsertRequest struct {
 	Classroom   string `json:"classroom"`
 	StudentsMax uint   `json:"studentsMax"`
 	Tries       uint   `json:"tries"`
+
+	RequiresAccessCode bool `json:"requiresAccessCode"`
 }
 
 // @Description Newly created question
@@ -109,6 +112,13 @@ func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 		Classroom:   reqData.Classroom,
 		StudentsMax: reqData.StudentsMax,
 		Tries:       reqData.Tries,
+
+		RequiresAccessCode: reqData.RequiresAccessCode,
+	}
+
+	if term.RequiresAccessCode {
+		term.AccessCode = helpers.GenerateAccessCode()
+		term.AccessCodeRotatedAt = time.Now()
 	}
 
 	if err := initializers.DB.Save(&term).Error; err != nil {
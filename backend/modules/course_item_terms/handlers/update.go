@@ -9,6 +9,7 @@ import (
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
 	"elogika.vsb.cz/backend/modules/course_item_terms/dtos"
+	"elogika.vsb.cz/backend/modules/course_item_terms/helpers"
 	"elogika.vsb.cz/backend/services"
 	"elogika.vsb.cz/backend/utils"
 	"github.com/gin-gonic/gin"
@@ -29,6 +30,8 @@ type TermsUpdateRequest struct {
 	StudentsMax uint   `json:"studentsMax"`
 	Tries       uint   `json:"tries"`
 
+	RequiresAccessCode bool `json:"requiresAccessCode"`
+
 	Version uint `json:"version"` // Version signature to prevent concurrency problems
 }
 
@@ -97,6 +100,11 @@ func Update(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 	term.Classroom = reqData.Classroom
 	term.StudentsMax = reqData.StudentsMax
 	term.Tries = reqData.Tries
+	term.RequiresAccessCode = reqData.RequiresAccessCode
+	if term.RequiresAccessCode && term.AccessCode == "" {
+		term.AccessCode = helpers.GenerateAccessCode()
+		term.AccessCodeRotatedAt = time.Now()
+	}
 
 	if err := initializers.DB.Save(&term).Error; err != nil {
 		return &common.ErrorResponse{
@@ -0,0 +1,22 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+const (
+	accessCodeLength = 6
+	accessCodeChars  = "0123456789"
+)
+
+// GenerateAccessCode returns random numeric code easy to dictate or write on a board
+func GenerateAccessCode() string {
+	var code strings.Builder
+	for code.Len() < accessCodeLength {
+		n, _ := rand.Int(rand.Reader, big.NewInt(int64(len(accessCodeChars))))
+		code.WriteByte(accessCodeChars[n.Int64()])
+	}
+	return code.String()
+}
@@ -14,6 +14,9 @@ func RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("courses/:courseId/items/:courseItemId/terms/recursive", wrappers.WithUserDataRole(handlers.ListByItemRecursive))
 	rg.GET("courses/:courseId/items/:courseItemId/terms/:termId", wrappers.WithUserDataRole(handlers.GetByID))
 
+	rg.GET("courses/:courseId/items/:courseItemId/terms/:termId/accesscode", wrappers.WithUserDataRole(handlers.AccessCodeGet))
+	rg.POST("courses/:courseId/items/:courseItemId/terms/:termId/accesscode", wrappers.WithUserDataRole(handlers.AccessCodeRotate))
+
 	rg.GET("courses/:courseId/items/:courseItemId/terms/:termId/students", wrappers.WithUserDataRole(handlers.ListJoinedStudents))
 	rg.POST("courses/:courseId/items/:courseItemId/terms/:termId/students", wrappers.WithUserDataRole(handlers.UserJoin))
 	rg.DELETE("courses/:courseId/items/:courseItemId/terms/:termId/students", wrappers.WithUserDataRole(handlers.UserLeave))
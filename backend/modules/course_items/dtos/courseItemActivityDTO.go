@@ -7,12 +7,14 @@ import (
 type CourseItemActivityDTO struct {
 	Description    *models.TipTapContent `json:"description" ts_type:"JSONContent"`
 	ExpectedResult *models.TipTapContent `json:"expectedResult" ts_type:"JSONContent"`
+	RubricID       *uint                 `json:"rubricId" ts_type:"number | null"`
 }
 
 func (m CourseItemActivityDTO) From(d *models.CourseItemActivity) CourseItemActivityDTO {
 	dto := CourseItemActivityDTO{
 		Description:    d.Description,
 		ExpectedResult: d.ExpectedResult,
+		RubricID:       d.RubricID,
 	}
 
 	return dto
@@ -21,6 +21,7 @@ type CourseItemDTO struct {
 	ManagedBy         enums.CourseUserRoleEnum    `json:"managedBy"`
 	EvaluateByAttempt enums.EvaluateByAttemptEnum `json:"evaluateByAttempt"`
 	IncludeInResults  bool                        `json:"includeInResults"`
+	AppealDays        uint                        `json:"appealDays"`
 
 	ActivityDetail *CourseItemActivityDTO `json:"activityDetail,omitempty"`
 	TestDetail     *CourseItemTestDTO     `json:"testDetail,omitempty"`
@@ -44,6 +45,7 @@ func (m CourseItemDTO) From(d *models.CourseItem) CourseItemDTO {
 		ManagedBy:         d.ManagedBy,
 		EvaluateByAttempt: d.EvaluateByAttempt,
 		IncludeInResults:  d.IncludeInResults,
+		AppealDays:        d.AppealDays,
 	}
 	if d.ActivityDetail != nil {
 		a := CourseItemActivityDTO{}.From(d.ActivityDetail)
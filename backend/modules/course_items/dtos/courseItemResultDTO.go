@@ -20,9 +20,10 @@ type CourseItemResultDTO struct {
 	TestInstanceID     *uint `json:"testInstanceId"`
 	ActivityInstanceID *uint `json:"activityInstanceId"`
 
-	Points   float64 `json:"points"`
-	Final    bool    `json:"final"`
-	Selected bool    `json:"selected"`
+	Points     float64 `json:"points"`
+	Adjustment float64 `json:"adjustment"` // Points added by scaling, raw points are points - adjustment
+	Final      bool    `json:"final"`
+	Selected   bool    `json:"selected"`
 }
 
 func (m CourseItemResultDTO) From(d *models.CourseItemResult) CourseItemResultDTO {
@@ -37,9 +38,10 @@ func (m CourseItemResultDTO) From(d *models.CourseItemResult) CourseItemResultDT
 		TestInstanceID:     d.TestInstanceID,
 		ActivityInstanceID: d.ActivityInstanceID,
 
-		Points:   d.Points,
-		Final:    d.Final,
-		Selected: d.Selected,
+		Points:     d.Points,
+		Adjustment: d.Adjustment,
+		Final:      d.Final,
+		Selected:   d.Selected,
 	}
 
 	if d.TestInstanceID != nil {
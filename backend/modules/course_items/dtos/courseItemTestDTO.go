@@ -1,6 +1,8 @@
 package dtos
 
 import (
+	"time"
+
 	"elogika.vsb.cz/backend/models"
 	"elogika.vsb.cz/backend/modules/common/enums"
 )
@@ -15,6 +17,24 @@ type CourseItemTestDTO struct {
 	AllowOffline    bool                   `json:"allowOffline"`
 	IsPaper         bool                   `json:"isPaper"`
 	IPRanges        string                 `json:"ipRanges"`
+
+	RequireSEB         bool   `json:"requireSeb"`
+	SEBBrowserExamKeys string `json:"sebBrowserExamKeys"`
+	SEBQuitPassword    string `json:"sebQuitPassword"`
+
+	NavigationMode     enums.TestNavigationModeEnum `json:"navigationMode"`
+	QuestionTimeLimits bool                         `json:"questionTimeLimits"`
+	BlindGrading       bool                         `json:"blindGrading"`
+
+	ModerationPercentage uint    `json:"moderationPercentage"`
+	ModerationThreshold  float64 `json:"moderationThreshold"`
+
+	ResultsReleaseMode     enums.ReleaseModeEnum `json:"resultsReleaseMode"`
+	ResultsReleaseAt       *time.Time            `json:"resultsReleaseAt"`
+	TestReleaseMode        enums.ReleaseModeEnum `json:"testReleaseMode"`
+	TestReleaseAt          *time.Time            `json:"testReleaseAt"`
+	CorrectnessReleaseMode enums.ReleaseModeEnum `json:"correctnessReleaseMode"`
+	CorrectnessReleaseAt   *time.Time            `json:"correctnessReleaseAt"`
 }
 
 func (m CourseItemTestDTO) From(d *models.CourseItemTest) CourseItemTestDTO {
@@ -28,7 +48,21 @@ func (m CourseItemTestDTO) From(d *models.CourseItemTest) CourseItemTestDTO {
 		AllowOffline:    d.AllowOffline,
 		IsPaper:         d.IsPaper,
 		IPRanges:        d.IPRanges,
+
+		RequireSEB:         d.RequireSEB,
+		SEBBrowserExamKeys: d.SEBBrowserExamKeys,
+		SEBQuitPassword:    d.SEBQuitPassword,
+
+		NavigationMode:     d.NavigationMode,
+		QuestionTimeLimits: d.QuestionTimeLimits,
+		BlindGrading:       d.BlindGrading,
+
+		ModerationPercentage: d.ModerationPercentage,
+		ModerationThreshold:  d.ModerationThreshold,
 	}
+	dto.ResultsReleaseMode, dto.ResultsReleaseAt, _ = d.ReleaseMode(enums.ReleaseLevelResults)
+	dto.TestReleaseMode, dto.TestReleaseAt, _ = d.ReleaseMode(enums.ReleaseLevelTest)
+	dto.CorrectnessReleaseMode, dto.CorrectnessReleaseAt, _ = d.ReleaseMode(enums.ReleaseLevelCorrectness)
 
 	return dto
 }
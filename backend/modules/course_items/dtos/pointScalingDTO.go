@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type PointScalingDTO struct {
+	ID         uint                    `json:"id"`
+	CreatedAt  time.Time               `json:"createdAt"`
+	CreatedBy  string                  `json:"createdBy"`
+	TermID     uint                    `json:"termId"`
+	Method     enums.ScalingMethodEnum `json:"method"`
+	Factor     float64                 `json:"factor"`
+	Offset     float64                 `json:"offset"`
+	Percentile float64                 `json:"percentile"`
+	Threshold  float64                 `json:"threshold"`
+	CapToMax   bool                    `json:"capToMax"`
+	Affected   uint                    `json:"affected"`
+	Active     bool                    `json:"active"`
+	RevertedAt *time.Time              `json:"revertedAt"`
+	RevertedBy string                  `json:"revertedBy"`
+}
+
+func (m PointScalingDTO) From(d *models.PointScaling) PointScalingDTO {
+	dto := PointScalingDTO{
+		ID:         d.ID,
+		CreatedAt:  d.CreatedAt,
+		TermID:     d.TermID,
+		Method:     d.Method,
+		Factor:     d.Factor,
+		Offset:     d.Offset,
+		Percentile: d.Percentile,
+		Threshold:  d.Threshold,
+		CapToMax:   d.CapToMax,
+		Affected:   d.Affected,
+		Active:     d.Active,
+		RevertedAt: d.RevertedAt,
+	}
+
+	if d.CreatedBy != nil {
+		dto.CreatedBy = d.CreatedBy.FullName()
+	}
+	if d.RevertedBy != nil {
+		dto.RevertedBy = d.RevertedBy.FullName()
+	}
+
+	return dto
+}
@@ -0,0 +1,33 @@
+package dtos
+
+import (
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/utils"
+)
+
+type ScalingPreviewItemDTO struct {
+	ResultID    uint    `json:"resultId"`
+	StudentID   uint    `json:"studentId"`
+	StudentName string  `json:"studentName"`
+	Final       bool    `json:"final"`
+	RawPoints   float64 `json:"rawPoints"`
+	Points      float64 `json:"points"`     // Points after scaling
+	Adjustment  float64 `json:"adjustment"` // Difference of scaled and raw points
+}
+
+func (m ScalingPreviewItemDTO) From(d *models.CourseItemResult, raw float64, scaled float64) ScalingPreviewItemDTO {
+	dto := ScalingPreviewItemDTO{
+		ResultID:   d.ID,
+		StudentID:  d.StudentID,
+		Final:      d.Final,
+		RawPoints:  raw,
+		Points:     scaled,
+		Adjustment: utils.RoundToEven(scaled-raw, 2),
+	}
+
+	if d.Student != nil {
+		dto.StudentName = d.Student.FullName()
+	}
+
+	return dto
+}
@@ -19,9 +19,11 @@ type StudentCourseItemResultDTO struct {
 	ActivityInstanceID *uint     `json:"activityInstanceId"`
 	InstanceStartTime  time.Time `json:"instanceStartTime"`
 
-	Points   float64 `json:"points"`
-	Final    bool    `json:"final"`
-	Selected bool    `json:"selected"`
+	Points         float64    `json:"points"`
+	Adjustment     float64    `json:"adjustment"` // Points added by scaling
+	Final          bool       `json:"final"`
+	Selected       bool       `json:"selected"`
+	AppealDeadline *time.Time `json:"appealDeadline"` // Regrade can be requested until this time, empty when not allowed
 
 	TermName       string    `json:"termName"`
 	TermActiveFrom time.Time `json:"termActiveFrom"`
@@ -37,12 +39,14 @@ func (m StudentCourseItemResultDTO) From(d *models.CourseItemResult) StudentCour
 		ActivityInstanceID: d.ActivityInstanceID,
 		InstanceStartTime:  d.CreatedAt,
 		Points:             d.Points,
+		Adjustment:         d.Adjustment,
 		Final:              d.Final,
 		Selected:           d.Selected,
 		TermName:           d.Term.Name,
 		TermActiveFrom:     d.Term.ActiveFrom,
 		TermActiveTo:       d.Term.ActiveTo,
 		CourseItemName:     d.CourseItem.Name,
+		AppealDeadline:     d.AppealDeadline(),
 	}
 
 	if d.CourseItem.Parent != nil {
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"elogika.vsb.cz/backend/auth"
 	"elogika.vsb.cz/backend/initializers"
 	"elogika.vsb.cz/backend/models"
@@ -8,6 +10,7 @@ import (
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
 	"elogika.vsb.cz/backend/modules/course_items/dtos"
+	"elogika.vsb.cz/backend/repositories"
 	services_course_item "elogika.vsb.cz/backend/services/courseItem"
 	"elogika.vsb.cz/backend/utils"
 	"elogika.vsb.cz/backend/utils/tiptap"
@@ -17,6 +20,7 @@ import (
 type ActivityDetailCourseItemInsertRequest struct {
 	Description    *models.TipTapContent `json:"description" ts_type:"JSONContent"`    // Assignemnt of activity
 	ExpectedResult *models.TipTapContent `json:"expectedResult" ts_type:"JSONContent"` // Expected result of the activity
+	RubricID       *uint                 `json:"rubricId" ts_type:"number | null"`     // Rubric used when grading instances
 }
 
 type GroupDetailCourseItemInsertRequest struct {
@@ -26,15 +30,29 @@ type GroupDetailCourseItemInsertRequest struct {
 }
 
 type TestDetailCourseItemInsertRequest struct {
-	TestType        enums.QuestionTypeEnum `json:"testType"`                           // Further filters questions used for this test
-	TimeLimit       uint                   `json:"timeLimit"`                          // Time limit for this test
-	ShowResults     bool                   `json:"showResults"`                        // Show results to student after finishing test
-	ShowTest        bool                   `json:"showTest"`                           // Show the exact test (questions)
-	ShowCorrectness bool                   `json:"showCorrectness"`                    // Show what answers were correct
-	AllowOffline    bool                   `json:"allowOffline"`                       // Allow offline answer sending
-	IsPaper         bool                   `json:"isPaper"`                            // Test is written physically on paper
-	IPRanges        string                 `json:"ipRanges"`                           // Allowed ip ranges to write a test
-	TestTemplateID  uint                   `json:"testTemplateId" validate:"required"` // Id of selected test template
+	TestType               enums.QuestionTypeEnum       `json:"testType"`                           // Further filters questions used for this test
+	TimeLimit              uint                         `json:"timeLimit"`                          // Time limit for this test
+	ShowResults            bool                         `json:"showResults"`                        // Show results to student after finishing test
+	ShowTest               bool                         `json:"showTest"`                           // Show the exact test (questions)
+	ShowCorrectness        bool                         `json:"showCorrectness"`                    // Show what answers were correct
+	AllowOffline           bool                         `json:"allowOffline"`                       // Allow offline answer sending
+	IsPaper                bool                         `json:"isPaper"`                            // Test is written physically on paper
+	IPRanges               string                       `json:"ipRanges"`                           // Allowed ip ranges to write a test
+	RequireSEB             bool                         `json:"requireSeb"`                         // Test can be written only inside Safe Exam Browser
+	SEBBrowserExamKeys     string                       `json:"sebBrowserExamKeys"`                 // Allowed browser exam keys separated by ";"
+	SEBQuitPassword        string                       `json:"sebQuitPassword"`                    // Password required to quit Safe Exam Browser
+	NavigationMode         enums.TestNavigationModeEnum `json:"navigationMode"`                     // Whether student can return to previous questions
+	QuestionTimeLimits     bool                         `json:"questionTimeLimits"`                 // Each question has its own time window
+	BlindGrading           bool                         `json:"blindGrading"`                       // Student identity is hidden in grading queue
+	ModerationPercentage   uint                         `json:"moderationPercentage"`               // Percentage of instances double-marked by second grader, 0 disables moderation
+	ModerationThreshold    float64                      `json:"moderationThreshold"`                // Difference of marks in percent which is flagged for moderator
+	ResultsReleaseMode     enums.ReleaseModeEnum        `json:"resultsReleaseMode"`                 // How results are released, manual mode follows showResults
+	ResultsReleaseAt       *time.Time                   `json:"resultsReleaseAt"`                   // Release time of scheduled mode
+	TestReleaseMode        enums.ReleaseModeEnum        `json:"testReleaseMode"`                    // How test content is released, manual mode follows showTest
+	TestReleaseAt          *time.Time                   `json:"testReleaseAt"`                      // Release time of scheduled mode
+	CorrectnessReleaseMode enums.ReleaseModeEnum        `json:"correctnessReleaseMode"`             // How correctness is released, manual mode follows showCorrectness
+	CorrectnessReleaseAt   *time.Time                   `json:"correctnessReleaseAt"`               // Release time of scheduled mode
+	TestTemplateID         uint                         `json:"testTemplateId" validate:"required"` // Id of selected test template
 }
 
 // @Description Request to insert new course item
@@ -50,6 +68,7 @@ type CourseItemInsertRequest struct {
 	ParentID          *uint                       `json:"parentId" validate:"optional"` // Id of parent group object
 	EvaluateByAttempt enums.EvaluateByAttemptEnum `json:"evaluateByAttempt"`            // Evaluation mode
 	IncludeInResults  bool                        `json:"includeInResults"`             // Include in overall results
+	AppealDays        uint                        `json:"appealDays"`                   // Days after results are shown when students may request regrade, 0 disables appeals
 	// TODO ManagedBy
 
 	ActivityDetail *ActivityDetailCourseItemInsertRequest `json:"activityDetail" validate:"optional"` // Additional data for type ACTIVITY (homework, project, ...)
@@ -124,6 +143,40 @@ func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 		}
 	}
 
+	if reqData.Type == enums.CourseItemTypeTest && (reqData.TestDetail.ModerationPercentage > 100 || reqData.TestDetail.ModerationThreshold < 0) {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Moderation percentage must be at most 100 and threshold non negative",
+			FormErrors: common.ErrorObject{
+				"testDetail": common.ErrorObject{
+					"moderationPercentage": "Moderation percentage must be at most 100 and threshold non negative",
+				},
+			},
+		}
+	}
+
+	if reqData.Type == enums.CourseItemTypeTest {
+		if err := validateReleaseSettings(
+			releaseSetting{"resultsRelease", &reqData.TestDetail.ResultsReleaseMode, reqData.TestDetail.ResultsReleaseAt},
+			releaseSetting{"testRelease", &reqData.TestDetail.TestReleaseMode, reqData.TestDetail.TestReleaseAt},
+			releaseSetting{"correctnessRelease", &reqData.TestDetail.CorrectnessReleaseMode, reqData.TestDetail.CorrectnessReleaseAt},
+		); err != nil {
+			return err
+		}
+	}
+
+	if reqData.Type == enums.CourseItemTypeActivity && reqData.ActivityDetail != nil {
+		if err := repositories.NewRubricRepository().ValidateRubricID(initializers.DB, params.CourseID, reqData.ActivityDetail.RubricID); err != nil {
+			if err.FormErrors != nil {
+				err.FormErrors = common.ErrorObject{
+					"activityDetail": err.FormErrors,
+				}
+			}
+			return err
+		}
+	}
+
 	courseItem := &models.CourseItem{
 		Version:           1,
 		CourseID:          params.CourseID,
@@ -139,6 +192,7 @@ func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 		AllowNegative:     reqData.AllowNegative,
 		EvaluateByAttempt: reqData.EvaluateByAttempt,
 		IncludeInResults:  reqData.IncludeInResults,
+		AppealDays:        reqData.AppealDays,
 	}
 	if reqData.ParentID != nil && *reqData.ParentID != uint(0) {
 		courseItem.ParentID = reqData.ParentID
@@ -151,6 +205,7 @@ func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 		innerCourseItem := models.CourseItemActivity{
 			Description:    reqData.ActivityDetail.Description,
 			ExpectedResult: reqData.ActivityDetail.ExpectedResult,
+			RubricID:       reqData.ActivityDetail.RubricID,
 		}
 
 		err = tiptap.FindAndSaveRelations(transaction, userData.ID, reqData.ActivityDetail.Description, &innerCourseItem, "DescriptionFiles")
@@ -191,15 +246,31 @@ func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 		courseItem.GroupDetailID = &innerCourseItem.ID
 	case enums.CourseItemTypeTest:
 		innerCourseItem := models.CourseItemTest{
-			TestType:        reqData.TestDetail.TestType,
-			TimeLimit:       reqData.TestDetail.TimeLimit,
-			ShowResults:     reqData.TestDetail.ShowResults,
-			ShowTest:        reqData.TestDetail.ShowTest,
-			ShowCorrectness: reqData.TestDetail.ShowCorrectness,
-			AllowOffline:    reqData.TestDetail.AllowOffline,
-			IsPaper:         reqData.TestDetail.IsPaper,
-			IPRanges:        reqData.TestDetail.IPRanges,
-			TestTemplateID:  reqData.TestDetail.TestTemplateID,
+			TestType:             reqData.TestDetail.TestType,
+			TimeLimit:            reqData.TestDetail.TimeLimit,
+			ShowResults:          reqData.TestDetail.ShowResults,
+			ShowTest:             reqData.TestDetail.ShowTest,
+			ShowCorrectness:      reqData.TestDetail.ShowCorrectness,
+			AllowOffline:         reqData.TestDetail.AllowOffline,
+			IsPaper:              reqData.TestDetail.IsPaper,
+			IPRanges:             reqData.TestDetail.IPRanges,
+			RequireSEB:           reqData.TestDetail.RequireSEB,
+			SEBBrowserExamKeys:   reqData.TestDetail.SEBBrowserExamKeys,
+			SEBQuitPassword:      reqData.TestDetail.SEBQuitPassword,
+			NavigationMode:       reqData.TestDetail.NavigationMode,
+			QuestionTimeLimits:   reqData.TestDetail.QuestionTimeLimits,
+			BlindGrading:         reqData.TestDetail.BlindGrading,
+			ModerationPercentage: reqData.TestDetail.ModerationPercentage,
+			ModerationThreshold:  reqData.TestDetail.ModerationThreshold,
+
+			ResultsReleaseMode:     reqData.TestDetail.ResultsReleaseMode,
+			ResultsReleaseAt:       reqData.TestDetail.ResultsReleaseAt,
+			TestReleaseMode:        reqData.TestDetail.TestReleaseMode,
+			TestReleaseAt:          reqData.TestDetail.TestReleaseAt,
+			CorrectnessReleaseMode: reqData.TestDetail.CorrectnessReleaseMode,
+			CorrectnessReleaseAt:   reqData.TestDetail.CorrectnessReleaseAt,
+
+			TestTemplateID: reqData.TestDetail.TestTemplateID,
 		}
 		if err := transaction.Save(&innerCourseItem).Error; err != nil {
 			transaction.Rollback()
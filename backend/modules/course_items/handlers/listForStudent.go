@@ -92,13 +92,23 @@ func ListForStudent(c *gin.Context, userData authdtos.LoggedUserDTO, userRole en
 		}
 	}
 
+	terms := make([]*models.Term, len(results))
+	for i, res := range results {
+		terms[i] = res.Term
+	}
+	if err := models.LoadTermReleases(initializers.DB, terms...); err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to fetch result releases",
+			Details: err.Error(),
+		}
+	}
+
 	for _, res := range results {
 		if res.CourseItem.Type == enums.CourseItemTypeTest {
-			if !res.CourseItem.TestDetail.ShowResults {
-				if res.Term.ActiveTo.After(time.Now()) {
-					res.Points = 0
-					res.Final = false
-				}
+			if !res.CourseItem.TestDetail.IsReleased(enums.ReleaseLevelResults, res.CourseItemID, res.Term, time.Now()) {
+				res.Points = 0
+				res.Final = false
 			}
 		}
 	}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"slices"
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// releaseSetting is release mode of one visibility level in request
+type releaseSetting struct {
+	field string
+	mode  *enums.ReleaseModeEnum
+	at    *time.Time
+}
+
+// validateReleaseSettings defaults empty modes to manual and requires time of scheduled releases
+func validateReleaseSettings(settings ...releaseSetting) *common.ErrorResponse {
+	formErrors := common.ErrorObject{}
+	for _, setting := range settings {
+		if *setting.mode == "" {
+			*setting.mode = enums.ReleaseModeManual
+		}
+
+		if !slices.Contains(enums.ReleaseModeEnumAll, *setting.mode) {
+			formErrors[setting.field+"Mode"] = "Unknown release mode"
+		} else if *setting.mode == enums.ReleaseModeScheduled && setting.at == nil {
+			formErrors[setting.field+"At"] = "Scheduled release requires time"
+		}
+	}
+
+	if len(formErrors) > 0 {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Invalid release settings",
+			FormErrors: common.ErrorObject{
+				"testDetail": formErrors,
+			},
+		}
+	}
+
+	return nil
+}
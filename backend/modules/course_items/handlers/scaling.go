@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/course_items/dtos"
+	"gorm.io/gorm"
+)
+
+// @Description Scaling of all results of a term
+type ScalingRequest struct {
+	Method     enums.ScalingMethodEnum `json:"method" binding:"required"`
+	Factor     float64                 `json:"factor"`     // Multiplier of LINEAR method
+	Offset     float64                 `json:"offset"`     // Points added by OFFSET method
+	Percentile float64                 `json:"percentile"` // Percentile (0-100] of PERCENTILE method
+	Threshold  float64                 `json:"threshold"`  // Points the percentile is mapped to by PERCENTILE method
+	CapToMax   bool                    `json:"capToMax"`   // Scaled points never exceed maximum of the item
+}
+
+func validateScalingRequest(reqData *ScalingRequest) *common.ErrorResponse {
+	formErrors := common.ErrorObject{}
+
+	switch reqData.Method {
+	case enums.ScalingMethodLinear:
+		if reqData.Factor <= 0 {
+			formErrors["factor"] = "Factor must be positive"
+		}
+	case enums.ScalingMethodPercentile:
+		if reqData.Percentile <= 0 || reqData.Percentile > 100 {
+			formErrors["percentile"] = "Percentile must be between 0 and 100"
+		}
+		if reqData.Threshold <= 0 {
+			formErrors["threshold"] = "Threshold must be positive"
+		}
+	default:
+		if !slices.Contains(enums.ScalingMethodEnumAll, reqData.Method) {
+			formErrors["method"] = "Unknown scaling method"
+		}
+	}
+
+	if len(formErrors) > 0 {
+		return &common.ErrorResponse{
+			Code:       422,
+			Message:    "Validation failed",
+			FormErrors: formErrors,
+		}
+	}
+
+	return nil
+}
+
+// loadScalingResults loads all results of term, raw points are recovered from stored adjustments
+func loadScalingResults(dbRef *gorm.DB, courseItemID uint, termID uint) ([]*models.CourseItemResult, *common.ErrorResponse) {
+	var results []*models.CourseItemResult
+	if err := dbRef.
+		Preload("Student").
+		Where("course_item_id = ?", courseItemID).
+		Where("term_id = ?", termID).
+		Order("id ASC").
+		Find(&results).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load results",
+			Details: err.Error(),
+		}
+	}
+
+	return results, nil
+}
+
+// newScaling builds scaling from request. Factor of percentile method is computed from final raw points of the term.
+func newScaling(courseItem *models.CourseItem, termID uint, results []*models.CourseItemResult, reqData *ScalingRequest, userID uint) (*models.PointScaling, *common.ErrorResponse) {
+	scaling := &models.PointScaling{
+		CreatedByID:  userID,
+		CourseItemID: courseItem.ID,
+		TermID:       termID,
+		Method:       reqData.Method,
+		Factor:       1,
+		Offset:       reqData.Offset,
+		Percentile:   reqData.Percentile,
+		Threshold:    reqData.Threshold,
+		CapToMax:     reqData.CapToMax,
+		Active:       true,
+	}
+
+	switch reqData.Method {
+	case enums.ScalingMethodLinear:
+		scaling.Factor = reqData.Factor
+	case enums.ScalingMethodPercentile:
+		points := []float64{}
+		for _, result := range results {
+			if result.Final {
+				points = append(points, result.RawPoints())
+			}
+		}
+		if len(points) == 0 {
+			return nil, &common.ErrorResponse{
+				Code:    409,
+				Message: "Term has no final results",
+			}
+		}
+
+		// Nearest rank percentile
+		sort.Float64s(points)
+		rank := int(math.Ceil(reqData.Percentile / 100 * float64(len(points))))
+		percentilePoints := points[max(rank, 1)-1]
+		if percentilePoints <= 0 {
+			return nil, &common.ErrorResponse{
+				Code:    409,
+				Message: "Points of percentile are not positive",
+			}
+		}
+		scaling.Factor = reqData.Threshold / percentilePoints
+	}
+
+	return scaling, nil
+}
+
+// previewScaling returns raw and scaled points of results without changing them
+func previewScaling(courseItem *models.CourseItem, results []*models.CourseItemResult, scaling *models.PointScaling) []dtos.ScalingPreviewItemDTO {
+	items := make([]dtos.ScalingPreviewItemDTO, len(results))
+	for i, result := range results {
+		raw := result.RawPoints()
+		items[i] = dtos.ScalingPreviewItemDTO{}.From(result, raw, scaling.Scale(raw, courseItem.PointsMax))
+	}
+
+	return items
+}
+
+// revertScaling restores raw points of results adjusted by scaling
+func revertScaling(dbRef *gorm.DB, scaling *models.PointScaling, userID uint) *common.ErrorResponse {
+	var results []*models.CourseItemResult
+	if err := dbRef.
+		Where("scaling_id = ?", scaling.ID).
+		Find(&results).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load results",
+			Details: err.Error(),
+		}
+	}
+
+	for _, result := range results {
+		result.Points = result.RawPoints()
+		result.Adjustment = 0
+		result.ScalingID = nil
+		if err := dbRef.
+			Model(result).
+			Select("points", "adjustment", "scaling_id").
+			Updates(result).Error; err != nil {
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to revert result",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	revertedAt := time.Now()
+	scaling.Active = false
+	scaling.RevertedAt = &revertedAt
+	scaling.RevertedByID = &userID
+	if err := dbRef.
+		Model(scaling).
+		Select("active", "reverted_at", "reverted_by_id").
+		Updates(scaling).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to revert scaling",
+			Details: err.Error(),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/course_items/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ScalingApplyResponse struct {
+	Data dtos.PointScalingDTO `json:"data"`
+}
+
+// @Summary Applies point scaling to all results of term. Active scaling of the term is reverted first.
+// @Tags CourseItems
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param body body ScalingRequest true "Scaling"
+// @Success 200 {object} ScalingApplyResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 409 {object} common.ErrorResponse "Percentile cannot be computed"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/items/{courseItemId}/scaling/{termId} [post]
+func ApplyScaling(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		ScalingRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	if err := validateScalingRequest(reqData); err != nil {
+		return err
+	}
+
+	transaction := initializers.DB.Begin()
+
+	var activeScalings []*models.PointScaling
+	if err := transaction.
+		Where("course_item_id = ?", courseItem.ID).
+		Where("term_id = ?", params.TermID).
+		Where("active = ?", true).
+		Find(&activeScalings).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load scalings",
+			Details: err.Error(),
+		}
+	}
+	for _, activeScaling := range activeScalings {
+		if err := revertScaling(transaction, activeScaling, userData.ID); err != nil {
+			transaction.Rollback()
+			return err
+		}
+	}
+
+	results, err := loadScalingResults(transaction, courseItem.ID, params.TermID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	scaling, err := newScaling(courseItem, params.TermID, results, reqData, userData.ID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+	scaling.Affected = uint(len(results))
+	if err := transaction.Create(scaling).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save scaling",
+			Details: err.Error(),
+		}
+	}
+
+	students := map[uint]bool{}
+	for _, result := range results {
+		raw := result.RawPoints()
+		result.ScalingID = &scaling.ID
+		result.Points = scaling.Scale(raw, courseItem.PointsMax)
+		result.Adjustment = utils.RoundToEven(result.Points-raw, 2)
+		if err := transaction.
+			Model(result).
+			Select("points", "adjustment", "scaling_id").
+			Updates(result).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to save result",
+				Details: err.Error(),
+			}
+		}
+		students[result.StudentID] = true
+	}
+
+	if err := updateScaledStudents(transaction, params.CourseID, courseItem, students); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, ScalingApplyResponse{
+		Data: dtos.PointScalingDTO{}.From(scaling),
+	})
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/course_items/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ScalingListResponse struct {
+	Items []dtos.PointScalingDTO `json:"items"`
+}
+
+// @Summary Lists point scalings of term, including reverted ones
+// @Tags CourseItems
+// @Security ApiKeyAuth
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Success 200 {object} ScalingListResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/items/{courseItemId}/scaling/{termId} [get]
+func ListScalings(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	var scalings []*models.PointScaling
+	if err := initializers.DB.
+		Preload("CreatedBy").
+		Preload("RevertedBy").
+		Where("course_item_id = ?", courseItem.ID).
+		Where("term_id = ?", params.TermID).
+		Order("created_at DESC").
+		Find(&scalings).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load scalings",
+			Details: err.Error(),
+		}
+	}
+
+	items := make([]dtos.PointScalingDTO, len(scalings))
+	for i, scaling := range scalings {
+		items[i] = dtos.PointScalingDTO{}.From(scaling)
+	}
+
+	c.JSON(200, ScalingListResponse{
+		Items: items,
+	})
+
+	return nil
+}
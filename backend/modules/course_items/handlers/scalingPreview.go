@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/course_items/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ScalingPreviewResponse struct {
+	Factor float64                      `json:"factor"` // Multiplier used by linear and percentile methods
+	Items  []dtos.ScalingPreviewItemDTO `json:"items"`
+}
+
+// @Summary Previews point scaling of term without changing results
+// @Tags CourseItems
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param body body ScalingRequest true "Scaling"
+// @Success 200 {object} ScalingPreviewResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 409 {object} common.ErrorResponse "Percentile cannot be computed"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/items/{courseItemId}/scaling/{termId}/preview [post]
+func PreviewScaling(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		ScalingRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	if err := validateScalingRequest(reqData); err != nil {
+		return err
+	}
+
+	results, err := loadScalingResults(initializers.DB, courseItem.ID, params.TermID)
+	if err != nil {
+		return err
+	}
+
+	scaling, err := newScaling(courseItem, params.TermID, results, reqData, userData.ID)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(200, ScalingPreviewResponse{
+		Factor: scaling.Factor,
+		Items:  previewScaling(courseItem, results, scaling),
+	})
+
+	return nil
+}
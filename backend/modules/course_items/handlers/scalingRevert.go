@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ScalingRevertResponse struct {
+	Success bool `json:"success"`
+}
+
+// @Summary Reverts active point scaling of term, results get back their raw points
+// @Tags CourseItems
+// @Security ApiKeyAuth
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Success 200 {object} ScalingRevertResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Term has no active scaling"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/items/{courseItemId}/scaling/{termId} [delete]
+func RevertScaling(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	var scaling models.PointScaling
+	if err := transaction.
+		Where("course_item_id = ?", courseItem.ID).
+		Where("term_id = ?", params.TermID).
+		Where("active = ?", true).
+		First(&scaling).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Term has no active scaling",
+			Details: err.Error(),
+		}
+	}
+
+	var studentIDs []uint
+	if err := transaction.
+		Model(&models.CourseItemResult{}).
+		Where("scaling_id = ?", scaling.ID).
+		Distinct().
+		Pluck("student_id", &studentIDs).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load results",
+			Details: err.Error(),
+		}
+	}
+
+	if err := revertScaling(transaction, &scaling, userData.ID); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	students := map[uint]bool{}
+	for _, studentID := range studentIDs {
+		students[studentID] = true
+	}
+	if err := updateScaledStudents(transaction, params.CourseID, courseItem, students); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, ScalingRevertResponse{
+		Success: true,
+	})
+
+	return nil
+}
+
+// updateScaledStudents recalculates selected results of students whose points were scaled
+func updateScaledStudents(dbRef *gorm.DB, courseID uint, courseItem *models.CourseItem, students map[uint]bool) *common.ErrorResponse {
+	rootCourseItem := courseItem.ID
+	if courseItem.ParentID != nil {
+		rootCourseItem = *courseItem.ParentID
+	}
+
+	for studentID := range students {
+		if err := services_course_item.UpdateSelectedResults(dbRef, courseID, rootCourseItem, studentID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"elogika.vsb.cz/backend/auth"
 	"elogika.vsb.cz/backend/initializers"
 	"elogika.vsb.cz/backend/models"
@@ -8,6 +10,7 @@ import (
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
 	"elogika.vsb.cz/backend/modules/course_items/dtos"
+	"elogika.vsb.cz/backend/repositories"
 	services_course_item "elogika.vsb.cz/backend/services/courseItem"
 	"elogika.vsb.cz/backend/utils"
 	"elogika.vsb.cz/backend/utils/tiptap"
@@ -17,6 +20,7 @@ import (
 type ActivityDetailCourseItemUpdateRequest struct {
 	Description    *models.TipTapContent `json:"description" ts_type:"JSONContent"`    // Assignemnt of activity
 	ExpectedResult *models.TipTapContent `json:"expectedResult" ts_type:"JSONContent"` // Expected result of the activity
+	RubricID       *uint                 `json:"rubricId" ts_type:"number | null"`     // Rubric used when grading instances
 }
 
 type GroupDetailCourseItemUpdateRequest struct {
@@ -26,15 +30,29 @@ type GroupDetailCourseItemUpdateRequest struct {
 }
 
 type TestDetailCourseItemUpdateRequest struct {
-	TestType        enums.QuestionTypeEnum `json:"testType"`        // Further filters questions used for this test
-	TimeLimit       uint                   `json:"timeLimit"`       // Time limit for this test
-	ShowResults     bool                   `json:"showResults"`     // Show results to student after finishing test
-	ShowTest        bool                   `json:"showTest"`        // Show the exact test (questions)
-	ShowCorrectness bool                   `json:"showCorrectness"` // Show what answers were correct
-	AllowOffline    bool                   `json:"allowOffline"`    // Allow offline answer sending
-	IsPaper         bool                   `json:"isPaper"`         // Test is written physically on paper
-	IPRanges        string                 `json:"ipRanges"`        // Allowed ip ranges to write a test
-	TestTemplateID  uint                   `json:"testTemplateId"`  // Id of selected test template
+	TestType               enums.QuestionTypeEnum       `json:"testType"`               // Further filters questions used for this test
+	TimeLimit              uint                         `json:"timeLimit"`              // Time limit for this test
+	ShowResults            bool                         `json:"showResults"`            // Show results to student after finishing test
+	ShowTest               bool                         `json:"showTest"`               // Show the exact test (questions)
+	ShowCorrectness        bool                         `json:"showCorrectness"`        // Show what answers were correct
+	AllowOffline           bool                         `json:"allowOffline"`           // Allow offline answer sending
+	IsPaper                bool                         `json:"isPaper"`                // Test is written physically on paper
+	IPRanges               string                       `json:"ipRanges"`               // Allowed ip ranges to write a test
+	RequireSEB             bool                         `json:"requireSeb"`             // Test can be written only inside Safe Exam Browser
+	SEBBrowserExamKeys     string                       `json:"sebBrowserExamKeys"`     // Allowed browser exam keys separated by ";"
+	SEBQuitPassword        string                       `json:"sebQuitPassword"`        // Password required to quit Safe Exam Browser
+	NavigationMode         enums.TestNavigationModeEnum `json:"navigationMode"`         // Whether student can return to previous questions
+	QuestionTimeLimits     bool                         `json:"questionTimeLimits"`     // Each question has its own time window
+	BlindGrading           bool                         `json:"blindGrading"`           // Student identity is hidden in grading queue
+	ModerationPercentage   uint                         `json:"moderationPercentage"`   // Percentage of instances double-marked by second grader, 0 disables moderation
+	ModerationThreshold    float64                      `json:"moderationThreshold"`    // Difference of marks in percent which is flagged for moderator
+	ResultsReleaseMode     enums.ReleaseModeEnum        `json:"resultsReleaseMode"`     // How results are released, manual mode follows showResults
+	ResultsReleaseAt       *time.Time                   `json:"resultsReleaseAt"`       // Release time of scheduled mode
+	TestReleaseMode        enums.ReleaseModeEnum        `json:"testReleaseMode"`        // How test content is released, manual mode follows showTest
+	TestReleaseAt          *time.Time                   `json:"testReleaseAt"`          // Release time of scheduled mode
+	CorrectnessReleaseMode enums.ReleaseModeEnum        `json:"correctnessReleaseMode"` // How correctness is released, manual mode follows showCorrectness
+	CorrectnessReleaseAt   *time.Time                   `json:"correctnessReleaseAt"`   // Release time of scheduled mode
+	TestTemplateID         uint                         `json:"testTemplateId"`         // Id of selected test template
 }
 
 // @Description Request to update course item
@@ -48,6 +66,7 @@ type CourseItemUpdateRequest struct {
 	AllowNegative     bool                        `json:"allowNegative"`     // Allow passing negative points outside of this item
 	EvaluateByAttempt enums.EvaluateByAttemptEnum `json:"evaluateByAttempt"` // Evaluation mode
 	IncludeInResults  bool                        `json:"includeInResults"`  // Include in overall results
+	AppealDays        uint                        `json:"appealDays"`        // Days after results are shown when students may request regrade, 0 disables appeals
 	// TODO ManagedBy
 
 	ActivityDetail *ActivityDetailCourseItemUpdateRequest `json:"activityDetail"` // Additional data for type ACTIVITY (homework, project, ...)
@@ -130,6 +149,40 @@ func Update(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 		}
 	}
 
+	if reqData.TestDetail != nil && (reqData.TestDetail.ModerationPercentage > 100 || reqData.TestDetail.ModerationThreshold < 0) {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Moderation percentage must be at most 100 and threshold non negative",
+			FormErrors: common.ErrorObject{
+				"testDetail": common.ErrorObject{
+					"moderationPercentage": "Moderation percentage must be at most 100 and threshold non negative",
+				},
+			},
+		}
+	}
+
+	if reqData.TestDetail != nil {
+		if err := validateReleaseSettings(
+			releaseSetting{"resultsRelease", &reqData.TestDetail.ResultsReleaseMode, reqData.TestDetail.ResultsReleaseAt},
+			releaseSetting{"testRelease", &reqData.TestDetail.TestReleaseMode, reqData.TestDetail.TestReleaseAt},
+			releaseSetting{"correctnessRelease", &reqData.TestDetail.CorrectnessReleaseMode, reqData.TestDetail.CorrectnessReleaseAt},
+		); err != nil {
+			return err
+		}
+	}
+
+	if courseItem.Type == enums.CourseItemTypeActivity && reqData.ActivityDetail != nil {
+		if err := repositories.NewRubricRepository().ValidateRubricID(initializers.DB, params.CourseID, reqData.ActivityDetail.RubricID); err != nil {
+			if err.FormErrors != nil {
+				err.FormErrors = common.ErrorObject{
+					"activityDetail": err.FormErrors,
+				}
+			}
+			return err
+		}
+	}
+
 	courseItem.Version = courseItem.Version + 1
 	courseItem.Name = reqData.Name
 	courseItem.PointsMin = reqData.PointsMin
@@ -139,6 +192,7 @@ func Update(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 	courseItem.AllowNegative = reqData.AllowNegative
 	courseItem.EvaluateByAttempt = reqData.EvaluateByAttempt
 	courseItem.IncludeInResults = reqData.IncludeInResults
+	courseItem.AppealDays = reqData.AppealDays
 
 	transaction := initializers.DB.Begin()
 
@@ -150,6 +204,7 @@ func Update(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 			return err
 		}
 		courseItem.ActivityDetail.ExpectedResult = reqData.ActivityDetail.ExpectedResult
+		courseItem.ActivityDetail.RubricID = reqData.ActivityDetail.RubricID
 		err = tiptap.FindAndSaveRelations(transaction, userData.ID, reqData.ActivityDetail.ExpectedResult, &courseItem.ActivityDetail, "ExpectedResultFiles")
 		if err != nil {
 			return err
@@ -182,6 +237,20 @@ func Update(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 		courseItem.TestDetail.AllowOffline = reqData.TestDetail.AllowOffline
 		courseItem.TestDetail.IsPaper = reqData.TestDetail.IsPaper
 		courseItem.TestDetail.IPRanges = reqData.TestDetail.IPRanges
+		courseItem.TestDetail.RequireSEB = reqData.TestDetail.RequireSEB
+		courseItem.TestDetail.SEBBrowserExamKeys = reqData.TestDetail.SEBBrowserExamKeys
+		courseItem.TestDetail.SEBQuitPassword = reqData.TestDetail.SEBQuitPassword
+		courseItem.TestDetail.NavigationMode = reqData.TestDetail.NavigationMode
+		courseItem.TestDetail.QuestionTimeLimits = reqData.TestDetail.QuestionTimeLimits
+		courseItem.TestDetail.BlindGrading = reqData.TestDetail.BlindGrading
+		courseItem.TestDetail.ModerationPercentage = reqData.TestDetail.ModerationPercentage
+		courseItem.TestDetail.ModerationThreshold = reqData.TestDetail.ModerationThreshold
+		courseItem.TestDetail.ResultsReleaseMode = reqData.TestDetail.ResultsReleaseMode
+		courseItem.TestDetail.ResultsReleaseAt = reqData.TestDetail.ResultsReleaseAt
+		courseItem.TestDetail.TestReleaseMode = reqData.TestDetail.TestReleaseMode
+		courseItem.TestDetail.TestReleaseAt = reqData.TestDetail.TestReleaseAt
+		courseItem.TestDetail.CorrectnessReleaseMode = reqData.TestDetail.CorrectnessReleaseMode
+		courseItem.TestDetail.CorrectnessReleaseAt = reqData.TestDetail.CorrectnessReleaseAt
 		courseItem.TestDetail.TestTemplateID = reqData.TestDetail.TestTemplateID
 		if err := transaction.Save(&courseItem.TestDetail).Error; err != nil {
 			transaction.Rollback()
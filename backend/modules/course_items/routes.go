@@ -17,4 +17,9 @@ func RegisterRoutes(rg *gin.RouterGroup) {
 
 	rg.GET("courses/:courseId/items/:courseItemId/results", wrappers.WithUserDataRole(handlers.ListResults))
 	rg.PUT("courses/:courseId/items/:courseItemId/results/:resultId", wrappers.WithUserDataRole(handlers.SelectResult))
+
+	rg.GET("courses/:courseId/items/:courseItemId/scaling/:termId", wrappers.WithUserDataRole(handlers.ListScalings))
+	rg.POST("courses/:courseId/items/:courseItemId/scaling/:termId", wrappers.WithUserDataRole(handlers.ApplyScaling))
+	rg.DELETE("courses/:courseId/items/:courseItemId/scaling/:termId", wrappers.WithUserDataRole(handlers.RevertScaling))
+	rg.POST("courses/:courseId/items/:courseItemId/scaling/:termId/preview", wrappers.WithUserDataRole(handlers.PreviewScaling))
 }
@@ -0,0 +1,90 @@
+package crons
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// Temporary folders which do not belong to any job, e.g. of synchronous prints
+const orphanFolderAge = 24 * time.Hour
+
+// CleanupPrintJobs removes printed files of jobs after retention period and temporary folders which do not belong
+// to any job
+func CleanupPrintJobs() {
+	now := time.Now()
+
+	var expired []*models.PrintJob
+	if err := initializers.DB.
+		Where("status IN ?", []enums.JobStatusEnum{enums.JobStatusFinished, enums.JobStatusFailed}).
+		Where("folder <> ''").
+		Where("finished_at < ?", now.Add(-initializers.GlobalAppConfig.PRINT_RETENTION)).
+		Find(&expired).Error; err != nil {
+		log.Println("Failed to load expired print jobs", err)
+		return
+	}
+
+	for _, job := range expired {
+		if err := os.RemoveAll(job.Folder); err != nil {
+			log.Printf("failed to remove %s: %v", job.Folder, err)
+			continue
+		}
+
+		job.Folder = ""
+		job.OutputPath = ""
+		if err := initializers.DB.
+			Model(job).
+			Select("folder", "output_path").
+			Updates(job).Error; err != nil {
+			log.Println("Failed to clean print job", job.ID, err)
+		}
+	}
+
+	var folders []string
+	if err := initializers.DB.
+		Model(&models.PrintJob{}).
+		Where("folder <> ''").
+		Pluck("folder", &folders).Error; err != nil {
+		log.Println("Failed to load folders of print jobs", err)
+		return
+	}
+
+	used := make(map[string]bool)
+	for _, folder := range folders {
+		used[filepath.Base(folder)] = true
+	}
+
+	entries, err := os.ReadDir("./temp/")
+	if err != nil {
+		log.Println("Failed to read temp folder", err)
+		return
+	}
+
+	for _, v := range entries {
+		if used[v.Name()] {
+			continue
+		}
+
+		dateTime := strings.Split(v.Name(), ".")[0]
+		if len(dateTime) != 15 {
+			continue
+		}
+
+		t, err := time.ParseInLocation("20060102_150405", dateTime, time.Local)
+		if err != nil {
+			continue
+		}
+
+		if t.Before(now.Add(-orphanFolderAge)) {
+			if err := os.RemoveAll("./temp/" + v.Name()); err != nil {
+				log.Printf("failed to remove %s: %v", v.Name(), err)
+			}
+		}
+	}
+}
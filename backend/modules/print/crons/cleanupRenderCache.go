@@ -0,0 +1,16 @@
+package crons
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/utils"
+)
+
+// Rendered content which was not printed for this long is removed from cache
+const renderCacheRetention = 30 * 24 * time.Hour
+
+// CleanupRenderCache removes renders of outdated templates and renders which were not used for a long time
+func CleanupRenderCache() {
+	utils.CleanupRenderCache(initializers.DB, renderCacheRetention)
+}
@@ -0,0 +1,17 @@
+package crons
+
+import (
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/print/handlers"
+	"elogika.vsb.cz/backend/utils"
+)
+
+// RunPrintJobs picks up queued print jobs which were not started, e.g. because of server restart
+func RunPrintJobs() {
+	utils.FailStaleJobs(initializers.DB, &models.PrintJob{}, "Print job was interrupted")
+
+	for _, jobID := range utils.PendingJobIDs(initializers.DB, &models.PrintJob{}, "created_at ASC") {
+		handlers.RunPrintJob(jobID)
+	}
+}
@@ -0,0 +1,40 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type PrintJobDTO struct {
+	ID           uint                    `json:"id"`
+	CreatedAt    time.Time               `json:"createdAt"`
+	CreatedByID  uint                    `json:"createdById"`
+	CourseItemID uint                    `json:"courseItemId"`
+	Status       enums.JobStatusEnum     `json:"status"`
+	Error        string                  `json:"error"`
+	FinishedAt   *time.Time              `json:"finishedAt"`
+	Progress     uint                    `json:"progress"`
+	Total        uint                    `json:"total"`
+	Downloadable bool                    `json:"downloadable"` // Finished job whose PDF was not removed by cleanup yet
+	Options      models.PrintJobOptions  `json:"options"`
+	CompileLog   *models.PrintCompileLog `json:"compileLog"`
+}
+
+func (m PrintJobDTO) From(d *models.PrintJob) PrintJobDTO {
+	return PrintJobDTO{
+		ID:           d.ID,
+		CreatedAt:    d.CreatedAt,
+		CreatedByID:  d.CreatedByID,
+		CourseItemID: d.CourseItemID,
+		Status:       d.Status,
+		Error:        d.Error,
+		FinishedAt:   d.FinishedAt,
+		Progress:     d.Progress,
+		Total:        d.Total,
+		Downloadable: d.Status == enums.JobStatusFinished && d.OutputPath != "",
+		Options:      d.Options,
+		CompileLog:   d.CompileLog,
+	}
+}
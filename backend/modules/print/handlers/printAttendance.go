@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/print/helpers"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Description Request to print attendance sheet and seating plan of term
+type PrintAttendanceRequest struct {
+	CourseItemID uint                `json:"courseItemId" binding:"required"`
+	TermID       uint                `json:"termId" binding:"required"`
+	Layout       *helpers.SeatLayout `json:"layout"` // Seats of classroom, defaults to rows of 6 seats without aisles
+}
+
+// @Summary Print alphabetical attendance sheet with signature column and seating plan where neighbours get different test variants. The seating plan is stored for booklets and instance generation.
+// @Tags Print
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  application/pdf
+// @Param courseId path int true "ID of the corresponding course"
+// @Param body body PrintAttendanceRequest true "Term and seat layout"
+// @Success 200 {file} file "PDF with attendance sheet and seating plan"
+// @Header 200 {int} X-Seat-Conflicts "Number of neighbouring seats with the same variant"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Term not found"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/print/attendance [post]
+func PrintAttendance(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		PrintAttendanceRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole == enums.CourseUserRoleStudent {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	// Check if tutor/garant can view/modify courseItem
+	courseItemService := services_course_item.CourseItemService{}
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, reqData.CourseItemID, userData.ID, userRole, nil, true, nil)
+	if err != nil {
+		return err
+	}
+
+	var course *models.Course
+	if err := initializers.DB.First(&course, params.CourseID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Course not found",
+		}
+	}
+
+	var term *models.Term
+	if err := initializers.DB.
+		Where("id = ?", reqData.TermID).
+		Where("course_item_id = ?", reqData.CourseItemID).
+		First(&term).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Term not found",
+		}
+	}
+
+	// Joined students in alphabetical order
+	var userTerms []*models.UserTerm
+	if err := initializers.DB.
+		InnerJoins("User").
+		Where("term_id = ?", term.ID).
+		Order("\"User\".\"family_name\" ASC").
+		Order("\"User\".\"first_name\" ASC").
+		Find(&userTerms).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load joined students",
+			Details: err.Error(),
+		}
+	}
+	if len(userTerms) == 0 {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "No students joined to term",
+		}
+	}
+
+	// Variants of the term, students with generated instance keep its variant
+	var tests []*models.Test
+	if err := initializers.DB.
+		Where("course_item_id = ?", reqData.CourseItemID).
+		Where("term_id = ?", term.ID).
+		Find(&tests).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to fetch test",
+			Details: err.Error(),
+		}
+	}
+
+	variantsByTest := make(map[uint]string)
+	variantSet := make(map[string]bool)
+	for _, test := range tests {
+		variantsByTest[test.ID] = test.Group
+		variantSet[test.Group] = true
+	}
+	variants := make([]string, 0, len(variantSet))
+	for variant := range variantSet {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+
+	var instances []*models.TestInstance
+	if err := initializers.DB.
+		Where("course_item_id = ?", reqData.CourseItemID).
+		Where("term_id = ?", term.ID).
+		Order("id ASC").
+		Find(&instances).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load test instances",
+			Details: err.Error(),
+		}
+	}
+
+	fixed := make(map[uint]string)
+	for _, instance := range instances {
+		// Latest instance of the student is used
+		if variant, ok := variantsByTest[instance.TestID]; ok {
+			fixed[instance.ParticipantID] = variant
+		}
+	}
+
+	students := make([]*models.User, 0, len(userTerms))
+	for _, userTerm := range userTerms {
+		students = append(students, userTerm.User)
+	}
+
+	layout := helpers.DefaultSeatLayout(len(students))
+	if reqData.Layout != nil {
+		layout = *reqData.Layout
+	}
+	if err := layout.Validate(); err != nil {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Invalid seat layout",
+			Details: err.Error(),
+		}
+	}
+
+	seated, conflicts, err2 := helpers.AssignSeats(students, fixed, variants, layout)
+	if err2 != nil {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Invalid seat layout",
+			Details: err2.Error(),
+		}
+	}
+
+	// Seating plan replaces the previous one, booklets and generation of instances use it
+	transaction := initializers.DB.Begin()
+
+	if err := transaction.
+		Where("term_id = ?", term.ID).
+		Delete(&models.TermSeat{}).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to delete previous seating plan",
+			Details: err.Error(),
+		}
+	}
+
+	termSeats := make([]*models.TermSeat, 0, len(seated))
+	for _, student := range seated {
+		termSeats = append(termSeats, &models.TermSeat{
+			TermID:  term.ID,
+			UserID:  student.Student.ID,
+			Row:     student.Row,
+			Column:  student.Column,
+			Variant: student.Variant,
+		})
+	}
+	if err := transaction.CreateInBatches(termSeats, 50).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save seating plan",
+			Details: err.Error(),
+		}
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	workDir, err2 := os.Getwd()
+	if err2 != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to print attendance",
+			Details: err2.Error(),
+		}
+	}
+
+	tmpFolder, err2 := utils.CreateTmpFolder(workDir)
+	if err2 != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to generate PDF file",
+			Details: err2.Error(),
+		}
+	}
+	// PDF is drawn without LaTeX, so it is served right away and removed afterwards
+	defer os.RemoveAll(tmpFolder)
+
+	outputPath := filepath.Join(tmpFolder, "attendance.pdf")
+	if err := helpers.PrintAttendance(seated, layout, conflicts, term, courseItem, course, workDir, outputPath); err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to print attendance",
+			Details: err.Error(),
+		}
+	}
+
+	c.Header("X-Seat-Conflicts", strconv.Itoa(conflicts))
+	c.FileAttachment(outputPath, "attendance.pdf")
+
+	return nil
+}
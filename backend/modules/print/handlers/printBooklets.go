@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/print/helpers"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// @Description Request to print personalized test booklets of students joined to term
+type PrintBookletsRequest struct {
+	CourseItemID         uint            `json:"courseItemId" binding:"required"`
+	TermID               uint            `json:"termId" binding:"required"`
+	SeparateAnswerSheets bool            `json:"separateAnswerSheets"`
+	Seats                map[uint]string `json:"seats"` // Seat labels by test instance ID, defaults to seat from seating plan of term
+}
+
+// @Summary Queue printing of personalized test booklets with cover page, test content and pre-filled answer sheets for every student joined to term
+// @Tags Print
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param body body PrintBookletsRequest true "Term and print options"
+// @Success 200 {object} PrintTestResponse "Print job, its zip with PDF of every student and merged PDF is downloaded once finished"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Term not found"
+// @Failure 409 {object} common.ErrorResponse "Some students do not have generated test instance"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/print/booklets [post]
+func PrintBooklets(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		PrintBookletsRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole == enums.CourseUserRoleStudent {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	// Check if tutor/garant can view/modify courseItem
+	courseItemService := services_course_item.CourseItemService{}
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, reqData.CourseItemID, userData.ID, userRole, nil, true, nil)
+	if err != nil {
+		return err
+	}
+
+	// Missing instances are reported right away instead of failing the job
+	if _, _, err := loadBookletInstances(courseItem.ID, reqData.TermID); err != nil {
+		return err
+	}
+
+	options := models.PrintJobOptions{
+		SeparateAnswerSheets: reqData.SeparateAnswerSheets,
+		TermID:               &reqData.TermID,
+		Booklets:             true,
+		Seats:                reqData.Seats,
+	}
+
+	transaction := initializers.DB.Begin()
+
+	job, err := CreatePrintJob(transaction, params.CourseID, courseItem.ID, options, userData.ID, userRole)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	go RunPrintJob(job.ID)
+
+	c.JSON(200, PrintTestResponse{
+		JobID: job.ID,
+	})
+
+	return nil
+}
+
+// loadBookletInstances returns students joined to term in alphabetical order with their latest test instances.
+// Every student must have an instance.
+func loadBookletInstances(courseItemID uint, termID uint) ([]*models.UserTerm, map[uint]*models.TestInstance, *common.ErrorResponse) {
+	var term *models.Term
+	if err := initializers.DB.
+		Where("id = ?", termID).
+		Where("course_item_id = ?", courseItemID).
+		First(&term).Error; err != nil {
+		return nil, nil, &common.ErrorResponse{
+			Code:    404,
+			Message: "Term not found",
+		}
+	}
+
+	// Joined students in alphabetical order
+	var userTerms []*models.UserTerm
+	if err := initializers.DB.
+		InnerJoins("User").
+		Where("term_id = ?", term.ID).
+		Order("\"User\".\"family_name\" ASC").
+		Order("\"User\".\"first_name\" ASC").
+		Find(&userTerms).Error; err != nil {
+		return nil, nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load joined students",
+			Details: err.Error(),
+		}
+	}
+
+	var instances []*models.TestInstance
+	if err := initializers.DB.
+		Joins("Participant").
+		Where("test_instances.course_item_id = ?", courseItemID).
+		Where("test_instances.term_id = ?", term.ID).
+		Order("test_instances.id ASC").
+		Find(&instances).Error; err != nil {
+		return nil, nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load test instances",
+			Details: err.Error(),
+		}
+	}
+
+	instancesByParticipant := make(map[uint]*models.TestInstance)
+	for _, instance := range instances {
+		// Latest instance of the student is printed
+		instancesByParticipant[instance.ParticipantID] = instance
+	}
+
+	missing := []string{}
+	for _, userTerm := range userTerms {
+		if _, ok := instancesByParticipant[userTerm.UserID]; !ok {
+			missing = append(missing, userTerm.User.FullName())
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, &common.ErrorResponse{
+			Code:    409,
+			Message: "Some students do not have generated test instance",
+			Details: strings.Join(missing, ", "),
+		}
+	}
+	if len(userTerms) == 0 {
+		return nil, nil, &common.ErrorResponse{
+			Code:    422,
+			Message: "No students joined to term",
+		}
+	}
+
+	return userTerms, instancesByParticipant, nil
+}
+
+// loadBooklets loads booklets of all students joined to term of the job with their test variants
+func loadBooklets(job *models.PrintJob) ([]*helpers.Booklet, error) {
+	if job.Options.TermID == nil {
+		return nil, errors.New("term of booklets is not set")
+	}
+
+	userTerms, instancesByParticipant, errRes := loadBookletInstances(job.CourseItemID, *job.Options.TermID)
+	if errRes != nil {
+		return nil, errors.New(errRes.Message)
+	}
+
+	testIDs := []uint{}
+	for _, instance := range instancesByParticipant {
+		testIDs = append(testIDs, instance.TestID)
+	}
+
+	var tests []*models.Test
+	if err := initializers.DB.
+		Where("tests.id IN ?", testIDs).
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.
+				Unscoped().
+				Joins("Question", initializers.DB.Unscoped()).
+				Order("\"order\" ASC")
+		}).
+		Preload("Questions.Answers", func(db *gorm.DB) *gorm.DB {
+			return db.
+				Unscoped().
+				Joins("Answer", initializers.DB.Unscoped()).
+				Order("\"order\" ASC")
+		}).
+		Preload("Course").
+		InnerJoins("Term").
+		Find(&tests).Error; err != nil {
+		return nil, err
+	}
+
+	testsByID := make(map[uint]*models.Test)
+	for _, test := range tests {
+		testsByID[test.ID] = test
+	}
+
+	var termSeats []*models.TermSeat
+	if err := initializers.DB.
+		Where("term_id = ?", *job.Options.TermID).
+		Find(&termSeats).Error; err != nil {
+		return nil, err
+	}
+	seatsByUser := make(map[uint]string)
+	for _, termSeat := range termSeats {
+		seatsByUser[termSeat.UserID] = termSeat.Label()
+	}
+
+	booklets := make([]*helpers.Booklet, 0, len(userTerms))
+	for _, userTerm := range userTerms {
+		instance := instancesByParticipant[userTerm.UserID]
+		test, ok := testsByID[instance.TestID]
+		if !ok {
+			return nil, fmt.Errorf("test %d not found", instance.TestID)
+		}
+
+		// Students without seating plan get empty seat to be filled in by hand
+		seat, ok := job.Options.Seats[instance.ID]
+		if !ok {
+			seat = seatsByUser[userTerm.UserID]
+		}
+
+		booklets = append(booklets, &helpers.Booklet{
+			Instance: instance,
+			Test:     test,
+			Seat:     seat,
+		})
+	}
+
+	return booklets, nil
+}
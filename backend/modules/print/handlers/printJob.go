@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/print/helpers"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"gorm.io/gorm"
+)
+
+// CreatePrintJob stores job printing tests of course item, run it by RunPrintJob after commit
+func CreatePrintJob(dbRef *gorm.DB, courseID uint, courseItemID uint, options models.PrintJobOptions, userID uint, userRole enums.CourseUserRoleEnum) (*models.PrintJob, *common.ErrorResponse) {
+	job := &models.PrintJob{
+		CreatedByID:  userID,
+		CourseID:     courseID,
+		CourseItemID: courseItemID,
+		UserRole:     userRole,
+		Status:       enums.JobStatusPending,
+		Options:      options,
+	}
+
+	if err := dbRef.Create(job).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to create print job",
+			Details: err.Error(),
+		}
+	}
+
+	return job, nil
+}
+
+// RunPrintJob renders requested tests to single PDF available for download.
+func RunPrintJob(jobID uint) {
+	if !utils.ClaimJob(initializers.DB, &models.PrintJob{}, jobID) {
+		return
+	}
+
+	var job models.PrintJob
+	if err := initializers.DB.First(&job, jobID).Error; err != nil {
+		log.Println("Failed to load print job", jobID, err)
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			finishPrintJob(&job, enums.JobStatusFailed, fmt.Sprint(r))
+		}
+	}()
+
+	// Permissions could change since the job was requested
+	courseItemService := services_course_item.CourseItemService{}
+	courseItem, errRes := courseItemService.GetCourseItemByID(initializers.DB, job.CourseID, job.CourseItemID, job.CreatedByID, job.UserRole, nil, true, nil)
+	if errRes != nil {
+		finishPrintJob(&job, enums.JobStatusFailed, errRes.Message)
+		return
+	}
+
+	var (
+		printData []*models.Test
+		booklets  []*helpers.Booklet
+		err       error
+	)
+	if job.Options.Booklets {
+		booklets, err = loadBooklets(&job)
+	} else {
+		printData, err = loadPrintTests(&job)
+	}
+	if err != nil {
+		finishPrintJob(&job, enums.JobStatusFailed, err.Error())
+		return
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		finishPrintJob(&job, enums.JobStatusFailed, err.Error())
+		return
+	}
+
+	tmpFolder, err := utils.CreateTmpFolder(workDir)
+	if err != nil {
+		finishPrintJob(&job, enums.JobStatusFailed, err.Error())
+		return
+	}
+
+	job.Folder = tmpFolder
+	if job.Options.Booklets {
+		job.Total = uint(len(booklets))
+	} else if job.Options.PrintAnswerKeys {
+		job.Total = uint(len(printData))
+	} else {
+		job.Total = helpers.CountPrintUnits(printData, job.Options.PrintAnswerSheets)
+	}
+	if err := initializers.DB.
+		Model(&job).
+		Select("folder", "total").
+		Updates(&job).Error; err != nil {
+		finishPrintJob(&job, enums.JobStatusFailed, err.Error())
+		return
+	}
+
+	progress := func() {
+		if err := initializers.DB.
+			Model(&models.PrintJob{}).
+			Where("id = ?", job.ID).
+			Update("progress", gorm.Expr("progress + 1")).Error; err != nil {
+			log.Println("Failed to update progress of print job", job.ID, err)
+		}
+	}
+
+	var outputPath string
+	if job.Options.Booklets {
+		outputPath, err = printBookletsZip(booklets, courseItem, job.Options.SeparateAnswerSheets, workDir, tmpFolder, progress)
+	} else if job.Options.PrintAnswerKeys {
+		outputPath, err = helpers.PrintAnswerKeys(printData, courseItem, workDir, tmpFolder, progress)
+	} else {
+		outputPath, err = helpers.PrintTests(printData, courseItem, job.Options.PrintAnswerSheets, job.Options.SeparateAnswerSheets, workDir, tmpFolder, progress)
+	}
+	if err != nil {
+		var latexErr *utils.LaTeXError
+		if errors.As(err, &latexErr) {
+			job.CompileLog = &models.PrintCompileLog{
+				Message: latexErr.Message,
+				Line:    latexErr.Line,
+				Context: latexErr.Context,
+				Source:  latexErr.Source,
+				Timeout: latexErr.Timeout,
+				Log:     latexErr.Log,
+			}
+		}
+		finishPrintJob(&job, enums.JobStatusFailed, err.Error())
+		return
+	}
+
+	job.OutputPath = outputPath
+	finishPrintJob(&job, enums.JobStatusFinished, "")
+}
+
+// printBookletsZip prints booklets and zips PDF of every student together with merged PDF for printing
+func printBookletsZip(booklets []*helpers.Booklet, courseItem *models.CourseItem, separateAnswerPage bool, workDir string, tmpFolder string, progress func()) (string, error) {
+	bookletDir, mergedPath, err := helpers.PrintBooklets(booklets, courseItem, separateAnswerPage, workDir, tmpFolder, progress)
+	if err != nil {
+		return "", err
+	}
+
+	if err := utils.CopyFile(mergedPath, filepath.Join(bookletDir, "print.pdf")); err != nil {
+		return "", err
+	}
+
+	zipFile, err := utils.ZipFolder(bookletDir)
+	if err != nil {
+		return "", err
+	}
+	defer zipFile.Close()
+
+	return zipFile.Name(), nil
+}
+
+// loadPrintTests loads test variants selected by job options
+func loadPrintTests(job *models.PrintJob) ([]*models.Test, error) {
+	var printData []*models.Test
+	query := initializers.DB.
+		Where("tests.course_item_id = ?", job.CourseItemID).
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.
+				Unscoped().
+				Joins("Question", initializers.DB.Unscoped()).
+				Order("\"order\" ASC")
+		}).
+		Preload("Questions.Answers", func(db *gorm.DB) *gorm.DB {
+			return db.
+				Unscoped().
+				Joins("Answer", initializers.DB.Unscoped()).
+				Order("\"order\" ASC")
+		}).
+		Preload("Course")
+
+	if job.Options.TermID != nil {
+		query = query.InnerJoins("Term", initializers.DB.Where("Term.id = ?", job.Options.TermID))
+	} else {
+		query = query.InnerJoins("Term")
+	}
+
+	if job.Options.TestID != nil {
+		query = query.Where("tests.id = ?", job.Options.TestID)
+	}
+
+	if job.Options.InstanceID != nil {
+		query = query.Preload("Instances", func(db *gorm.DB) *gorm.DB {
+			return db.Joins("Participant").Where("test_instances.id = ?", job.Options.InstanceID)
+		})
+	} else {
+		query = query.Preload("Instances", func(db *gorm.DB) *gorm.DB {
+			return db.Joins("Participant")
+		})
+	}
+
+	if err := query.Order("tests.id ASC").Find(&printData).Error; err != nil {
+		return nil, err
+	}
+
+	return printData, nil
+}
+
+func finishPrintJob(job *models.PrintJob, status enums.JobStatusEnum, message string) {
+	now := time.Now()
+	job.Status = status
+	job.Error = message
+	job.FinishedAt = &now
+
+	if err := initializers.DB.
+		Model(job).
+		Select("status", "error", "finished_at", "output_path", "compile_log").
+		Updates(job).Error; err != nil {
+		log.Println("Failed to finish print job", job.ID, err)
+	}
+}
+
+// printErrorDetails returns structured compile log for LaTeX errors, message otherwise
+func printErrorDetails(err error) any {
+	var latexErr *utils.LaTeXError
+	if errors.As(err, &latexErr) {
+		return latexErr
+	}
+	return err.Error()
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Downloads PDF of finished print job. Range requests are supported, so interrupted downloads can be resumed.
+// @Tags Print
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  application/pdf
+// @Param courseId path int true "ID of the corresponding course"
+// @Param jobId path int true "ID of the print job"
+// @Success 200 {file} file "PDF file of tests"
+// @Success 206 {file} file "Requested range of PDF file"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Job not found"
+// @Failure 409 {object} common.ErrorResponse "Job is not finished"
+// @Failure 410 {object} common.ErrorResponse "PDF was already removed"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/print/jobs/{jobId}/download [get]
+func PrintJobDownload(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			JobID    uint `uri:"jobId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	query, err := printJobQuery(params.CourseID, userData, userRole)
+	if err != nil {
+		return err
+	}
+
+	var job models.PrintJob
+	if err := query.First(&job, params.JobID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Failed to load print job",
+			Details: err.Error(),
+		}
+	}
+
+	if job.Status != enums.JobStatusFinished {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Print job is not finished",
+		}
+	}
+
+	if job.OutputPath == "" {
+		return &common.ErrorResponse{
+			Code:    410,
+			Message: "Printed file was already removed",
+		}
+	}
+	if _, err := os.Stat(job.OutputPath); err != nil {
+		return &common.ErrorResponse{
+			Code:    410,
+			Message: "Printed file was already removed",
+			Details: err.Error(),
+		}
+	}
+
+	// Served by http.ServeContent, which handles Range and If-Range headers
+	name := fmt.Sprintf("print_%d.pdf", job.ID)
+	if job.Options.Booklets {
+		name = fmt.Sprintf("booklets_%d.zip", job.ID)
+	}
+	c.FileAttachment(job.OutputPath, name)
+
+	return nil
+}
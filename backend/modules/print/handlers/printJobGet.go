@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/print/dtos"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type PrintJobGetResponse struct {
+	Data dtos.PrintJobDTO `json:"data"`
+}
+
+// @Summary Gets state and progress of print job
+// @Tags Print
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param jobId path int true "ID of the print job"
+// @Success 200 {object} PrintJobGetResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Job not found"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/print/jobs/{jobId} [get]
+func PrintJobGet(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			JobID    uint `uri:"jobId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	query, err := printJobQuery(params.CourseID, userData, userRole)
+	if err != nil {
+		return err
+	}
+
+	var job models.PrintJob
+	if err := query.First(&job, params.JobID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Failed to load print job",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, PrintJobGetResponse{
+		Data: dtos.PrintJobDTO{}.From(&job),
+	})
+
+	return nil
+}
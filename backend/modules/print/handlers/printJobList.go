@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/print/dtos"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type PrintJobListResponse struct {
+	Items []dtos.PrintJobDTO `json:"items"`
+}
+
+// @Summary Lists print jobs in course, only garants see jobs of other users
+// @Tags Print
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Success 200 {object} PrintJobListResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/print/jobs [get]
+func PrintJobList(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	query, err := printJobQuery(params.CourseID, userData, userRole)
+	if err != nil {
+		return err
+	}
+
+	var jobs []*models.PrintJob
+	if err := query.
+		Order("created_at DESC").
+		Find(&jobs).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load print jobs",
+			Details: err.Error(),
+		}
+	}
+
+	items := []dtos.PrintJobDTO{}
+	for _, job := range jobs {
+		items = append(items, dtos.PrintJobDTO{}.From(job))
+	}
+
+	c.JSON(200, PrintJobListResponse{
+		Items: items,
+	})
+
+	return nil
+}
+
+// printJobQuery limits print jobs to the course, tutors can access only jobs they requested
+func printJobQuery(courseID uint, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) (*gorm.DB, *common.ErrorResponse) {
+	query := initializers.DB.Where("print_jobs.course_id = ?", courseID)
+
+	switch userRole {
+	case enums.CourseUserRoleAdmin, enums.CourseUserRoleGarant:
+		return query, nil
+	case enums.CourseUserRoleTutor:
+		return query.Where("print_jobs.created_by_id = ?", userData.ID), nil
+	default:
+		return nil, &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+}
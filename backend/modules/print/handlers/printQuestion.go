@@ -127,7 +127,7 @@ func PrintQuestion(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enu
 		return &common.ErrorResponse{
 			Code:     500,
 			Message:  "Failed to print questions",
-			Details:  err2.Error(),
+			Details:  printErrorDetails(err2),
 			FileData: zipData,
 		}
 	}
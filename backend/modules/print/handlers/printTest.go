@@ -1,20 +1,15 @@
 package handlers
 
 import (
-	"os"
-
 	"elogika.vsb.cz/backend/auth"
 	"elogika.vsb.cz/backend/initializers"
 	"elogika.vsb.cz/backend/models"
 	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
-	"elogika.vsb.cz/backend/modules/print/helpers"
 	services_course_item "elogika.vsb.cz/backend/services/courseItem"
 	"elogika.vsb.cz/backend/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 // @Description Request to insert new question
@@ -22,19 +17,24 @@ type PrintTestRequest struct {
 	CourseItemID         uint  `json:"courseItemId" binding:"required"`
 	PrintAnswerSheets    bool  `json:"printAnswerSheets"`
 	SeparateAnswerSheets bool  `json:"separateAnswerSheets"`
+	PrintAnswerKeys      bool  `json:"printAnswerKeys"` // Print answer keys with explanations instead of tests
 	TestID               *uint `json:"testId"`
 	InstanceID           *uint `json:"instanceId"`
 	TermID               *uint `json:"termId"`
 }
 
-// @Summary Print tests
+type PrintTestResponse struct {
+	JobID uint `json:"jobId"`
+}
+
+// @Summary Queues printing of tests or answer keys of test variants. Progress and the PDF are available through print jobs.
 // @Tags Print
 // @Security ApiKeyAuth
 // @Accept  json
 // @Produce  json
 // @Param courseId path int true "ID of the corresponding course"
 // @Param body body PrintTestRequest true "New data for question"
-// @Success 200 {file} file "PDF file of tests"
+// @Success 200 {object} PrintTestResponse "Successful operation"
 // @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
 // @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
 // @Failure 422 {object} common.ErrorResponse "Data validation errors"
@@ -66,84 +66,37 @@ func PrintTest(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.C
 		return err
 	}
 
-	var printData []*models.Test
-	query := initializers.DB.
-		Where("tests.course_item_id = ?", reqData.CourseItemID).
-		Preload("Questions", func(db *gorm.DB) *gorm.DB {
-			return db.
-				Unscoped().
-				Joins("Question", initializers.DB.Unscoped()).
-				Order("\"order\" ASC")
-		}).
-		Preload("Questions.Answers", func(db *gorm.DB) *gorm.DB {
-			return db.
-				Unscoped().
-				Joins("Answer", initializers.DB.Unscoped()).
-				Order("\"order\" ASC")
-		}).
-		Preload("Course")
-
-	if reqData.TermID != nil {
-		query = query.InnerJoins("Term", initializers.DB.Where("Term.id = ?", reqData.TermID))
-	} else {
-		query = query.InnerJoins("Term")
+	options := models.PrintJobOptions{
+		PrintAnswerSheets:    reqData.PrintAnswerSheets,
+		SeparateAnswerSheets: reqData.SeparateAnswerSheets,
+		PrintAnswerKeys:      reqData.PrintAnswerKeys,
+		TestID:               reqData.TestID,
+		InstanceID:           reqData.InstanceID,
+		TermID:               reqData.TermID,
 	}
 
-	if reqData.TestID != nil {
-		query = query.Where("tests.id = ?", reqData.TestID)
-	}
+	transaction := initializers.DB.Begin()
 
-	if reqData.InstanceID != nil {
-		query = query.Preload("Instances", func(db *gorm.DB) *gorm.DB {
-			return db.Joins("Participant").Where("test_instances.id = ?", reqData.InstanceID)
-		})
-	} else {
-		query = query.Preload("Instances", func(db *gorm.DB) *gorm.DB {
-			return db.Joins("Participant")
-		})
+	job, err := CreatePrintJob(transaction, params.CourseID, courseItem.ID, options, userData.ID, userRole)
+	if err != nil {
+		transaction.Rollback()
+		return err
 	}
 
-	if err := query.Find(&printData).Error; err != nil {
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
 		return &common.ErrorResponse{
 			Code:    500,
-			Message: "Failed to fetch test",
+			Message: "Failed to commit changes",
 			Details: err.Error(),
 		}
 	}
 
-	workDir, err2 := os.Getwd()
-	if err2 != nil {
-		return &common.ErrorResponse{
-			Code:    500,
-			Message: "Failed to print questions",
-			Details: err2.Error(),
-		}
-	}
+	go RunPrintJob(job.ID)
 
-	tmpFolder, err2 := utils.CreateTmpFolder(workDir)
-	if err2 != nil {
-		return &common.ErrorResponse{
-			Code:    500,
-			Message: "Failed to generate PDF file",
-			Details: err2.Error(),
-		}
-	}
-
-	filepath, err2 := helpers.PrintTests(printData, courseItem, reqData.PrintAnswerSheets, reqData.SeparateAnswerSheets, workDir, tmpFolder)
-	if err2 != nil {
-		zipData, err := utils.ZipFolderError(tmpFolder)
-		if err != nil {
-			return err
-		}
-
-		return &common.ErrorResponse{
-			Code:     500,
-			Message:  "Failed to print questions",
-			Details:  err2.Error(),
-			FileData: zipData,
-		}
-	}
-	c.FileAttachment(filepath, uuid.NewString())
+	c.JSON(200, PrintTestResponse{
+		JobID: job.ID,
+	})
 
 	return nil
 }
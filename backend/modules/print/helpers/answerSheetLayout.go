@@ -0,0 +1,81 @@
+package helpers
+
+import "math"
+
+// SheetRect is rectangle on answer sheet page in pt, origin is in the top left corner of the page
+type SheetRect struct {
+	X float64
+	Y float64
+	W float64
+	H float64
+}
+
+// AnswerRowLayout holds mark boxes of single question row
+type AnswerRowLayout struct {
+	Answers []SheetRect // Answer squares
+	Repairs []SheetRect // Squares of repair column, filled when the original answer is spoiled
+	Repair  SheetRect   // Box marking that the repair column replaces the answer ("op")
+}
+
+// Bounds returns rectangle covering all boxes of the row
+func (r AnswerRowLayout) Bounds() SheetRect {
+	bounds := r.Repair
+	for _, box := range append(append([]SheetRect{}, r.Answers...), r.Repairs...) {
+		minX, minY := math.Min(bounds.X, box.X), math.Min(bounds.Y, box.Y)
+		maxX, maxY := math.Max(bounds.X+bounds.W, box.X+box.W), math.Max(bounds.Y+bounds.H, box.Y+box.H)
+		bounds = SheetRect{X: minX, Y: minY, W: maxX - minX, H: maxY - minY}
+	}
+	return bounds
+}
+
+// AnswerSheetLayout describes marks printed on one answer sheet page, recognizer uses it to read scanned sheets
+type AnswerSheetLayout struct {
+	Width         float64
+	Height        float64
+	LineWidth     float64   // Width of printed lines, outlines are centered on their rectangles
+	Frame         SheetRect // Rectangle around the answer area
+	Triangle      SheetRect // Orientation triangle, its right angle is in the top left corner
+	TestQR        SheetRect
+	ParticipantQR SheetRect
+	Rows          []AnswerRowLayout
+}
+
+// NewAnswerSheetLayout computes positions of marks printed by GenerateAnswerSheets for sheet with given answer square count of every question
+func NewAnswerSheetLayout(answerCounts []int) AnswerSheetLayout {
+	layout := AnswerSheetLayout{
+		Width:     sheetWidth,
+		Height:    sheetHeight,
+		LineWidth: lineWidth,
+		Frame:     SheetRect{X: pageSpacing, Y: pageSpacing + headingHeight, W: sheetWidth - pageSpacing*2, H: sheetHeight - pageSpacing*2 - headingHeight},
+		Triangle:  SheetRect{X: pageSpacing, Y: pageSpacing, W: triangleSize, H: triangleSize},
+		TestQR:    SheetRect{X: sheetWidth - pageSpacing - qrSize, Y: pageSpacing, W: qrSize, H: qrSize},
+		ParticipantQR: SheetRect{
+			X: sheetWidth - pageSpacing - participantQROffset - qrSize,
+			Y: pageSpacing,
+			W: qrSize,
+			H: qrSize,
+		},
+		Rows: make([]AnswerRowLayout, len(answerCounts)),
+	}
+
+	posX := pageSpacing + answerIndent
+	posY := pageSpacing + headingHeight
+	repairX := posX + 11*answerSquareSize + repairAnswersOffset
+	rows, _ := answerOffsets(len(answerCounts))
+
+	for i, count := range answerCounts {
+		y := posY + rows[i]
+		row := AnswerRowLayout{
+			Answers: make([]SheetRect, count),
+			Repairs: make([]SheetRect, count),
+			Repair:  SheetRect{X: repairX + 11*answerSquareSize + repairColumnOffset, Y: y, W: 2 * answerSquareSize, H: answerSquareSize},
+		}
+		for j := 0; j < count; j++ {
+			row.Answers[j] = SheetRect{X: posX + float64(j+1)*answerSquareSize, Y: y, W: answerSquareSize, H: answerSquareSize}
+			row.Repairs[j] = SheetRect{X: repairX + float64(j+1)*answerSquareSize, Y: y, W: answerSquareSize, H: answerSquareSize}
+		}
+		layout.Rows[i] = row
+	}
+
+	return layout
+}
@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -117,15 +118,16 @@ func (tp NodeConvertor) ConvertNodeToLaTeX(node *models.TipTapContent) (string,
 			if err != nil {
 				return "", err
 			}
-			src = strings.ReplaceAll(tp.AssetDir+"/"+src, "\\", "/")
 		} else {
 			src2, err := utils.DownloadFile(src, tp.AssetDir)
 			if err != nil {
 				return "", err
 			} else {
-				src = src2
+				src = filepath.Base(src2)
 			}
 		}
+		// Referenced assets are copied to sandbox of the compilation
+		src = utils.LaTeXAssetPath(src)
 
 		width = min(width/2, 500)
 
@@ -0,0 +1,242 @@
+package helpers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils"
+)
+
+// answerLetter returns label of answer with given position, the same as labels of printed test content
+func answerLetter(index int) string {
+	return string(rune('a' + index))
+}
+
+// correctLetters returns labels of correct answers of test question
+func correctLetters(question *models.TestQuestion) []string {
+	letters := []string{}
+	for i, answer := range question.Answers {
+		if answer.Answer.Correct {
+			letters = append(letters, answerLetter(i))
+		}
+	}
+	return letters
+}
+
+// GenerateAnswerKey renders key of the test variant. The first page contains grid of correct answers of all
+// questions, the following pages contain questions with marked correct answers and their explanations.
+func GenerateAnswerKey(
+	testData *models.Test,
+	workDir string,
+	assetDir string,
+	outputDir string,
+) (string, int, error) {
+	return renderCached(answerKeySource(testData), outputDir, func() (string, int, error) {
+		latexCode, err := answerKeyLaTeX(testData, workDir, assetDir)
+		if err != nil {
+			return "", 0, err
+		}
+		return compileLaTeX(latexCode, assetDir, outputDir)
+	})
+}
+
+// answerKeyLaTeX converts key of the test variant to LaTeX document
+func answerKeyLaTeX(testData *models.Test, workDir string, assetDir string) (string, error) {
+	nc := NodeConvertor{
+		WorkDir:  workDir,
+		AssetDir: assetDir,
+	}
+
+	latexCode := testContentPreamble + `
+	\begin{document}
+	`
+
+	// Key grid
+	latexCode += `\noindent{\LARGE\textbf{Klíč -- varianta ` + testData.Group + `}}\par\medskip` + "\n"
+	if testData.CourseItem != nil {
+		latexCode += `\noindent ` + testData.CourseItem.Name + `\par\bigskip` + "\n"
+	}
+	latexCode += `\begin{center}\begin{tabular}{|r|l||r|l|}\hline` + "\n"
+	latexCode += `\textbf{Otázka} & \textbf{Správně} & \textbf{Otázka} & \textbf{Správně} \\ \hline` + "\n"
+
+	half := (len(testData.Questions) + 1) / 2
+	for row := 0; row < half; row++ {
+		cells := []string{}
+		for _, i := range []int{row, row + half} {
+			if i >= len(testData.Questions) {
+				cells = append(cells, "", "")
+				continue
+			}
+			question := testData.Questions[i]
+			key := "--"
+			if question.Question.QuestionFormat == enums.QuestionFormatOpen {
+				key = `\textit{otevřená}`
+			} else if letters := correctLetters(question); len(letters) > 0 {
+				key = strings.Join(letters, ", ")
+			}
+			cells = append(cells, strconv.Itoa(i+1), key)
+		}
+		latexCode += strings.Join(cells, " & ") + ` \\ \hline` + "\n"
+	}
+	latexCode += `\end{tabular}\end{center}` + "\n"
+	latexCode += `\clearpage` + "\n"
+
+	// Solutions
+	lastBlockID := uint(0)
+
+	for i, question := range testData.Questions {
+		latexCode += utils.LaTeXSourceMarker(questionLabel(i, question.Question))
+
+		if title := blockHeading(testData, question, &lastBlockID); title != "" {
+			latexCode += `\block{` + title + `}`
+		}
+
+		latexCode += `\question{} `
+		if res, err := nc.ConvertNodeToLaTeX(question.Question.Content); err != nil {
+			return "", err
+		} else {
+			latexCode += res
+		}
+
+		switch question.Question.QuestionFormat {
+		case enums.QuestionFormatOpen:
+		case enums.QuestionFormatTest:
+			latexCode += `\begin{enumerate}[label=\alph*)]`
+
+			for _, answer := range question.Answers {
+				if answer.Answer.Correct {
+					latexCode += `\item \ding{51} \hspace{0.2em}`
+				} else {
+					latexCode += `\item \ding{55} \hspace{0.2em}`
+				}
+				if res, err := nc.ConvertNodeToLaTeX(answer.Answer.Content); err != nil {
+					return "", err
+				} else {
+					latexCode += res
+				}
+
+				if answer.Answer.Explanation != nil && len(answer.Answer.Explanation.Content) > 0 {
+					if res, err := nc.ConvertNodeToLaTeX(answer.Answer.Explanation); err != nil {
+						return "", err
+					} else {
+						latexCode += `\begin{tcolorbox}[colback=white, colframe=gray, boxrule=0.5pt]` + res + `\end{tcolorbox}`
+					}
+				}
+			}
+			latexCode += `\end{enumerate}`
+		default:
+			return "", fmt.Errorf("unexpected enums.QuestionFormatEnum: %#v", question.Question.QuestionFormat)
+		}
+
+		latexCode += "\n\n"
+	}
+
+	latexCode += `\end{document}`
+
+	return latexCode, nil
+}
+
+// answerKeySource describes everything that affects rendered answer key, its hash is the render cache key
+func answerKeySource(testData *models.Test) *models.TipTapContent {
+	source := &models.TipTapContent{
+		Type: "answerKey",
+		Attrs: map[string]interface{}{
+			"group": testData.Group,
+		},
+	}
+	if testData.CourseItem != nil {
+		source.Attrs["courseItem"] = testData.CourseItem.Name
+	}
+
+	lastBlockID := uint(0)
+	for _, question := range testData.Questions {
+		node := &models.TipTapContent{
+			Type: "question",
+			Attrs: map[string]interface{}{
+				"block":  blockHeading(testData, question, &lastBlockID),
+				"format": question.Question.QuestionFormat,
+			},
+			Content: []*models.TipTapContent{question.Question.Content},
+		}
+		for _, answer := range question.Answers {
+			node.Content = append(node.Content, &models.TipTapContent{
+				Type: "answer",
+				Attrs: map[string]interface{}{
+					"correct": answer.Answer.Correct,
+				},
+				Content: []*models.TipTapContent{answer.Answer.Content, answer.Answer.Explanation},
+			})
+		}
+		source.Content = append(source.Content, node)
+	}
+
+	return source
+}
+
+// PrintAnswerKeys generates answer keys of all test variants merged to single PDF. Every key starts on odd page.
+// Progress is called after every rendered key, it may be nil.
+func PrintAnswerKeys(testsData []*models.Test, courseItem *models.CourseItem, workDir string, tmpFolder string, progress func()) (string, error) {
+	assetDir, err := utils.CreateFolder(filepath.Join(tmpFolder, "assets"))
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		keys = make([][]string, len(testsData))
+		errs = make([]error, len(testsData))
+		wg   sync.WaitGroup
+	)
+	if progress == nil {
+		progress = func() {}
+	}
+
+	for i, testData := range testsData {
+		wg.Add(1)
+
+		// Compilations are limited by shared pool of LaTeX workers
+		go func(i int, testData *models.Test) {
+			defer wg.Done()
+
+			testData.CourseItem = courseItem
+			keyOutputDir, err := utils.CreateFolder(filepath.Join(tmpFolder, strconv.Itoa(int(testData.ID))))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			keyPath, keyPages, err := GenerateAnswerKey(testData, workDir, assetDir, keyOutputDir)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to generate key of test %d: %w", testData.ID, err)
+				return
+			}
+
+			if keyPages%2 == 1 {
+				keys[i] = []string{keyPath, workDir + "/assets/blank.pdf"}
+			} else {
+				keys[i] = []string{keyPath}
+			}
+			progress()
+		}(i, testData)
+	}
+
+	wg.Wait()
+
+	joiner := []string{}
+	for i := range testsData {
+		if errs[i] != nil {
+			return "", errs[i]
+		}
+		joiner = append(joiner, keys[i]...)
+	}
+
+	mergeDir, err := utils.CreateFolder(filepath.Join(tmpFolder, "merging"))
+	if err != nil {
+		return "", err
+	}
+	return MergeFiles(joiner, mergeDir), nil
+}
@@ -0,0 +1,383 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"codeberg.org/go-pdf/fpdf"
+	"elogika.vsb.cz/backend/models"
+)
+
+const (
+	defaultSeatColumns = uint(6)
+	attendanceRowSize  = float64(24)
+	aisleSize          = float64(20)
+	maxSeatSize        = float64(70)
+)
+
+// SeatLayout describes seats of classroom, rows and columns are numbered from 1 starting at the front left
+type SeatLayout struct {
+	Rows    uint           `json:"rows" binding:"required,min=1,max=50"`
+	Columns uint           `json:"columns" binding:"required,min=1,max=50"`
+	Aisles  []uint         `json:"aisles"`  // Columns followed by aisle, students across aisle are not neighbours
+	Blocked []SeatPosition `json:"blocked"` // Seats which must stay empty
+}
+
+type SeatPosition struct {
+	Row    uint `json:"row"`
+	Column uint `json:"column"`
+}
+
+// SeatedStudent is student with assigned seat and test variant
+type SeatedStudent struct {
+	Student *models.User
+	Variant string
+	Row     uint
+	Column  uint
+}
+
+// Seat returns label of the seat as row/column
+func (s SeatedStudent) Seat() string {
+	return fmt.Sprintf("%d/%d", s.Row, s.Column)
+}
+
+// DefaultSeatLayout returns layout without aisles large enough for given number of students
+func DefaultSeatLayout(students int) SeatLayout {
+	return SeatLayout{
+		Rows:    uint(math.Max(1, math.Ceil(float64(students)/float64(defaultSeatColumns)))),
+		Columns: defaultSeatColumns,
+	}
+}
+
+// Validate checks that aisles and blocked seats are within the layout
+func (l SeatLayout) Validate() error {
+	for _, aisle := range l.Aisles {
+		if aisle < 1 || aisle >= l.Columns {
+			return fmt.Errorf("aisle after column %d is outside of layout", aisle)
+		}
+	}
+	for _, seat := range l.Blocked {
+		if seat.Row < 1 || seat.Row > l.Rows || seat.Column < 1 || seat.Column > l.Columns {
+			return fmt.Errorf("blocked seat %d/%d is outside of layout", seat.Row, seat.Column)
+		}
+	}
+	return nil
+}
+
+func (l SeatLayout) isBlocked(row uint, column uint) bool {
+	for _, seat := range l.Blocked {
+		if seat.Row == row && seat.Column == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (l SeatLayout) hasAisleAfter(column uint) bool {
+	for _, aisle := range l.Aisles {
+		if aisle == column {
+			return true
+		}
+	}
+	return false
+}
+
+// AssignSeats seats students row by row so that students sitting next to each other or one behind another get
+// different variants. Students with generated instance keep its variant (fixed), the others get the least used
+// variant which differs from their neighbours. Students are returned in the order they were given, with number of
+// neighbouring seats where the same variant could not be avoided.
+func AssignSeats(students []*models.User, fixed map[uint]string, variants []string, layout SeatLayout) ([]*SeatedStudent, int, error) {
+	seats := []SeatPosition{}
+	for row := uint(1); row <= layout.Rows; row++ {
+		for column := uint(1); column <= layout.Columns; column++ {
+			if !layout.isBlocked(row, column) {
+				seats = append(seats, SeatPosition{Row: row, Column: column})
+			}
+		}
+	}
+	if len(seats) < len(students) {
+		return nil, 0, errors.New("seat layout does not have enough seats")
+	}
+
+	// Students waiting for seat, both queues keep the alphabetical order
+	fixedQueues := make(map[string][]*SeatedStudent)
+	freeQueue := []*SeatedStudent{}
+	result := make([]*SeatedStudent, 0, len(students))
+	for _, student := range students {
+		seated := &SeatedStudent{Student: student}
+		result = append(result, seated)
+		if variant, ok := fixed[student.ID]; ok {
+			seated.Variant = variant
+			fixedQueues[variant] = append(fixedQueues[variant], seated)
+		} else {
+			freeQueue = append(freeQueue, seated)
+		}
+	}
+
+	fixedVariants := make([]string, 0, len(fixedQueues))
+	for variant := range fixedQueues {
+		fixedVariants = append(fixedVariants, variant)
+	}
+	sort.Strings(fixedVariants)
+
+	used := make(map[string]int)
+	grid := make(map[SeatPosition]string)
+	conflicts := 0
+	remaining := len(students)
+	for _, seat := range seats {
+		if remaining == 0 {
+			break
+		}
+
+		forbidden := make(map[string]bool)
+		if seat.Column > 1 && !layout.hasAisleAfter(seat.Column-1) {
+			if variant, ok := grid[SeatPosition{Row: seat.Row, Column: seat.Column - 1}]; ok {
+				forbidden[variant] = true
+			}
+		}
+		if variant, ok := grid[SeatPosition{Row: seat.Row - 1, Column: seat.Column}]; ok {
+			forbidden[variant] = true
+		}
+
+		// Largest group of fixed variant which does not conflict
+		var next *SeatedStudent
+		largest := func(allowConflict bool) string {
+			best := ""
+			for _, variant := range fixedVariants {
+				if len(fixedQueues[variant]) == 0 || (!allowConflict && forbidden[variant]) {
+					continue
+				}
+				if best == "" || len(fixedQueues[variant]) > len(fixedQueues[best]) {
+					best = variant
+				}
+			}
+			return best
+		}
+
+		if variant := largest(false); variant != "" {
+			next = fixedQueues[variant][0]
+			fixedQueues[variant] = fixedQueues[variant][1:]
+		} else if len(freeQueue) > 0 {
+			next = freeQueue[0]
+			freeQueue = freeQueue[1:]
+			next.Variant = leastUsedVariant(variants, used, forbidden)
+		} else {
+			variant := largest(true)
+			next = fixedQueues[variant][0]
+			fixedQueues[variant] = fixedQueues[variant][1:]
+		}
+
+		if next.Variant != "" && forbidden[next.Variant] {
+			conflicts++
+		}
+
+		next.Row = seat.Row
+		next.Column = seat.Column
+		grid[seat] = next.Variant
+		used[next.Variant]++
+		remaining--
+	}
+
+	return result, conflicts, nil
+}
+
+// leastUsedVariant returns the least used variant which is not forbidden, or the least used one when all are
+func leastUsedVariant(variants []string, used map[string]int, forbidden map[string]bool) string {
+	best := ""
+	found := false
+	for _, variant := range variants {
+		if forbidden[variant] {
+			continue
+		}
+		if !found || used[variant] < used[best] {
+			best = variant
+			found = true
+		}
+	}
+	if found {
+		return best
+	}
+
+	for _, variant := range variants {
+		if !found || used[variant] < used[best] {
+			best = variant
+			found = true
+		}
+	}
+	return best
+}
+
+// PrintAttendance generates alphabetical attendance list with signature column followed by seating map. Number of
+// neighbours with the same variant is shown on the map, so supervisors can check them.
+func PrintAttendance(seated []*SeatedStudent, layout SeatLayout, conflicts int, term *models.Term, courseItem *models.CourseItem, course *models.Course, workDir string, outputPath string) error {
+	pdf := fpdf.New("P", "pt", "A4", "")
+	pdf.SetFontLocation(workDir)
+	pdf.AddUTF8Font("Arial", "", filepath.Join("assets", "fonts", "ARIAL.TTF"))
+	pdf.SetLineWidth(1)
+	pdf.SetAutoPageBreak(false, pageSpacing)
+
+	headingLines := []string{
+		"Předmět: " + course.Name,
+		"Test: " + courseItem.Name,
+		"Termín: " + term.Name,
+		"Datum: " + term.ActiveFrom.Format("02.01.2006"),
+		"Čas: " + term.ActiveFrom.Local().Format("15:04") + " - " + term.ActiveTo.Local().Format("15:04"),
+		"Místnost: " + term.Classroom,
+	}
+
+	// Attendance list
+	columns := []struct {
+		title string
+		width float64
+	}{
+		{"#", 25},
+		{"Student", 175},
+		{"Login", 75},
+		{"Místo", 45},
+		{"Varianta", 55},
+		{"Podpis", 0},
+	}
+	pw, ph := pdf.GetPageSize()
+	columns[len(columns)-1].width = pw - pageSpacing*2
+	for _, column := range columns[:len(columns)-1] {
+		columns[len(columns)-1].width -= column.width
+	}
+
+	drawTableHeading := func() {
+		pdf.SetFont("Arial", "", 11)
+		pdf.SetX(pageSpacing)
+		for _, column := range columns {
+			pdf.CellFormat(column.width, attendanceRowSize, column.title, "1", 0, "C", false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	pdf.AddPage()
+	pdf.SetFont("Arial", "", 16)
+	pdf.SetXY(pageSpacing, pageSpacing)
+	pdf.CellFormat(pw-pageSpacing*2, 24, "Prezenční listina", "", 2, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 11)
+	for _, line := range headingLines {
+		pdf.SetX(pageSpacing)
+		pdf.CellFormat(pw-pageSpacing*2, 16, line, "", 2, "L", false, 0, "")
+	}
+	pdf.Ln(10)
+	drawTableHeading()
+
+	for i, student := range seated {
+		if pdf.GetY()+attendanceRowSize > ph-pageSpacing {
+			pdf.AddPage()
+			pdf.SetXY(pageSpacing, pageSpacing)
+			drawTableHeading()
+		}
+
+		values := []string{
+			strconv.Itoa(i + 1),
+			student.Student.FamilyName + " " + student.Student.FirstName,
+			student.Student.Username,
+			student.Seat(),
+			student.Variant,
+			"",
+		}
+		pdf.SetX(pageSpacing)
+		for j, column := range columns {
+			align := "L"
+			if j != 1 && j != 2 {
+				align = "C"
+			}
+			pdf.CellFormat(column.width, attendanceRowSize, fitText(pdf, values[j], column.width-4), "1", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	// Seating map
+	pdf.AddPageFormat("L", pdf.GetPageSizeStr("A4"))
+	pw, ph = pdf.GetPageSize()
+
+	pdf.SetFont("Arial", "", 16)
+	pdf.SetXY(pageSpacing, pageSpacing)
+	pdf.CellFormat(pw-pageSpacing*2, 24, "Zasedací pořádek", "", 2, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 11)
+	pdf.SetX(pageSpacing)
+	pdf.CellFormat(pw-pageSpacing*2, 16, courseItem.Name+", "+term.Name+", "+term.Classroom, "", 2, "L", false, 0, "")
+	if conflicts > 0 {
+		pdf.SetX(pageSpacing)
+		pdf.CellFormat(pw-pageSpacing*2, 16, "Sousední místa se stejnou variantou: "+strconv.Itoa(conflicts), "", 2, "L", false, 0, "")
+	}
+
+	deskY := pdf.GetY() + 10
+	pdf.Rect(pw/2-80, deskY, 160, 24, "D")
+	pdf.SetXY(pw/2-80, deskY)
+	pdf.CellFormat(160, 24, "Katedra", "", 0, "C", false, 0, "")
+
+	gridY := deskY + 24 + 20
+	aisles := float64(len(layout.Aisles))
+	seatWidth := math.Min((pw-pageSpacing*2-aisles*aisleSize)/float64(layout.Columns), maxSeatSize*2)
+	seatHeight := math.Min((ph-pageSpacing-gridY)/float64(layout.Rows), maxSeatSize)
+	gridWidth := seatWidth*float64(layout.Columns) + aisles*aisleSize
+	gridX := (pw - gridWidth) / 2
+
+	bySeat := make(map[SeatPosition]*SeatedStudent)
+	for _, student := range seated {
+		bySeat[SeatPosition{Row: student.Row, Column: student.Column}] = student
+	}
+
+	fontSize := math.Max(5, math.Min(9, seatHeight/5))
+	lineHeight := fontSize * 1.2
+	for row := uint(1); row <= layout.Rows; row++ {
+		x := gridX
+		y := gridY + float64(row-1)*seatHeight
+		for column := uint(1); column <= layout.Columns; column++ {
+			if layout.isBlocked(row, column) {
+				pdf.SetFillColor(210, 210, 210)
+				pdf.Rect(x+2, y+2, seatWidth-4, seatHeight-4, "FD")
+			} else {
+				pdf.Rect(x+2, y+2, seatWidth-4, seatHeight-4, "D")
+
+				lines := []string{fmt.Sprintf("%d/%d", row, column)}
+				if student, ok := bySeat[SeatPosition{Row: row, Column: column}]; ok {
+					lines = append(lines,
+						student.Student.FamilyName,
+						student.Student.FirstName,
+						student.Student.Username,
+						"Var. "+student.Variant,
+					)
+				}
+
+				pdf.SetFont("Arial", "", fontSize)
+				pdf.SetXY(x+4, y+4)
+				for _, line := range lines {
+					if pdf.GetY()+lineHeight > y+seatHeight-2 {
+						break
+					}
+					pdf.SetX(x + 4)
+					pdf.CellFormat(seatWidth-8, lineHeight, fitText(pdf, line, seatWidth-8), "", 2, "L", false, 0, "")
+				}
+			}
+
+			x += seatWidth
+			if layout.hasAisleAfter(column) {
+				x += aisleSize
+			}
+		}
+	}
+
+	return pdf.OutputFileAndClose(outputPath)
+}
+
+// fitText shortens text so that it fits into given width with current font
+func fitText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -0,0 +1,129 @@
+package helpers
+
+import (
+	"testing"
+
+	"elogika.vsb.cz/backend/models"
+)
+
+func seatStudents(count int) []*models.User {
+	students := make([]*models.User, count)
+	for i := range students {
+		students[i] = &models.User{ID: uint(i + 1)}
+	}
+	return students
+}
+
+// seatConflicts counts seats whose left or front neighbour has the same variant, seats without variant never conflict
+func seatConflicts(seated []*SeatedStudent, layout SeatLayout) int {
+	grid := make(map[SeatPosition]string)
+	for _, student := range seated {
+		grid[SeatPosition{Row: student.Row, Column: student.Column}] = student.Variant
+	}
+
+	conflicts := 0
+	for seat, variant := range grid {
+		if variant == "" {
+			continue
+		}
+		left, hasLeft := grid[SeatPosition{Row: seat.Row, Column: seat.Column - 1}]
+		front, hasFront := grid[SeatPosition{Row: seat.Row - 1, Column: seat.Column}]
+		if (hasLeft && !layout.hasAisleAfter(seat.Column-1) && left == variant) || (hasFront && front == variant) {
+			conflicts++
+		}
+	}
+	return conflicts
+}
+
+func TestAssignSeats(t *testing.T) {
+	tests := []struct {
+		name          string
+		students      int
+		fixed         map[uint]string
+		variants      []string
+		layout        SeatLayout
+		wantConflicts int
+		wantErr       bool
+	}{
+		{"two variants in grid", 6, nil, []string{"A", "B"}, SeatLayout{Rows: 2, Columns: 3}, 0, false},
+		{"fewer students than seats", 4, nil, []string{"A", "B"}, SeatLayout{Rows: 3, Columns: 3}, 0, false},
+		{"single variant conflicts", 4, nil, []string{"A"}, SeatLayout{Rows: 2, Columns: 2}, 3, false},
+		{"aisle separates neighbours", 2, nil, []string{"A"}, SeatLayout{Rows: 1, Columns: 2, Aisles: []uint{1}}, 0, false},
+		{"blocked seats stay empty", 3, nil, []string{"A", "B"}, SeatLayout{Rows: 2, Columns: 2, Blocked: []SeatPosition{{Row: 1, Column: 1}}}, 1, false},
+		{"fixed variants are kept", 6, map[uint]string{1: "B", 2: "B", 5: "A"}, []string{"A", "B"}, SeatLayout{Rows: 2, Columns: 3}, 0, false},
+		{"fixed variant outside of term variants", 4, map[uint]string{3: "X"}, []string{"A", "B"}, SeatLayout{Rows: 2, Columns: 2}, 1, false},
+		{"fixed variant outside of term variants with room", 4, map[uint]string{3: "X"}, []string{"A", "B"}, SeatLayout{Rows: 1, Columns: 4}, 0, false},
+		{"no variants", 3, nil, []string{}, SeatLayout{Rows: 1, Columns: 3}, 0, false},
+		{"not enough seats", 5, nil, []string{"A", "B"}, SeatLayout{Rows: 2, Columns: 2}, 0, true},
+		{"not enough seats after blocking", 4, nil, []string{"A", "B"}, SeatLayout{Rows: 2, Columns: 2, Blocked: []SeatPosition{{Row: 2, Column: 2}}}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			students := seatStudents(tt.students)
+			seated, conflicts, err := AssignSeats(students, tt.fixed, tt.variants, tt.layout)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error: got %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(seated) != len(students) {
+				t.Fatalf("seated %d students, want %d", len(seated), len(students))
+			}
+
+			taken := make(map[SeatPosition]bool)
+			for i, student := range seated {
+				if student.Student != students[i] {
+					t.Fatalf("student %d is out of order", i)
+				}
+
+				seat := SeatPosition{Row: student.Row, Column: student.Column}
+				if seat.Row < 1 || seat.Row > tt.layout.Rows || seat.Column < 1 || seat.Column > tt.layout.Columns {
+					t.Fatalf("seat %s is outside of layout", student.Seat())
+				}
+				if tt.layout.isBlocked(seat.Row, seat.Column) {
+					t.Fatalf("blocked seat %s is used", student.Seat())
+				}
+				if taken[seat] {
+					t.Fatalf("seat %s is used twice", student.Seat())
+				}
+				taken[seat] = true
+
+				if variant, ok := tt.fixed[student.Student.ID]; ok && student.Variant != variant {
+					t.Fatalf("student %d: got variant %q, want fixed %q", student.Student.ID, student.Variant, variant)
+				}
+			}
+
+			if conflicts != tt.wantConflicts {
+				t.Fatalf("conflicts: got %d, want %d", conflicts, tt.wantConflicts)
+			}
+			if actual := seatConflicts(seated, tt.layout); actual != conflicts {
+				t.Fatalf("reported %d conflicts, seating has %d", conflicts, actual)
+			}
+		})
+	}
+}
+
+func TestSeatLayoutValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  SeatLayout
+		wantErr bool
+	}{
+		{"plain", SeatLayout{Rows: 3, Columns: 4}, false},
+		{"aisles and blocked seats", SeatLayout{Rows: 3, Columns: 4, Aisles: []uint{2}, Blocked: []SeatPosition{{Row: 3, Column: 4}}}, false},
+		{"aisle after last column", SeatLayout{Rows: 3, Columns: 4, Aisles: []uint{4}}, true},
+		{"aisle before first column", SeatLayout{Rows: 3, Columns: 4, Aisles: []uint{0}}, true},
+		{"blocked seat outside", SeatLayout{Rows: 3, Columns: 4, Blocked: []SeatPosition{{Row: 4, Column: 1}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.layout.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("error: got %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"codeberg.org/go-pdf/fpdf"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+)
+
+const coverQRSize = float64(140)
+
+// Booklet is personalized paper test of one student
+type Booklet struct {
+	Instance *models.TestInstance // Instance with preloaded participant
+	Test     *models.Test         // Test variant of the instance with preloaded questions, course and term
+	Seat     string
+}
+
+// PrintBooklets generates one PDF per student with cover page, test content and answer sheets filled with identifiers
+// of the instance. Every part starts on odd page, so booklets can be printed on both sides. Returns folder with
+// student PDFs and merged PDF of all booklets. Progress is called after every booklet, it may be nil.
+func PrintBooklets(booklets []*Booklet, courseItem *models.CourseItem, separateAnswerPage bool, workDir string, tmpFolder string, progress func()) (string, string, error) {
+	if progress == nil {
+		progress = func() {}
+	}
+
+	assetDir, err := utils.CreateFolder(filepath.Join(tmpFolder, "assets"))
+	if err != nil {
+		return "", "", err
+	}
+
+	// Test content is shared by all students of the same variant
+	type content struct {
+		path  string
+		pages int
+		err   error
+	}
+	var (
+		contents = make(map[uint]*content)
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+	)
+	for _, booklet := range booklets {
+		if _, ok := contents[booklet.Test.ID]; ok {
+			continue
+		}
+		result := &content{}
+		contents[booklet.Test.ID] = result
+
+		// Compilations are limited by shared pool of LaTeX workers
+		wg.Add(1)
+		go func(testData *models.Test) {
+			defer wg.Done()
+
+			testData.CourseItem = courseItem
+			testOutputDir, err := utils.CreateFolder(filepath.Join(tmpFolder, strconv.Itoa(int(testData.ID))))
+			if err != nil {
+				mu.Lock()
+				result.err = err
+				mu.Unlock()
+				return
+			}
+
+			path, pages, err := GenerateTestContent(testData, workDir, assetDir, testOutputDir)
+			mu.Lock()
+			result.path, result.pages, result.err = path, pages, err
+			mu.Unlock()
+		}(booklet.Test)
+	}
+	wg.Wait()
+
+	bookletDir, err := utils.CreateFolder(filepath.Join(tmpFolder, "booklets"))
+	if err != nil {
+		return "", "", err
+	}
+	partsDir, err := utils.CreateFolder(filepath.Join(tmpFolder, "parts"))
+	if err != nil {
+		return "", "", err
+	}
+
+	blankPath := filepath.Join(workDir, "assets", "blank.pdf")
+	bookletPaths := make([]string, 0, len(booklets))
+	for _, booklet := range booklets {
+		testContent := contents[booklet.Test.ID]
+		if testContent.err != nil {
+			return "", "", fmt.Errorf("failed to generate content of test %d: %w", booklet.Test.ID, testContent.err)
+		}
+
+		instanceDir, err := utils.CreateFolder(filepath.Join(partsDir, strconv.Itoa(int(booklet.Instance.ID))))
+		if err != nil {
+			return "", "", err
+		}
+
+		coverPath := filepath.Join(instanceDir, "cover.pdf")
+		if err := GenerateBookletCover(booklet, courseItem, workDir, coverPath); err != nil {
+			return "", "", err
+		}
+
+		answerSheetPrinter := AnswerSheetPrinter{
+			WorkDir:    workDir,
+			OutputDir:  instanceDir,
+			OutputName: "sheets.pdf",
+		}
+		answerSheetPath, answerSheetPages := answerSheetPrinter.GenerateAnswerSheets(booklet.Test, booklet.Instance, separateAnswerPage)
+
+		paths := []string{coverPath, blankPath, testContent.path}
+		if testContent.pages%2 == 1 {
+			paths = append(paths, blankPath)
+		}
+		paths = append(paths, answerSheetPath)
+		if answerSheetPages%2 == 1 {
+			paths = append(paths, blankPath)
+		}
+
+		mergeDir, err := utils.CreateFolder(filepath.Join(instanceDir, "merging"))
+		if err != nil {
+			return "", "", err
+		}
+		bookletPath := filepath.Join(bookletDir, bookletFileName(booklet))
+		if err := utils.CopyFile(MergeFiles(paths, mergeDir), bookletPath); err != nil {
+			return "", "", err
+		}
+		bookletPaths = append(bookletPaths, bookletPath)
+		progress()
+	}
+
+	mergeDir, err := utils.CreateFolder(filepath.Join(tmpFolder, "merging"))
+	if err != nil {
+		return "", "", err
+	}
+
+	return bookletDir, MergeFiles(bookletPaths, mergeDir), nil
+}
+
+// GenerateBookletCover draws cover page with student, seat and QR code of the instance
+func GenerateBookletCover(booklet *Booklet, courseItem *models.CourseItem, workDir string, outputPath string) error {
+	testData := booklet.Test
+	participant := booklet.Instance.Participant
+
+	pdf := fpdf.New("P", "pt", "A4", "")
+	pdf.SetFontLocation(workDir)
+	pdf.AddUTF8Font("Arial", "", filepath.Join("assets", "fonts", "ARIAL.TTF"))
+	pdf.SetLineWidth(lineWidth)
+	pdf.AddPage()
+	pw, _, _ := pdf.PageSize(0)
+
+	// Test information
+	pdf.SetFont("Arial", "", 14)
+	pdf.SetXY(pageSpacing, pageSpacing)
+	headingLines := []string{
+		"Předmět: " + testData.Course.Name,
+		"Test: " + courseItem.Name,
+		"Datum: " + testData.Term.ActiveFrom.Format("02.01.2006"),
+		"Čas: " + testData.Term.ActiveFrom.Local().Format("15:04") + " - " + testData.Term.ActiveTo.Local().Format("15:04"),
+		"Varianta: " + testData.Group,
+	}
+	pdf.MultiCell(pw-pageSpacing*3-coverQRSize, 20, strings.Join(headingLines, "\n"), "", "L", false)
+
+	// Instance QR code, the same content as participant QR code of answer sheets
+	qr, err := qrcode.Encode(ParticipantIdentifier(booklet.Instance), qrcode.High, 512)
+	if err != nil {
+		return err
+	}
+	identifier := uuid.NewString()
+	imgOpts := fpdf.ImageOptions{
+		ImageType: "PNG",
+		ReadDpi:   true,
+	}
+	pdf.RegisterImageOptionsReader(identifier, imgOpts, bytes.NewReader(qr))
+	pdf.ImageOptions(identifier, pw-pageSpacing-coverQRSize, pageSpacing, coverQRSize, coverQRSize, false, imgOpts, 0, "")
+	pdf.Rect(pw-pageSpacing-coverQRSize, pageSpacing, coverQRSize, coverQRSize, "")
+
+	// Student
+	boxY := pageSpacing + coverQRSize + 40
+	pdf.Rect(pageSpacing, boxY, pw-pageSpacing*2, 150, "")
+	pdf.SetFont("Arial", "", 26)
+	pdf.SetXY(pageSpacing+20, boxY+20)
+	pdf.CellFormat(pw-pageSpacing*2-40, 36, participant.FullName(), "", 2, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 18)
+	pdf.SetX(pageSpacing + 20)
+	pdf.CellFormat(pw-pageSpacing*2-40, 30, "Login: "+participant.Username, "", 2, "L", false, 0, "")
+	pdf.SetX(pageSpacing + 20)
+	pdf.CellFormat(pw-pageSpacing*2-40, 30, "Místo: "+booklet.Seat, "", 2, "L", false, 0, "")
+
+	if err := pdf.OutputFileAndClose(outputPath); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bookletFileName returns readable unique file name of student booklet
+func bookletFileName(booklet *Booklet) string {
+	participant := booklet.Instance.Participant
+	name := participant.FamilyName + "_" + participant.FirstName + "_" + participant.Username
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+	return fmt.Sprintf("%s_%d.pdf", name, booklet.Instance.ID)
+}
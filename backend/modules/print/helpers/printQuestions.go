@@ -1,11 +1,8 @@
 package helpers
 
 import (
-	"os"
-	"os/exec"
-	"path/filepath"
-
 	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/utils"
 )
 
 func classificationString(link *models.CourseQuestion) string {
@@ -25,6 +22,47 @@ func PrintQuestions(
 	workDir string,
 	assetDir string,
 	outputDir string,
+) (string, error) {
+	outputPath, _, err := renderCached(questionsSource(questions), outputDir, func() (string, int, error) {
+		outputPath, err := printQuestionsLaTeX(questions, workDir, assetDir, outputDir)
+		return outputPath, 0, err
+	})
+	return outputPath, err
+}
+
+// questionsSource describes everything that affects rendered questions, its hash is the render cache key
+func questionsSource(questions []*models.Question) *models.TipTapContent {
+	source := &models.TipTapContent{Type: "questions"}
+
+	for _, question := range questions {
+		node := &models.TipTapContent{
+			Type: "question",
+			Attrs: map[string]interface{}{
+				"title":          question.Title,
+				"classification": classificationString(question.CourseLink),
+			},
+			Content: []*models.TipTapContent{question.Content},
+		}
+		for _, answer := range question.Answers {
+			node.Content = append(node.Content, &models.TipTapContent{
+				Type: "answer",
+				Attrs: map[string]interface{}{
+					"correct": answer.Answer.Correct,
+				},
+				Content: []*models.TipTapContent{answer.Answer.Content},
+			})
+		}
+		source.Content = append(source.Content, node)
+	}
+
+	return source
+}
+
+func printQuestionsLaTeX(
+	questions []*models.Question,
+	workDir string,
+	assetDir string,
+	outputDir string,
 ) (string, error) {
 	nc := NodeConvertor{
 		WorkDir:  workDir,
@@ -67,7 +105,8 @@ func PrintQuestions(
 	\begin{document}
 	`
 
-	for _, question := range questions {
+	for i, question := range questions {
+		latexCode += utils.LaTeXSourceMarker(questionLabel(i, question))
 		latexCode += `\begin{tcolorbox}[colback=white, colframe=white, boxrule=0pt, left=0pt, right=0pt, top=0pt, bottom=0pt]`
 
 		latexCode += `\question{` + question.Title + `}` + `{` + classificationString(question.CourseLink) + `}` + "\n"
@@ -102,21 +141,8 @@ func PrintQuestions(
 
 	latexCode += `\end{document}`
 
-	// Write LaTeX code to file
-	texFile := outputDir + "/output.tex"
-	var err error
-	err = os.WriteFile(texFile, []byte(latexCode), 0644)
-	if err != nil {
-		return "", err
-	}
-
-	// Run pdflatex
-	cmd := exec.Command("pdflatex", "-interaction=nonstopmode", "-output-directory="+outputDir+"/", texFile)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err = cmd.Run()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(outputDir, "output.pdf"), nil
+	release := acquireLaTeXWorker()
+	outputPath, err := utils.RunLaTeX("pdflatex", latexCode, assetDir, outputDir, ".pdf")
+	release()
+	return outputPath, err
 }
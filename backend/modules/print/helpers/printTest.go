@@ -13,101 +13,131 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 )
 
-func PrintTests(testsData []*models.Test, courseItem *models.CourseItem, printAnswerSheet bool, separateAnswerPage bool, workDir string, tmpFolder string) (string, error) {
+// CountPrintUnits returns number of progress steps of PrintTests, i.e. number of printed instances
+// (or variants printed without instances)
+func CountPrintUnits(testsData []*models.Test, printAnswerSheet bool) uint {
+	total := uint(0)
+	for _, testData := range testsData {
+		if printAnswerSheet && len(testData.Instances) > 0 {
+			total += uint(len(testData.Instances))
+		} else {
+			total++
+		}
+	}
+	return total
+}
+
+// PrintTests merges content of all tests (with answer sheets of their instances) to single PDF. Progress is called
+// after every printed instance (or variant printed without instances), it may be nil.
+func PrintTests(testsData []*models.Test, courseItem *models.CourseItem, printAnswerSheet bool, separateAnswerPage bool, workDir string, tmpFolder string, progress func()) (string, error) {
 	var (
-		joiner []string
-		mu     sync.Mutex
+		joiner = make([][]string, len(testsData))
+		errs   = make([]error, len(testsData))
 		wg     sync.WaitGroup
-		sem    = make(chan struct{}, 20) // limit to 10 concurrent generations
 	)
+	if progress == nil {
+		progress = func() {}
+	}
 
-	for _, testData := range testsData {
-		wg.Add(1)
+	assetDir, err := utils.CreateFolder(filepath.Join(tmpFolder, "assets"))
+	if err != nil {
+		return "", err
+	}
 
-		// acquire semaphore
-		sem <- struct{}{}
+	for i, testData := range testsData {
+		wg.Add(1)
 
-		go func(testData *models.Test) {
+		// Compilations are limited by shared pool of LaTeX workers
+		go func(i int, testData *models.Test) {
 			testData.CourseItem = courseItem
 			defer wg.Done()
-			defer func() { <-sem }() // release semaphore
 
-			testOutputDir, err := utils.CreateFolder(
-				filepath.Join(tmpFolder, strconv.Itoa(int(testData.ID))),
-			)
+			paths, err := printTest(testData, printAnswerSheet, separateAnswerPage, workDir, assetDir, tmpFolder, progress)
 			if err != nil {
-				panic(err)
+				errs[i] = fmt.Errorf("failed to print test %d: %w", testData.ID, err)
+				return
 			}
+			joiner[i] = paths
+		}(i, testData)
+	}
 
-			assetDir, err := utils.CreateFolder(filepath.Join(tmpFolder, "assets"))
-			if err != nil {
-				panic(err)
-			}
+	wg.Wait()
 
-			finalTestPath, finalTestPages, err := GenerateTestContent(testData, workDir, assetDir, testOutputDir)
-			if err != nil {
-				panic(err)
-			}
+	paths := []string{}
+	for i := range testsData {
+		if errs[i] != nil {
+			return "", errs[i]
+		}
+		paths = append(paths, joiner[i]...)
+	}
 
-			var paths []string
-
-			if printAnswerSheet {
-				if len(testData.Instances) == 0 {
-					answerSheetDir, err := utils.CreateFolder(filepath.Join(testOutputDir, "instances"))
-					if err != nil {
-						panic(err)
-					}
-					answerSheetPrinter := AnswerSheetPrinter{
-						WorkDir:    workDir,
-						OutputDir:  answerSheetDir,
-						OutputName: "common" + uuid.NewString() + ".pdf",
-					}
-					answerSheetPath, answerSheetPages := answerSheetPrinter.GenerateAnswerSheets(testData, nil, separateAnswerPage)
-					if ((answerSheetPages + finalTestPages) % 2) == 0 {
-						paths = append(paths, answerSheetPath, finalTestPath)
-					} else {
-						paths = append(paths, answerSheetPath, finalTestPath, workDir+"/assets/blank.pdf")
-					}
-				} else {
-					for _, instance := range testData.Instances {
-						answerSheetDir, err := utils.CreateFolder(filepath.Join(testOutputDir, "instances"))
-						if err != nil {
-							panic(err)
-						}
-						answerSheetPrinter := AnswerSheetPrinter{
-							WorkDir:    workDir,
-							OutputDir:  answerSheetDir,
-							OutputName: strconv.Itoa(int(instance.ID)) + ".pdf",
-						}
-						answerSheetPath, answerSheetPages := answerSheetPrinter.GenerateAnswerSheets(testData, &instance, separateAnswerPage)
-						if ((answerSheetPages + finalTestPages) % 2) == 0 {
-							paths = append(paths, answerSheetPath, finalTestPath)
-						} else {
-							paths = append(paths, answerSheetPath, finalTestPath, workDir+"/assets/blank.pdf")
-						}
-					}
-				}
-			} else {
-				paths = append(paths, finalTestPath)
-			}
+	mergeDir, err := utils.CreateFolder(
+		filepath.Join(tmpFolder, "merging"),
+	)
+	if err != nil {
+		return "", err
+	}
+	return MergeFiles(paths, mergeDir), nil
+}
 
-			// append to shared slice safely
-			mu.Lock()
-			joiner = append(joiner, paths...)
-			mu.Unlock()
+// printTest renders content of one test variant and answer sheets of its instances. Returns files to merge.
+func printTest(testData *models.Test, printAnswerSheet bool, separateAnswerPage bool, workDir string, assetDir string, tmpFolder string, progress func()) ([]string, error) {
+	testOutputDir, err := utils.CreateFolder(
+		filepath.Join(tmpFolder, strconv.Itoa(int(testData.ID))),
+	)
+	if err != nil {
+		return nil, err
+	}
 
-		}(testData)
+	finalTestPath, finalTestPages, err := GenerateTestContent(testData, workDir, assetDir, testOutputDir)
+	if err != nil {
+		return nil, err
 	}
 
-	wg.Wait()
+	var paths []string
 
-	mergeDir, err := utils.CreateFolder(
-		filepath.Join(tmpFolder, "merging"),
-	)
+	if !printAnswerSheet {
+		progress()
+		return []string{finalTestPath}, nil
+	}
+
+	answerSheetDir, err := utils.CreateFolder(filepath.Join(testOutputDir, "instances"))
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	if len(testData.Instances) == 0 {
+		answerSheetPrinter := AnswerSheetPrinter{
+			WorkDir:    workDir,
+			OutputDir:  answerSheetDir,
+			OutputName: "common" + uuid.NewString() + ".pdf",
+		}
+		answerSheetPath, answerSheetPages := answerSheetPrinter.GenerateAnswerSheets(testData, nil, separateAnswerPage)
+		if ((answerSheetPages + finalTestPages) % 2) == 0 {
+			paths = append(paths, answerSheetPath, finalTestPath)
+		} else {
+			paths = append(paths, answerSheetPath, finalTestPath, workDir+"/assets/blank.pdf")
+		}
+		progress()
+		return paths, nil
 	}
-	return MergeFiles(joiner, mergeDir), nil
+
+	for _, instance := range testData.Instances {
+		answerSheetPrinter := AnswerSheetPrinter{
+			WorkDir:    workDir,
+			OutputDir:  answerSheetDir,
+			OutputName: strconv.Itoa(int(instance.ID)) + ".pdf",
+		}
+		answerSheetPath, answerSheetPages := answerSheetPrinter.GenerateAnswerSheets(testData, &instance, separateAnswerPage)
+		if ((answerSheetPages + finalTestPages) % 2) == 0 {
+			paths = append(paths, answerSheetPath, finalTestPath)
+		} else {
+			paths = append(paths, answerSheetPath, finalTestPath, workDir+"/assets/blank.pdf")
+		}
+		progress()
+	}
+
+	return paths, nil
 }
 
 func MergeFiles(filesToJoin []string, tmpPath string) string {
@@ -3,29 +3,40 @@ package helpers
 import (
 	"errors"
 	"fmt"
-	"io"
-	"os"
-	"os/exec"
+	"log"
 	"path/filepath"
+	"sync"
 
+	"elogika.vsb.cz/backend/initializers"
 	"elogika.vsb.cz/backend/models"
 	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils"
 	"github.com/google/uuid"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 )
 
-func GenerateTestContent(
-	testData *models.Test,
-	workDir string,
-	assetDir string,
-	outputDir string,
-) (string, int, error) {
-	nc := NodeConvertor{
-		WorkDir:  workDir,
-		AssetDir: assetDir,
-	}
+var (
+	latexWorkers     chan struct{}
+	latexWorkersOnce sync.Once
+)
+
+// acquireLaTeXWorker blocks until pdflatex process can be started, so that concurrent print jobs do not exhaust the
+// server. Returned function releases the worker.
+func acquireLaTeXWorker() func() {
+	latexWorkersOnce.Do(func() {
+		workers := int64(1)
+		if initializers.GlobalAppConfig != nil && initializers.GlobalAppConfig.PRINT_WORKERS > 0 {
+			workers = initializers.GlobalAppConfig.PRINT_WORKERS
+		}
+		latexWorkers = make(chan struct{}, workers)
+	})
+
+	latexWorkers <- struct{}{}
+	return func() { <-latexWorkers }
+}
 
-	latexCode := `
+// testContentPreamble is shared LaTeX preamble of documents with test content
+const testContentPreamble = `
 	\documentclass{article}
 	\usepackage[a4paper, margin=15mm]{geometry}
 	\usepackage[utf8]{inputenc}
@@ -57,30 +68,47 @@ func GenerateTestContent(
 		\refstepcounter{question}%
 		\bigskip\par\noindent{\Large\textbf{\thequestion. otázka}}\par\medskip
 	}
+`
 
+func GenerateTestContent(
+	testData *models.Test,
+	workDir string,
+	assetDir string,
+	outputDir string,
+) (string, int, error) {
+	return renderCached(testContentSource(testData), outputDir, func() (string, int, error) {
+		latexCode, err := testContentLaTeX(testData, workDir, assetDir)
+		if err != nil {
+			return "", 0, err
+		}
+		return compileLaTeX(latexCode, assetDir, outputDir)
+	})
+}
+
+// testContentLaTeX converts test content to LaTeX document
+func testContentLaTeX(testData *models.Test, workDir string, assetDir string) (string, error) {
+	nc := NodeConvertor{
+		WorkDir:  workDir,
+		AssetDir: assetDir,
+	}
+
+	latexCode := testContentPreamble + `
 	\begin{document}
 	`
 
 	lastBlockID := uint(0)
 
-	for _, question := range testData.Questions {
+	for i, question := range testData.Questions {
+		latexCode += utils.LaTeXSourceMarker(questionLabel(i, question.Question))
 		latexCode += `\begin{minipage}{\textwidth}`
 
-		if question.BlockID != lastBlockID {
-			lastBlockID = question.BlockID
-			for _, block := range testData.Blocks {
-				if block.ID == question.BlockID {
-					if block.ShowName {
-						latexCode += `\block{` + block.Title + `}`
-					}
-					break
-				}
-			}
+		if title := blockHeading(testData, question, &lastBlockID); title != "" {
+			latexCode += `\block{` + title + `}`
 		}
 
 		latexCode += `\question{} `
 		if res, err := nc.ConvertNodeToLaTeX(question.Question.Content); err != nil {
-			return "", 0, err
+			return "", err
 		} else {
 			latexCode += res
 		}
@@ -95,14 +123,14 @@ func GenerateTestContent(
 
 			for _, answer := range question.Answers {
 				if res, err := nc.ConvertNodeToLaTeX(answer.Answer.Content); err != nil {
-					return "", 0, err
+					return "", err
 				} else {
 					latexCode += `\item ` + res
 				}
 			}
 			latexCode += `\end{enumerate}`
 		default:
-			return "", 0, fmt.Errorf("unexpected enums.QuestionFormatEnum: %#v", question.Question.QuestionFormat)
+			return "", fmt.Errorf("unexpected enums.QuestionFormatEnum: %#v", question.Question.QuestionFormat)
 		}
 
 		latexCode += `\end{minipage}` + "\n\n"
@@ -114,28 +142,91 @@ func GenerateTestContent(
 
 	latexCode += `\end{document}`
 
-	// Write LaTeX code to file
+	return latexCode, nil
+}
+
+// testContentSource describes everything that affects rendered test content, its hash is the render cache key
+func testContentSource(testData *models.Test) *models.TipTapContent {
+	source := &models.TipTapContent{Type: "testContent"}
+
+	lastBlockID := uint(0)
+	for _, question := range testData.Questions {
+		node := &models.TipTapContent{
+			Type: "question",
+			Attrs: map[string]interface{}{
+				"block":       blockHeading(testData, question, &lastBlockID),
+				"format":      question.Question.QuestionFormat,
+				"answerSpace": question.Question.IncludeAnswerSpace,
+			},
+			Content: []*models.TipTapContent{question.Question.Content},
+		}
+		for _, answer := range question.Answers {
+			node.Content = append(node.Content, answer.Answer.Content)
+		}
+		source.Content = append(source.Content, node)
+	}
+
+	return source
+}
+
+// questionLabel identifies question in compile errors
+func questionLabel(index int, question *models.Question) string {
+	return fmt.Sprintf("question %d \"%s\" (ID %d)", index+1, question.Title, question.ID)
+}
 
-	uniqueName := "output" + uuid.NewString()
+// blockHeading returns title of block printed before the question, or empty string when the question continues
+// in the same block or the block name is hidden
+func blockHeading(testData *models.Test, question *models.TestQuestion, lastBlockID *uint) string {
+	if question.BlockID == *lastBlockID {
+		return ""
+	}
 
-	texFile := outputDir + "/" + uniqueName + ".tex"
-	var err error
-	err = os.WriteFile(texFile, []byte(latexCode), 0644)
+	*lastBlockID = question.BlockID
+	for _, block := range testData.Blocks {
+		if block.ID == question.BlockID {
+			if block.ShowName {
+				return block.Title
+			}
+			break
+		}
+	}
+	return ""
+}
+
+// renderCached returns PDF of source from render cache. Otherwise the PDF is rendered by build and stored to cache.
+func renderCached(source *models.TipTapContent, outputDir string, build func() (string, int, error)) (string, int, error) {
+	contentHash, err := source.Hash()
 	if err != nil {
 		return "", 0, err
 	}
 
-	// Run pdflatex
-	cmd := exec.Command("pdflatex", "-interaction=batchmode", "-halt-on-error", "-output-directory="+outputDir+"/", texFile)
-	cmd.Stdout = io.Discard
-	cmd.Stderr = io.Discard
-	cmd.Stdin = nil
-	err = cmd.Run()
+	if cached := utils.GetCachedRender(initializers.DB, utils.RenderKindPDF, contentHash, utils.PDFTemplateVersion); cached != nil {
+		cachedPath := filepath.Join(outputDir, "cached"+uuid.NewString()+".pdf")
+		if err := utils.CopyFile(filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, cached.File.StoredName), cachedPath); err == nil {
+			return cachedPath, cached.Pages, nil
+		}
+	}
+
+	outputPath, pages, err := build()
 	if err != nil {
 		return "", 0, err
 	}
 
-	returnPath := filepath.Join(outputDir, uniqueName+".pdf")
+	if _, err := utils.StoreCachedRender(initializers.DB, utils.RenderKindPDF, contentHash, utils.PDFTemplateVersion, outputPath, "application/pdf", pages, 0); err != nil {
+		log.Println("Failed to store rendered PDF to cache", err)
+	}
+
+	return outputPath, pages, nil
+}
+
+// compileLaTeX compiles LaTeX code to PDF in sandbox. Returns path to PDF and number of its pages.
+func compileLaTeX(latexCode string, assetDir string, outputDir string) (string, int, error) {
+	release := acquireLaTeXWorker()
+	returnPath, err := utils.RunLaTeX("pdflatex", latexCode, assetDir, outputDir, ".pdf")
+	release()
+	if err != nil {
+		return "", 0, err
+	}
 
 	ctx, err := api.ReadContextFile(returnPath)
 	if err != nil {
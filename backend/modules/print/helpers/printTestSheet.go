@@ -20,6 +20,20 @@ const pageSpacing = float64(30)
 const headingHeight = float64(105)
 const maxHeadingLineChars = 42
 
+// Answer area geometry, shared by printing and recognition of scanned sheets
+const answerSquareSize = float64(16)
+const answerIndent = float64(25)
+const repairAnswersOffset = float64(45)
+const repairColumnOffset = float64(60)
+const triangleSize = float64(50)
+const qrSize = float64(90)
+const participantQROffset = float64(100)
+const lineWidth = float64(2.5)
+
+// Page size of A4 in pt
+const sheetWidth = 595.28
+const sheetHeight = 841.89
+
 type SheetTypeEnum string
 
 const (
@@ -49,7 +63,7 @@ func (asp AnswerSheetPrinter) GenerateAnswerSheets(testData *models.Test, testIn
 	pdf.SetFontLocation(asp.WorkDir)
 	pdf.AddUTF8Font("Arial", "", filepath.Join("assets", "fonts", "ARIAL.TTF"))
 	pdf.SetFont("Arial", "", 11)
-	pdf.SetLineWidth(2.5)
+	pdf.SetLineWidth(lineWidth)
 
 	// Genearte sheets
 	for _, sheet := range sheets {
@@ -60,9 +74,9 @@ func (asp AnswerSheetPrinter) GenerateAnswerSheets(testData *models.Test, testIn
 		{
 			// Draw triangle (filled)
 			points := []fpdf.PointType{
-				{X: pageSpacing, Y: pageSpacing},      // top point
-				{X: pageSpacing, Y: pageSpacing + 50}, // bottom-left (right angle here)
-				{X: pageSpacing + 50, Y: pageSpacing}, // bottom-right
+				{X: pageSpacing, Y: pageSpacing},                // top point
+				{X: pageSpacing, Y: pageSpacing + triangleSize}, // bottom-left (right angle here)
+				{X: pageSpacing + triangleSize, Y: pageSpacing}, // bottom-right
 			}
 			pdf.Polygon(points, "D")                                             // D=draw, F=fill
 			pdf.Line(pageSpacing-1.25, pageSpacing, pageSpacing+25, pageSpacing) // vertical
@@ -85,16 +99,15 @@ func (asp AnswerSheetPrinter) GenerateAnswerSheets(testData *models.Test, testIn
 			pdf.MultiCell(220, 14, strings.Join(headingLines, "\n"), "", "L", false)
 
 			// Draw test instance ID-QR
-			testIdentifier := fmt.Sprintf("V1;%d;%d;%s%d",
-				testData.CourseID,
-				testData.ID,
-				sheet.Type,
-				sheet.SheetOrder,
-			)
-			DrawTestIdentifierQR(pdf, pw-pageSpacing, pageSpacing, 90, testIdentifier)
+			instanceID := uint(0)
+			if testInstance != nil {
+				instanceID = testInstance.ID
+			}
+			testIdentifier := TestIdentifierV2(testData.CourseID, testData.ID, instanceID, sheet.Type, sheet.SheetOrder)
+			DrawTestIdentifierQR(pdf, pw-pageSpacing, pageSpacing, qrSize, testIdentifier)
 
 			// Draw participant QR
-			DrawParticipantQR(pdf, pw-pageSpacing-100, pageSpacing, 90, testInstance)
+			DrawParticipantQR(pdf, pw-pageSpacing-participantQROffset, pageSpacing, qrSize, testInstance)
 		}
 		//big rect
 		pdf.Rect(pageSpacing, pageSpacing+headingHeight, pw-pageSpacing*2, ph-pageSpacing*2-headingHeight, "")
@@ -172,42 +185,63 @@ func SplitToSheets(testData *models.Test) []*SheetData {
 }
 
 func DrawAnswers(pdf *fpdf.Fpdf, posX float64, posY float64, w float64, h float64, sheet *SheetData) {
-	posX += 25
+	posX += answerIndent
+
+	rows, headers := answerOffsets(len(sheet.Questions))
+	for _, offsetY := range headers {
+		DrawHeader(pdf, posX, posY+offsetY, answerSquareSize, sheet)
+	}
+
+	for i, q := range sheet.Questions {
+		questionString := strconv.Itoa(int(q.Order + 1)) // Offset so it does not start printing on 1
+		DrawAnswerRow(pdf, posX, posY+rows[i], answerSquareSize, questionString, AnswerBoxCount(q))
+	}
+}
+
+// AnswerBoxCount returns number of answer squares printed for question, open questions use 0-100 % scale
+func AnswerBoxCount(q *models.TestQuestion) int {
+	switch q.Question.QuestionFormat {
+	case enums.QuestionFormatOpen:
+		return 11
+	case enums.QuestionFormatTest:
+		return len(q.Answers)
+	default:
+		panic(fmt.Sprintf("unexpected enums.QuestionFormatEnum: %#v", q.Question.QuestionFormat))
+	}
+}
 
-	squareSize := float64(16)
+// answerOffsets returns vertical offsets of answer rows and column headers from the top of the answer area.
+// Headers are above first row, between halves of the sheet and below the last row of full sheet.
+func answerOffsets(rowCount int) ([]float64, []float64) {
 	offsetYAdd := float64(30)
 	offsetYAddHeader := float64(20)
 
 	offsetY := float64(20)
 
-	for i, q := range sheet.Questions {
+	rows := make([]float64, 0, rowCount)
+	headers := make([]float64, 0, 3)
+	for i := 0; i < rowCount; i++ {
 		if i == 0 {
-			DrawHeader(pdf, posX, posY+offsetY, squareSize, sheet)
+			headers = append(headers, offsetY)
 			offsetY += offsetYAddHeader
 		}
 
-		questionString := strconv.Itoa(int(q.Order + 1)) // Offset so it does not start printing on 1
-		switch q.Question.QuestionFormat {
-		case enums.QuestionFormatOpen:
-			DrawAnswerRow(pdf, posX, posY+offsetY, squareSize, questionString, 11)
-		case enums.QuestionFormatTest:
-			DrawAnswerRow(pdf, posX, posY+offsetY, squareSize, questionString, len(q.Answers))
-		default:
-			panic(fmt.Sprintf("unexpected enums.QuestionFormatEnum: %#v", q.Question.QuestionFormat))
-		}
+		rows = append(rows, offsetY)
 
 		switch i {
 		case maxQuestionsPerSheet - 1:
 			offsetY += offsetYAddHeader + 5
-			DrawHeader(pdf, posX, posY+offsetY, squareSize, sheet)
+			headers = append(headers, offsetY)
 		case (maxQuestionsPerSheet / 2) - 1:
 			offsetY += offsetYAddHeader + 10
-			DrawHeader(pdf, posX, posY+offsetY, squareSize, sheet)
+			headers = append(headers, offsetY)
 			offsetY += offsetYAddHeader + 2
 		default:
 			offsetY += offsetYAdd
 		}
 	}
+
+	return rows, headers
 }
 
 func DrawHeader(pdf *fpdf.Fpdf, posX float64, posY float64, squareSize float64, sheet *SheetData) {
@@ -228,7 +262,6 @@ func DrawHeaderPercentage(pdf *fpdf.Fpdf, posX float64, posY float64, squareSize
 		pdf.Cell(10, 10, strconv.Itoa(i*10))
 	}
 
-	repairAnswersOffset := float64(45)
 	posX += 11*squareSize + repairAnswersOffset
 
 	for i := range 11 {
@@ -236,7 +269,6 @@ func DrawHeaderPercentage(pdf *fpdf.Fpdf, posX float64, posY float64, squareSize
 		pdf.Cell(10, 10, strconv.Itoa(i*10))
 	}
 
-	repairColumnOffset := float64(60)
 	posX += 11*squareSize + repairColumnOffset
 
 	pdf.SetXY(posX+10, posY)
@@ -250,7 +282,6 @@ func DrawHeaderTest(pdf *fpdf.Fpdf, posX float64, posY float64, squareSize float
 		pdf.Cell(10, 10, GetTestAnswerLabel(i))
 	}
 
-	repairAnswersOffset := float64(45)
 	posX += 11*squareSize + repairAnswersOffset
 
 	for i := 0; i < answerCount; i++ {
@@ -258,7 +289,6 @@ func DrawHeaderTest(pdf *fpdf.Fpdf, posX float64, posY float64, squareSize float
 		pdf.Cell(10, 10, GetTestAnswerLabel(i))
 	}
 
-	repairColumnOffset := float64(60)
 	posX += 11*squareSize + repairColumnOffset
 
 	pdf.SetXY(posX+10, posY)
@@ -273,7 +303,6 @@ func DrawAnswerRow(pdf *fpdf.Fpdf, posX float64, posY float64, squareSize float6
 		pdf.Rect(posX+i*squareSize, posY, squareSize, squareSize, "")
 	}
 
-	repairAnswersOffset := float64(45)
 	posX += 11*squareSize + repairAnswersOffset
 
 	pdf.SetXY(posX-8, posY+3)
@@ -283,7 +312,6 @@ func DrawAnswerRow(pdf *fpdf.Fpdf, posX float64, posY float64, squareSize float6
 		pdf.Rect(posX+i*squareSize, posY, squareSize, squareSize, "")
 	}
 
-	repairColumnOffset := float64(60)
 	posX += 11*squareSize + repairColumnOffset
 
 	pdf.Rect(posX, posY, 2*squareSize, squareSize, "")
@@ -319,11 +347,7 @@ func DrawParticipantQR(pdf *fpdf.Fpdf, toprightX float64, toprightY float64, siz
 	topleftXQR := toprightX - size
 
 	if testInstance != nil {
-		participantIdentifier := fmt.Sprintf("%s;%d",
-			testInstance.Participant.Username,
-			testInstance.ID,
-		)
-		qr, err := qrcode.Encode(participantIdentifier, qrcode.High, 512)
+		qr, err := qrcode.Encode(ParticipantIdentifier(testInstance), qrcode.High, 512)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -348,6 +372,14 @@ func DrawParticipantQR(pdf *fpdf.Fpdf, toprightX float64, toprightY float64, siz
 	pdf.Rect(topleftX, toprightY, width, size, "")
 }
 
+// ParticipantIdentifier returns content of participant QR code
+func ParticipantIdentifier(testInstance *models.TestInstance) string {
+	return fmt.Sprintf("%s;%d",
+		testInstance.Participant.Username,
+		testInstance.ID,
+	)
+}
+
 func GetTestAnswerLabel(n int) string {
 	label := ""
 	for n >= 0 {
@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"elogika.vsb.cz/backend/initializers"
+)
+
+// Length of identifier signature in hex characters
+const identifierSignatureLength = 16
+
+// TestIdentifierV2 returns content of test identifier QR code. Instance is zero for sheets printed without participant,
+// signature makes sure that misread or hand made codes are not attached to wrong test or instance.
+func TestIdentifierV2(courseID uint, testID uint, instanceID uint, sheetType SheetTypeEnum, sheetOrder uint) string {
+	payload := fmt.Sprintf("V2;%d;%d;%d;%s%d",
+		courseID,
+		testID,
+		instanceID,
+		sheetType,
+		sheetOrder,
+	)
+	return payload + ";" + SignIdentifier(payload)
+}
+
+// SignIdentifier returns truncated HMAC of identifier payload keyed by server secret
+func SignIdentifier(payload string) string {
+	mac := hmac.New(sha256.New, initializers.GlobalAppConfig.SHEET_SECRET)
+	mac.Write([]byte(payload))
+	return strings.ToUpper(hex.EncodeToString(mac.Sum(nil))[:identifierSignatureLength])
+}
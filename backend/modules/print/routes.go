@@ -8,6 +8,11 @@ import (
 
 func RegisterRoutes(rg *gin.RouterGroup) {
 	rg.POST("courses/:courseId/print/tests", wrappers.WithUserDataRole(handlers.PrintTest))
+	rg.GET("courses/:courseId/print/jobs", wrappers.WithUserDataRole(handlers.PrintJobList))
+	rg.GET("courses/:courseId/print/jobs/:jobId", wrappers.WithUserDataRole(handlers.PrintJobGet))
+	rg.GET("courses/:courseId/print/jobs/:jobId/download", wrappers.WithUserDataRole(handlers.PrintJobDownload))
+	rg.POST("courses/:courseId/print/booklets", wrappers.WithUserDataRole(handlers.PrintBooklets))
+	rg.POST("courses/:courseId/print/attendance", wrappers.WithUserDataRole(handlers.PrintAttendance))
 	rg.POST("courses/:courseId/print/questions", wrappers.WithUserDataRole(handlers.PrintQuestion))
 	rg.POST("courses/:courseId/print/questions/:questionId", wrappers.WithUserDataRole(handlers.PrintQuestion))
 }
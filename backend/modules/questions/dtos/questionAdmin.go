@@ -21,6 +21,7 @@ type QuestionAdminDTO struct {
 	ChapterID          uint                     `json:"chapterId"`
 	CategoryID         *uint                    `json:"categoryId" ts_type:"number | null"`
 	Steps              []uint                   `json:"steps"`
+	RubricID           *uint                    `json:"rubricId" ts_type:"number | null"`
 
 	Answers   []QuestionAnswerAdminDTO `json:"answers"`
 	CheckedBy []QuestionCheckedByDTO   `json:"checkedBy"`
@@ -43,6 +44,7 @@ func (m QuestionAdminDTO) From(d *models.Question) QuestionAdminDTO {
 		CreatedBy:          QuestionCreatedByDTO{}.From(d.CreatedBy),
 		Active:             d.Active,
 		Steps:              make([]uint, len(d.CourseLink.Steps)),
+		RubricID:           d.RubricID,
 
 		CheckedBy: make([]QuestionCheckedByDTO, len(d.CheckedBy)),
 		Answers:   make([]QuestionAnswerAdminDTO, len(d.Answers)),
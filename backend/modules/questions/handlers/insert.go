@@ -27,6 +27,7 @@ type QuestionInsertRequest struct {
 	QuestionType       enums.QuestionTypeEnum        `json:"questionType" binding:"required"`                        // Type of the question
 	QuestionFormat     enums.QuestionFormatEnum      `json:"questionFormat" binding:"required"`                      // Format of the question
 	IncludeAnswerSpace bool                          `json:"includeAnswerSpace"`                                     // Defines if a box of empty space should be included after open question
+	RubricID           *uint                         `json:"rubricId" validate:"optional" ts_type:"number | null"`   // ID of the rubric used when grading open answers
 	Active             bool                          `json:"active"`                                                 // Is the question in active pool for selection
 	Answers            []dtos.QuestionAnswerAdminDTO `json:"answers"`                                                // All answers for this question
 	ChapterID          uint                          `json:"chapterId" binding:"required"`                           // ID of the chapter
@@ -81,6 +82,10 @@ func QuestionInsert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole en
 		}
 	}
 
+	if err := repositories.NewRubricRepository().ValidateRubricID(initializers.DB, params.CourseID, reqData.RubricID); err != nil {
+		return err
+	}
+
 	//Create question group
 	questionGroup := models.QuestionGroup{
 		ID:           0,
@@ -116,6 +121,7 @@ func QuestionInsert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole en
 		Active:             reqData.Active,
 		AnswerCount:        uint(len(reqData.Answers)),
 		QuestionGroupID:    questionGroup.ID,
+		RubricID:           reqData.RubricID,
 	}
 
 	err = tiptap.FindAndSaveRelations(transaction, userData.ID, reqData.Content, &question, "ContentFiles")
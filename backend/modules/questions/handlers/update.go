@@ -24,6 +24,7 @@ type QuestionUpdateRequest struct {
 	QuestionType       enums.QuestionTypeEnum        `json:"questionType" binding:"required"`                        // Type of the question
 	QuestionFormat     enums.QuestionFormatEnum      `json:"questionFormat" binding:"required"`                      // Format of the question
 	IncludeAnswerSpace bool                          `json:"includeAnswerSpace"`                                     // Defines if a box of empty space should be included after open question
+	RubricID           *uint                         `json:"rubricId" validate:"optional" ts_type:"number | null"`   // ID of the rubric used when grading open answers
 	Active             bool                          `json:"active"`                                                 // Is the question in active pool for selection
 	Answers            []dtos.QuestionAnswerAdminDTO `json:"answers"`                                                // All answers for this question
 	ChapterID          uint                          `json:"chapterId" binding:"required"`                           // ID of the chapter
@@ -91,6 +92,9 @@ func asNewVersion(c *gin.Context, userData authdtos.LoggedUserDTO, reqData *Ques
 	if err != nil {
 		return err
 	}
+	if err := repositories.NewRubricRepository().ValidateRubricID(initializers.DB, params.CourseID, reqData.RubricID); err != nil {
+		return err
+	}
 
 	transaction := initializers.DB.Begin()
 
@@ -115,6 +119,7 @@ func asNewVersion(c *gin.Context, userData authdtos.LoggedUserDTO, reqData *Ques
 		Active:             reqData.Active,
 		QuestionGroupID:    question.QuestionGroupID,
 		AnswerCount:        uint(len(reqData.Answers)),
+		RubricID:           reqData.RubricID,
 	}
 
 	err = tiptap.FindAndSaveRelations(transaction, userData.ID, reqData.Content, &newQuestion, "ContentFiles")
@@ -210,6 +215,9 @@ func updateExisting(c *gin.Context, userData authdtos.LoggedUserDTO, reqData *Qu
 	if err != nil {
 		return err
 	}
+	if err := repositories.NewRubricRepository().ValidateRubricID(initializers.DB, params.CourseID, reqData.RubricID); err != nil {
+		return err
+	}
 
 	transaction := initializers.DB.Begin()
 
@@ -234,6 +242,7 @@ func updateExisting(c *gin.Context, userData authdtos.LoggedUserDTO, reqData *Qu
 	question.IncludeAnswerSpace = reqData.IncludeAnswerSpace
 	question.Active = reqData.Active
 	question.AnswerCount = uint(len(reqData.Answers))
+	question.RubricID = reqData.RubricID
 
 	if err := transaction.Save(&question).Error; err != nil {
 		transaction.Rollback()
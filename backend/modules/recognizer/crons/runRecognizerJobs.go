@@ -0,0 +1,26 @@
+package crons
+
+import (
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/recognizer/handlers"
+	"elogika.vsb.cz/backend/utils"
+)
+
+// RunRecognizerBatches picks up uploads which were not split, e.g. because of server restart
+func RunRecognizerBatches() {
+	utils.FailStaleJobs(initializers.DB, &models.RecognizerBatch{}, "Splitting of upload was interrupted")
+
+	for _, jobID := range utils.PendingJobIDs(initializers.DB, &models.RecognizerBatch{}, "created_at ASC") {
+		handlers.RunRecognizerBatch(jobID)
+	}
+}
+
+// RunRecognizerFiles recognizes queued pages left behind by interrupted uploads
+func RunRecognizerFiles() {
+	utils.FailStaleJobs(initializers.DB, &models.RecognizerFile{}, "Recognition of page was interrupted")
+
+	for _, fileID := range utils.PendingJobIDs(initializers.DB, &models.RecognizerFile{}, "batch_id ASC, page ASC") {
+		handlers.RunRecognizerFile(fileID)
+	}
+}
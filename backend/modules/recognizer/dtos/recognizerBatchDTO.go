@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type RecognizerBatchDTO struct {
+	ID         uint                `json:"id"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	FileName   string              `json:"fileName"`
+	Status     enums.JobStatusEnum `json:"status"`
+	Error      string              `json:"error"`
+	FinishedAt *time.Time          `json:"finishedAt"`
+
+	Pages        []models.RecognizerBatchPage `json:"pages"`
+	Unidentified []models.RecognizerBatchPage `json:"unidentified"` // Pages not attached to any instance
+	Duplicates   []models.RecognizerBatchPage `json:"duplicates"`   // Pages repeating already attached sheet
+	Files        []RecognizerFileDTO          `json:"files"`        // Attached pages with state of their recognition
+}
+
+func (m RecognizerBatchDTO) From(d *models.RecognizerBatch) RecognizerBatchDTO {
+	dto := RecognizerBatchDTO{
+		ID:           d.ID,
+		CreatedAt:    d.CreatedAt,
+		Status:       d.Status,
+		Error:        d.Error,
+		FinishedAt:   d.FinishedAt,
+		Pages:        d.Report,
+		Unidentified: []models.RecognizerBatchPage{},
+		Duplicates:   []models.RecognizerBatchPage{},
+		Files:        make([]RecognizerFileDTO, len(d.Files)),
+	}
+
+	if d.File != nil {
+		dto.FileName = d.File.OriginalName
+	}
+	if dto.Pages == nil {
+		dto.Pages = []models.RecognizerBatchPage{}
+	}
+
+	for _, page := range d.Report {
+		if page.DuplicateOf != nil {
+			dto.Duplicates = append(dto.Duplicates, page)
+		} else if page.RecognizerFileID == nil {
+			dto.Unidentified = append(dto.Unidentified, page)
+		}
+	}
+
+	for f_i, f := range d.Files {
+		dto.Files[f_i] = RecognizerFileDTO{}.From(f)
+	}
+
+	return dto
+}
@@ -0,0 +1,45 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type RecognizerBatchListItemDTO struct {
+	ID           uint                `json:"id"`
+	CreatedAt    time.Time           `json:"createdAt"`
+	FileName     string              `json:"fileName"`
+	Status       enums.JobStatusEnum `json:"status"`
+	Error        string              `json:"error"`
+	FinishedAt   *time.Time          `json:"finishedAt"`
+	Pages        uint                `json:"pages"`
+	Unidentified uint                `json:"unidentified"`
+	Duplicates   uint                `json:"duplicates"`
+}
+
+func (m RecognizerBatchListItemDTO) From(d *models.RecognizerBatch) RecognizerBatchListItemDTO {
+	dto := RecognizerBatchListItemDTO{
+		ID:         d.ID,
+		CreatedAt:  d.CreatedAt,
+		Status:     d.Status,
+		Error:      d.Error,
+		FinishedAt: d.FinishedAt,
+		Pages:      uint(len(d.Report)),
+	}
+
+	if d.File != nil {
+		dto.FileName = d.File.OriginalName
+	}
+
+	for _, page := range d.Report {
+		if page.DuplicateOf != nil {
+			dto.Duplicates++
+		} else if page.RecognizerFileID == nil {
+			dto.Unidentified++
+		}
+	}
+
+	return dto
+}
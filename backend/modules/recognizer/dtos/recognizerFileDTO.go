@@ -0,0 +1,52 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type RecognizerFileDTO struct {
+	ID             uint                        `json:"id"`
+	FileID         uint                        `json:"fileId"`
+	StoredName     string                      `json:"storedName"`
+	TestInstanceID uint                        `json:"testInstanceId"`
+	Variant        string                      `json:"variant"`
+	Page           uint                        `json:"page"`
+	Status         enums.JobStatusEnum         `json:"status"`
+	Error          string                      `json:"error"`
+	Confidence     float64                     `json:"confidence"`
+	NeedsReview    bool                        `json:"needsReview"`
+	ReviewedAt     *time.Time                  `json:"reviewedAt"`
+	StudentName    string                      `json:"studentName"`
+	Questions      []models.RecognizedQuestion `json:"questions"`
+}
+
+func (m RecognizerFileDTO) From(d *models.RecognizerFile) RecognizerFileDTO {
+	dto := RecognizerFileDTO{
+		ID:             d.ID,
+		FileID:         d.FileID,
+		TestInstanceID: d.TestInstanceID,
+		Variant:        d.UniqueIdent,
+		Page:           d.Page,
+		Status:         d.Status,
+		Error:          d.Error,
+		Confidence:     d.Confidence,
+		NeedsReview:    d.NeedsReview,
+		ReviewedAt:     d.ReviewedAt,
+		Questions:      d.Questions,
+	}
+
+	if d.TestInstance != nil && d.TestInstance.Participant != nil {
+		dto.StudentName = d.TestInstance.Participant.FullName()
+	}
+	if d.File != nil {
+		dto.StoredName = d.File.StoredName
+	}
+	if dto.Questions == nil {
+		dto.Questions = []models.RecognizedQuestion{}
+	}
+
+	return dto
+}
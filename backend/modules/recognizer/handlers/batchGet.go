@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/recognizer/dtos"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RecognizerBatchGetResponse struct {
+	Data dtos.RecognizerBatchDTO `json:"data"`
+}
+
+// @Summary Gets summary of bulk upload with unidentified and duplicate pages and state of recognition
+// @Tags Recognizer
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param batchId path int true "ID of the upload"
+// @Success 200 {object} RecognizerBatchGetResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Upload not found"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/recognizer/batches/{batchId} [get]
+func RecognizerBatchGet(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			BatchID  uint `uri:"batchId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	query, err := recognizerBatchQuery(params.CourseID, userData, userRole)
+	if err != nil {
+		return err
+	}
+
+	var job models.RecognizerBatch
+	if err := query.
+		Preload("File").
+		Preload("Files", func(db *gorm.DB) *gorm.DB {
+			return db.Preload("File").Order("page ASC")
+		}).
+		First(&job, params.BatchID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Failed to load recognizer batch",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, RecognizerBatchGetResponse{
+		Data: dtos.RecognizerBatchDTO{}.From(&job),
+	})
+
+	return nil
+}
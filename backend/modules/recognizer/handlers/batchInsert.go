@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type RecognizerBatchInsertResponse struct {
+	BatchID uint `json:"batchId"`
+}
+
+// @Summary Uploads scanned answer sheets in bulk. Pages are identified, attached to test instances and recognized in background.
+// @Tags Recognizer
+// @Security ApiKeyAuth
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param file formData file true "Scanned sheets as multi-page PDF or zip of images"
+// @Success 200 {object} RecognizerBatchInsertResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/recognizer/batches [post]
+func RecognizerBatchInsert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole == enums.CourseUserRoleStudent {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	file, header, fileErr := c.Request.FormFile("file")
+	if fileErr != nil {
+		return &common.ErrorResponse{
+			Code:    400,
+			Message: "Missing scanned file",
+			Details: fileErr.Error(),
+		}
+	}
+	defer file.Close()
+
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	if ext == "" || len(ext) > 10 {
+		return &common.ErrorResponse{
+			Code:    400,
+			Message: "Invalid file extension",
+		}
+	}
+
+	data, fileErr := io.ReadAll(file)
+	if fileErr != nil {
+		return &common.ErrorResponse{
+			Code:    400,
+			Message: "Failed to read scanned file",
+			Details: fileErr.Error(),
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	uploaded, err := storeScannedFile(transaction, userData.ID, header.Filename, data)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	job, err := CreateRecognizerBatch(transaction, params.CourseID, uploaded.ID, userData.ID, userRole)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	go RunRecognizerBatch(job.ID)
+
+	c.JSON(200, RecognizerBatchInsertResponse{
+		BatchID: job.ID,
+	})
+
+	return nil
+}
@@ -0,0 +1,455 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/recognizer/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"elogika.vsb.cz/backend/utils/omr"
+	"gorm.io/gorm"
+)
+
+// batchTest caches test lookups of a batch, uploads usually contain many sheets of the same test
+type batchTest struct {
+	identifier *helpers.SheetIdentifier
+	test       *models.Test
+	err        string
+}
+
+// CreateRecognizerBatch stores job splitting uploaded file to pages, run it by RunRecognizerBatch after commit
+func CreateRecognizerBatch(dbRef *gorm.DB, courseID uint, fileID uint, userID uint, userRole enums.CourseUserRoleEnum) (*models.RecognizerBatch, *common.ErrorResponse) {
+	job := &models.RecognizerBatch{
+		CreatedByID: userID,
+		CourseID:    courseID,
+		UserRole:    userRole,
+		FileID:      fileID,
+		Status:      enums.JobStatusPending,
+	}
+
+	if err := dbRef.Create(job).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to create recognizer batch",
+			Details: err.Error(),
+		}
+	}
+
+	return job, nil
+}
+
+// RunRecognizerBatch splits upload to pages, identifies every page by its sheet QR codes and attaches it
+// to the test instance. Attached pages are queued for recognition, which starts once all pages are identified.
+func RunRecognizerBatch(jobID uint) {
+	if !utils.ClaimJob(initializers.DB, &models.RecognizerBatch{}, jobID) {
+		return
+	}
+
+	var job models.RecognizerBatch
+	if err := initializers.DB.Preload("File").First(&job, jobID).Error; err != nil {
+		log.Println("Failed to load recognizer batch", jobID, err)
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			finishRecognizerBatch(&job, enums.JobStatusFailed, fmt.Sprint(r))
+		}
+	}()
+
+	data, err := os.ReadFile(filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, job.File.StoredName))
+	if err != nil {
+		finishRecognizerBatch(&job, enums.JobStatusFailed, err.Error())
+		return
+	}
+
+	pages, err := omr.SplitPages(data, job.File.OriginalName)
+	if err != nil {
+		finishRecognizerBatch(&job, enums.JobStatusFailed, err.Error())
+		return
+	}
+
+	tests := make(map[string]*batchTest)
+	attached := make(map[string]uint)
+	fileIDs := make([]uint, 0, len(pages))
+	for i, page := range pages {
+		item := identifyBatchPage(&job, uint(i+1), page, tests, attached)
+		if item.RecognizerFileID != nil {
+			fileIDs = append(fileIDs, *item.RecognizerFileID)
+		}
+		job.Report = append(job.Report, item)
+	}
+
+	finishRecognizerBatch(&job, enums.JobStatusFinished, "")
+
+	for _, fileID := range fileIDs {
+		RunRecognizerFile(fileID)
+	}
+}
+
+// identifyBatchPage finds instance the page belongs to and attaches it, pages are identified by test
+// identifier and participant login, so the same sheet of the same instance is attached only once
+func identifyBatchPage(
+	job *models.RecognizerBatch,
+	pageNumber uint,
+	page omr.PageFile,
+	tests map[string]*batchTest,
+	attached map[string]uint,
+) models.RecognizerBatchPage {
+	item := models.RecognizerBatchPage{
+		Page: pageNumber,
+	}
+
+	images, err := omr.LoadPages(page.Data, "page"+page.Ext)
+	if err != nil || len(images) == 0 || images[0] == nil {
+		item.Error = "Page does not contain scanned image"
+		return item
+	}
+
+	sheet, errResp := helpers.LocateSheet(images[0])
+	if errResp != nil {
+		item.Error = errResp.Message
+		return item
+	}
+	item.Variant = sheet.Variant
+	item.Login = sheet.Login
+
+	cached, ok := tests[sheet.Variant]
+	if !ok {
+		cached = loadBatchTest(job, sheet.Variant)
+		tests[sheet.Variant] = cached
+	}
+	if cached.err != "" {
+		item.Error = cached.err
+		return item
+	}
+
+	if sheet.Login == "" {
+		item.Error = "Participant QR code not found"
+		return item
+	}
+	loginData, errResp := helpers.ParseV1Login(sheet.Login)
+	if errResp != nil {
+		item.Error = errResp.Message
+		return item
+	}
+
+	instanceID, errResp := ResolveSheetInstance(initializers.DB, cached.identifier, loginData)
+	if errResp != nil {
+		item.Error = errResp.Message
+		return item
+	}
+	if instanceID == 0 {
+		item.Error = "Test instance not found"
+		return item
+	}
+	item.TestInstanceID = &instanceID
+
+	var instance models.TestInstance
+	if err := initializers.DB.InnerJoins("Participant").First(&instance, instanceID).Error; err == nil {
+		item.StudentName = instance.Participant.FullName()
+	}
+
+	key := fmt.Sprintf("%d;%s", instanceID, sheet.Variant)
+	if duplicateOf, ok := attached[key]; ok {
+		item.DuplicateOf = &duplicateOf
+		item.Error = "Duplicate page"
+		return item
+	}
+
+	recoFile, errResp := attachBatchPage(job, pageNumber, page, instanceID, sheet.Variant)
+	if errResp != nil {
+		item.Error = errResp.Message
+		return item
+	}
+	attached[key] = pageNumber
+	item.RecognizerFileID = &recoFile.ID
+
+	return item
+}
+
+// loadBatchTest checks that sheet belongs to editable test of the batch course
+func loadBatchTest(job *models.RecognizerBatch, variant string) *batchTest {
+	identifier, errResp := helpers.ParseIdentifier(variant)
+	if errResp != nil {
+		return &batchTest{err: errResp.Message}
+	}
+	if identifier.Type != helpers.SheetTypeStudent && identifier.Type != helpers.SheetTypeTeacher {
+		return &batchTest{err: "Invalid type or sheet order"}
+	}
+	if identifier.CourseID != job.CourseID {
+		return &batchTest{err: "Sheet belongs to another course"}
+	}
+
+	var test models.Test
+	if err := initializers.DB.
+		Where("course_id = ?", job.CourseID).
+		First(&test, identifier.TestID).Error; err != nil {
+		return &batchTest{err: "Test not found"}
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, errResp := courseItemService.GetCourseItemByID(initializers.DB, job.CourseID, test.CourseItemID, job.CreatedByID, job.UserRole, nil, false, nil)
+	if errResp != nil {
+		return &batchTest{err: errResp.Message}
+	}
+	if !courseItem.Editable {
+		return &batchTest{err: "Not enough permissions"}
+	}
+
+	return &batchTest{
+		identifier: identifier,
+		test:       &test,
+	}
+}
+
+// attachBatchPage stores the page and queues it for recognition, page replaces older scan of the same sheet
+func attachBatchPage(job *models.RecognizerBatch, pageNumber uint, page omr.PageFile, instanceID uint, variant string) (*models.RecognizerFile, *common.ErrorResponse) {
+	transaction := initializers.DB.Begin()
+
+	file, err := storeScannedFile(transaction, job.CreatedByID, fmt.Sprintf("%s-%d%s", strings.TrimSuffix(job.File.OriginalName, filepath.Ext(job.File.OriginalName)), pageNumber, page.Ext), page.Data)
+	if err != nil {
+		transaction.Rollback()
+		return nil, err
+	}
+
+	if err := transaction.
+		Model(&models.RecognizerFile{}).
+		Where("test_instance_id = ?", instanceID).
+		Where("unique_ident = ?", variant).
+		Delete(&models.RecognizerFile{}).Error; err != nil {
+		transaction.Rollback()
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to remove old recognizer file",
+			Details: err.Error(),
+		}
+	}
+
+	recoFile := &models.RecognizerFile{
+		FileID:         file.ID,
+		TestInstanceID: instanceID,
+		UniqueIdent:    variant,
+		BatchID:        &job.ID,
+		Page:           pageNumber,
+		Status:         enums.JobStatusPending,
+	}
+	if err := transaction.Create(recoFile).Error; err != nil {
+		transaction.Rollback()
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save recognizer file",
+			Details: err.Error(),
+		}
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	return recoFile, nil
+}
+
+// storeScannedFile writes data to uploads and creates its file record
+func storeScannedFile(dbRef *gorm.DB, userID uint, originalName string, data []byte) (*models.File, *common.ErrorResponse) {
+	ext := strings.ToLower(filepath.Ext(originalName))
+
+	newFileName, err := utils.GenerateFileName(dbRef, ext)
+	if err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: err.Error(),
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, newFileName), data, 0644); err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save file",
+			Details: err.Error(),
+		}
+	}
+
+	file := &models.File{
+		UserID:       userID,
+		OriginalName: originalName,
+		StoredName:   newFileName,
+		MIMEType:     mime.TypeByExtension(ext),
+		SizeBytes:    int64(len(data)),
+		UploadedAt:   time.Now(),
+	}
+	if err := dbRef.Create(file).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "DB save failed",
+			Details: err.Error(),
+		}
+	}
+
+	return file, nil
+}
+
+// RunRecognizerFile reads marks of queued page and saves them to its test instance, pages with ambiguous
+// marks are left for review instead.
+func RunRecognizerFile(fileID uint) {
+	if !utils.ClaimJob(initializers.DB, &models.RecognizerFile{}, fileID) {
+		return
+	}
+
+	var recoFile models.RecognizerFile
+	if err := initializers.DB.
+		InnerJoins("File").
+		InnerJoins("Batch").
+		First(&recoFile, fileID).Error; err != nil {
+		log.Println("Failed to load recognizer file", fileID, err)
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			finishRecognizerFile(&recoFile, enums.JobStatusFailed, fmt.Sprint(r))
+		}
+	}()
+
+	if err := recognizeFile(&recoFile); err != nil {
+		finishRecognizerFile(&recoFile, enums.JobStatusFailed, err.Message)
+		return
+	}
+
+	finishRecognizerFile(&recoFile, enums.JobStatusFinished, "")
+}
+
+func recognizeFile(recoFile *models.RecognizerFile) *common.ErrorResponse {
+	data, err := os.ReadFile(filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, recoFile.File.StoredName))
+	if err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to read scanned page",
+			Details: err.Error(),
+		}
+	}
+
+	images, err := omr.LoadPages(data, recoFile.File.StoredName)
+	if err != nil || len(images) == 0 || images[0] == nil {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Page does not contain scanned image",
+		}
+	}
+
+	sheet, errResp := helpers.LocateSheet(images[0])
+	if errResp != nil {
+		return errResp
+	}
+	if sheet.Variant != recoFile.UniqueIdent {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Sheet does not match identified test",
+		}
+	}
+
+	identifier, errResp := helpers.ParseIdentifier(sheet.Variant)
+	if errResp != nil {
+		return errResp
+	}
+
+	test, errResp := loadSheetTest(initializers.DB, identifier)
+	if errResp != nil {
+		return errResp
+	}
+	if test.ID == 0 || len(test.Questions) == 0 {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Sheet does not match any questions of the test",
+		}
+	}
+
+	var courseItem models.CourseItem
+	if err := initializers.DB.First(&courseItem, test.CourseItemID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to find associated course item",
+			Details: err.Error(),
+		}
+	}
+
+	questions, confidence := readSheetAnswers(sheet, test)
+	recoFile.Confidence = confidence
+	recoFile.Questions = make([]models.RecognizedQuestion, len(questions))
+	for i, q := range questions {
+		recoFile.Questions[i] = models.RecognizedQuestion{
+			Order:      q.Order,
+			Answers:    q.Answers,
+			Confidence: q.Confidence,
+			Ambiguous:  q.Ambiguous,
+		}
+		recoFile.NeedsReview = recoFile.NeedsReview || q.Ambiguous
+	}
+
+	// Ambiguous sheets wait in review queue, nothing is saved until tutor confirms them
+	if recoFile.NeedsReview {
+		return nil
+	}
+
+	transaction := initializers.DB.Begin()
+
+	userData := &authdtos.LoggedUserDTO{ID: recoFile.Batch.CreatedByID}
+	if _, err := SaveRecognizedAnswers(transaction, userData, identifier, &courseItem, recoFile.TestInstanceID, questions); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	return nil
+}
+
+func finishRecognizerBatch(job *models.RecognizerBatch, status enums.JobStatusEnum, message string) {
+	now := time.Now()
+	job.Status = status
+	job.Error = message
+	job.FinishedAt = &now
+
+	if err := initializers.DB.
+		Model(job).
+		Select("status", "error", "finished_at", "report").
+		Updates(job).Error; err != nil {
+		log.Println("Failed to finish recognizer batch", job.ID, err)
+	}
+}
+
+func finishRecognizerFile(recoFile *models.RecognizerFile, status enums.JobStatusEnum, message string) {
+	recoFile.Status = status
+	recoFile.Error = message
+
+	if err := initializers.DB.
+		Model(recoFile).
+		Select("status", "error", "confidence", "needs_review", "questions").
+		Updates(recoFile).Error; err != nil {
+		log.Println("Failed to finish recognizer file", recoFile.ID, err)
+	}
+}
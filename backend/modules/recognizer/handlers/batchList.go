@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/recognizer/dtos"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RecognizerBatchListResponse struct {
+	Items []dtos.RecognizerBatchListItemDTO `json:"items"`
+}
+
+// @Summary Lists bulk uploads of scanned sheets in course, tutors see only their own uploads
+// @Tags Recognizer
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Success 200 {object} RecognizerBatchListResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/recognizer/batches [get]
+func RecognizerBatchList(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	query, err := recognizerBatchQuery(params.CourseID, userData, userRole)
+	if err != nil {
+		return err
+	}
+
+	var jobs []*models.RecognizerBatch
+	if err := query.
+		Preload("File").
+		Order("created_at DESC").
+		Find(&jobs).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load recognizer batches",
+			Details: err.Error(),
+		}
+	}
+
+	items := []dtos.RecognizerBatchListItemDTO{}
+	for _, job := range jobs {
+		items = append(items, dtos.RecognizerBatchListItemDTO{}.From(job))
+	}
+
+	c.JSON(200, RecognizerBatchListResponse{
+		Items: items,
+	})
+
+	return nil
+}
+
+// recognizerBatchQuery limits batches to the course, tutors can access only batches they uploaded
+func recognizerBatchQuery(courseID uint, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) (*gorm.DB, *common.ErrorResponse) {
+	query := initializers.DB.Where("recognizer_batches.course_id = ?", courseID)
+
+	switch userRole {
+	case enums.CourseUserRoleAdmin, enums.CourseUserRoleGarant:
+		return query, nil
+	case enums.CourseUserRoleTutor:
+		return query.Where("recognizer_batches.created_by_id = ?", userData.ID), nil
+	default:
+		return nil, &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+}
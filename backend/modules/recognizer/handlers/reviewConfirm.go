@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/recognizer/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type RecognizerReviewConfirmRequest struct {
+	Questions []RecognizerTestSaveRequestQuestion `json:"questions" binding:"required"` // Confirmed or corrected answers of all questions on the page
+}
+
+type RecognizerReviewConfirmResponse struct {
+	Success bool `json:"success"`
+}
+
+// @Summary Confirms or corrects answers of recognized page, answers are saved and instance is evaluated
+// @Tags Recognizer
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param fileId path int true "ID of the recognized page"
+// @Param body body RecognizerReviewConfirmRequest true "Confirmed answers"
+// @Success 200 {object} RecognizerReviewConfirmResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Page not found"
+// @Failure 409 {object} common.ErrorResponse "Page was already reviewed"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/recognizer/review/{fileId} [post]
+func RecognizerReviewConfirm(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			FileID   uint `uri:"fileId" binding:"required"`
+		},
+		RecognizerReviewConfirmRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	recoFile, err := loadReviewFile(params.CourseID, params.FileID, userData, userRole)
+	if err != nil {
+		return err
+	}
+	if recoFile.ReviewedAt != nil {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Page was already reviewed",
+		}
+	}
+
+	// Confirmed answers have to match the recognized sheet
+	if len(reqData.Questions) != len(recoFile.Questions) {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Question count does not match",
+		}
+	}
+	for i, q := range reqData.Questions {
+		if q.Order != recoFile.Questions[i].Order || len(q.Answers) != len(recoFile.Questions[i].Answers) {
+			return &common.ErrorResponse{
+				Code:    422,
+				Message: "Validation failed",
+				Details: "Answer box count does not match",
+			}
+		}
+	}
+
+	identifier, err := helpers.ParseIdentifier(recoFile.UniqueIdent)
+	if err != nil {
+		return err
+	}
+
+	var test models.Test
+	if err := initializers.DB.
+		Where("course_id = ?", params.CourseID).
+		First(&test, identifier.TestID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Test not found",
+			Details: err.Error(),
+		}
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, test.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	// Page is claimed by conditional update, so concurrent reviews cannot both save answers
+	now := time.Now()
+	for i, q := range reqData.Questions {
+		recoFile.Questions[i].Answers = q.Answers
+	}
+	recoFile.ReviewedByID = &userData.ID
+	recoFile.ReviewedAt = &now
+	claim := transaction.
+		Model(recoFile).
+		Where("reviewed_at IS NULL").
+		Select("reviewed_by_id", "reviewed_at", "questions").
+		Updates(recoFile)
+	if claim.Error != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save review",
+			Details: claim.Error.Error(),
+		}
+	}
+	if claim.RowsAffected == 0 {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Page was already reviewed",
+		}
+	}
+
+	if _, err := SaveRecognizedAnswers(transaction, &userData, identifier, courseItem, recoFile.TestInstanceID, reqData.Questions); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, RecognizerReviewConfirmResponse{
+		Success: true,
+	})
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	printHelpers "elogika.vsb.cz/backend/modules/print/helpers"
+	"elogika.vsb.cz/backend/modules/recognizer/helpers"
+	"elogika.vsb.cz/backend/utils"
+	"elogika.vsb.cz/backend/utils/omr"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Resolution of crop of the whole answer area, pixels per pt
+	pageCropScale = 1.5
+	// Resolution of crop of single question row, pixels per pt
+	rowCropScale = 3
+	// Margin around cropped area in pt
+	cropMargin = 6
+)
+
+// @Summary Gets upright crop of recognized page, either whole answer area or single question row
+// @Tags Recognizer
+// @Security ApiKeyAuth
+// @Produce  png
+// @Param courseId path int true "ID of the corresponding course"
+// @Param fileId path int true "ID of the recognized page"
+// @Param question query int false "Order of question"
+// @Success 200 {file} file "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Page or question not found"
+// @Failure 422 {object} common.ErrorResponse "Page could not be read"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/recognizer/review/{fileId}/image [get]
+func RecognizerReviewImage(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			FileID   uint `uri:"fileId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Whole answer area is returned when question is not given
+	var questionOrder *uint
+	if question := c.Query("question"); question != "" {
+		order, parseErr := strconv.ParseUint(question, 10, 64)
+		if parseErr != nil {
+			return &common.ErrorResponse{
+				Code:    400,
+				Message: "Invalid question order",
+			}
+		}
+		value := uint(order)
+		questionOrder = &value
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	recoFile, err := loadReviewFile(params.CourseID, params.FileID, userData, userRole)
+	if err != nil {
+		return err
+	}
+
+	data, readErr := os.ReadFile(filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, recoFile.File.StoredName))
+	if readErr != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to read scanned page",
+			Details: readErr.Error(),
+		}
+	}
+
+	images, readErr := omr.LoadPages(data, recoFile.File.StoredName)
+	if readErr != nil || len(images) == 0 || images[0] == nil {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Page does not contain scanned image",
+		}
+	}
+
+	sheet, err := helpers.LocateSheet(images[0])
+	if err != nil {
+		return err
+	}
+
+	answerCounts := make([]int, len(recoFile.Questions))
+	for i, q := range recoFile.Questions {
+		answerCounts[i] = len(q.Answers)
+	}
+	layout := printHelpers.NewAnswerSheetLayout(answerCounts)
+
+	area, scale := layout.Frame, float64(pageCropScale)
+	if questionOrder != nil {
+		found := false
+		for i, q := range recoFile.Questions {
+			if q.Order == *questionOrder {
+				area, scale, found = layout.Rows[i].Bounds(), rowCropScale, true
+				break
+			}
+		}
+		if !found {
+			return &common.ErrorResponse{
+				Code:    404,
+				Message: "Question not found on the page",
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, sheet.Render(area, cropMargin, scale)); err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to encode image",
+			Details: err.Error(),
+		}
+	}
+
+	c.Data(200, "image/png", buf.Bytes())
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/recognizer/dtos"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RecognizerReviewListResponse struct {
+	Items []dtos.RecognizerFileDTO `json:"items"`
+}
+
+// @Summary Lists recognized pages with ambiguous marks waiting for confirmation
+// @Tags Recognizer
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Success 200 {object} RecognizerReviewListResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/recognizer/review [get]
+func RecognizerReviewList(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	query, err := recognizerReviewQuery(params.CourseID, userData, userRole)
+	if err != nil {
+		return err
+	}
+
+	var files []*models.RecognizerFile
+	if err := query.
+		Where("recognizer_files.reviewed_at IS NULL").
+		Preload("File").
+		Preload("TestInstance.Participant").
+		Order("recognizer_files.batch_id ASC, recognizer_files.page ASC").
+		Find(&files).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load review queue",
+			Details: err.Error(),
+		}
+	}
+
+	items := []dtos.RecognizerFileDTO{}
+	for _, file := range files {
+		items = append(items, dtos.RecognizerFileDTO{}.From(file))
+	}
+
+	c.JSON(200, RecognizerReviewListResponse{
+		Items: items,
+	})
+
+	return nil
+}
+
+// recognizerReviewQuery limits recognized pages needing review to the course, tutors can access only pages they uploaded
+func recognizerReviewQuery(courseID uint, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) (*gorm.DB, *common.ErrorResponse) {
+	query := initializers.DB.
+		InnerJoins("Batch").
+		Where("\"Batch\".\"course_id\" = ?", courseID).
+		Where("recognizer_files.needs_review = ?", true).
+		Where("recognizer_files.status = ?", enums.JobStatusFinished)
+
+	switch userRole {
+	case enums.CourseUserRoleAdmin, enums.CourseUserRoleGarant:
+		return query, nil
+	case enums.CourseUserRoleTutor:
+		return query.Where("\"Batch\".\"created_by_id\" = ?", userData.ID), nil
+	default:
+		return nil, &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+}
+
+// loadReviewFile loads recognized page needing review together with its scanned file
+func loadReviewFile(courseID uint, fileID uint, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) (*models.RecognizerFile, *common.ErrorResponse) {
+	query, err := recognizerReviewQuery(courseID, userData, userRole)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoFile models.RecognizerFile
+	if err := query.
+		InnerJoins("File").
+		First(&recoFile, fileID).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    404,
+			Message: "Recognized page not found",
+			Details: err.Error(),
+		}
+	}
+
+	return &recoFile, nil
+}
@@ -48,40 +48,16 @@ func RecognizerTestGet(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 	// TODO validate from here
 
 	identifierParts := strings.Split(params.Identifier, ";")
-	if strings.ToUpper(identifierParts[0]) == "V1" {
-		testIdentifierData, err := helpers.ParseV1Identifier(params.Identifier)
+	if version := strings.ToUpper(identifierParts[0]); version == "V1" || version == "V2" {
+		testIdentifierData, err := helpers.ParseIdentifier(params.Identifier)
 		if err != nil {
 			return err
 		}
 
 		// Get test data
-		var testData *models.Test
-		if err := initializers.DB.
-			Preload("Questions", func(db *gorm.DB) *gorm.DB {
-				tmp := db.
-					Joins("Question").
-					Preload("Answers").
-					Order("\"order\" ASC").
-					Limit(18).
-					Offset(18 * int(testIdentifierData.SheetOrder))
-
-				switch testIdentifierData.Type {
-				case helpers.SheetTypeStudent:
-					tmp = tmp.Where("Question.question_format = ?", enums.QuestionFormatTest)
-				case helpers.SheetTypeTeacher:
-					tmp = tmp.Where("Question.question_format = ?", enums.QuestionFormatOpen)
-				default:
-					panic(fmt.Sprintf("unexpected helpers.SheetTypeEnum: %#v", testIdentifierData.Type))
-				}
-
-				return tmp
-			}).
-			Find(&testData, testIdentifierData.TestID).Error; err != nil {
-			return &common.ErrorResponse{
-				Code:    403,
-				Message: "Not enough permission for this item",
-				Details: err.Error(),
-			}
+		testData, err := loadSheetTest(initializers.DB, testIdentifierData)
+		if err != nil {
+			return err
 		}
 
 		courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
@@ -97,3 +73,37 @@ func RecognizerTestGet(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 
 	return nil
 }
+
+// loadSheetTest loads test with questions printed on the identified answer sheet
+func loadSheetTest(dbRef *gorm.DB, testIdentifierData *helpers.SheetIdentifier) (*models.Test, *common.ErrorResponse) {
+	var testData *models.Test
+	if err := dbRef.
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			tmp := db.
+				Joins("Question").
+				Preload("Answers").
+				Order("\"order\" ASC").
+				Limit(18).
+				Offset(18 * int(testIdentifierData.SheetOrder))
+
+			switch testIdentifierData.Type {
+			case helpers.SheetTypeStudent:
+				tmp = tmp.Where("Question.question_format = ?", enums.QuestionFormatTest)
+			case helpers.SheetTypeTeacher:
+				tmp = tmp.Where("Question.question_format = ?", enums.QuestionFormatOpen)
+			default:
+				panic(fmt.Sprintf("unexpected helpers.SheetTypeEnum: %#v", testIdentifierData.Type))
+			}
+
+			return tmp
+		}).
+		Find(&testData, testIdentifierData.TestID).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permission for this item",
+			Details: err.Error(),
+		}
+	}
+
+	return testData, nil
+}
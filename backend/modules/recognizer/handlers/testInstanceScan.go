@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"image"
+	"io"
+	"math"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	printHelpers "elogika.vsb.cz/backend/modules/print/helpers"
+	"elogika.vsb.cz/backend/modules/recognizer/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils/omr"
+	"github.com/gin-gonic/gin"
+)
+
+type RecognizerScanPage struct {
+	Page       int                        `json:"page"`
+	Data       *RecognizerTestSaveRequest `json:"data"`       // Recognized sheet in format accepted by recognizer/test
+	Confidence float64                    `json:"confidence"` // Lowest confidence of all marks on the page
+	Error      string                     `json:"error"`
+}
+
+type RecognizerScanResponse struct {
+	Pages []RecognizerScanPage `json:"pages"`
+}
+
+// scannedTest caches sheet test lookups, scanned files usually contain many sheets of the same test
+type scannedTest struct {
+	test *models.Test
+	err  *common.ErrorResponse
+}
+
+// @Summary Recognizes scanned answer sheets. Nothing is saved, the result is meant to be reviewed and sent to recognizer/test.
+// @Tags Recognizer
+// @Security ApiKeyAuth
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "Scanned sheets as PDF or image"
+// @Success 200 {object} RecognizerScanResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 422 {object} common.ErrorResponse "File could not be read"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/recognizer/scan [POST]
+func RecognizerScan(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	if userRole == enums.CourseUserRoleStudent {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		return &common.ErrorResponse{
+			Code:    400,
+			Message: "Missing scanned file",
+			Details: err.Error(),
+		}
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return &common.ErrorResponse{
+			Code:    400,
+			Message: "Failed to read scanned file",
+			Details: err.Error(),
+		}
+	}
+
+	pages, err := omr.LoadPages(data, header.Filename)
+	if err != nil {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Failed to read scanned file",
+			Details: err.Error(),
+		}
+	}
+
+	tests := make(map[string]scannedTest)
+	result := make([]RecognizerScanPage, len(pages))
+	for i, page := range pages {
+		recognized, confidence, err := recognizePage(page, header.Filename, tests, userData, userRole)
+		result[i] = RecognizerScanPage{
+			Page:       i + 1,
+			Data:       recognized,
+			Confidence: confidence,
+		}
+		if err != nil {
+			result[i].Error = err.Message
+		}
+	}
+
+	c.JSON(200, RecognizerScanResponse{
+		Pages: result,
+	})
+
+	return nil
+}
+
+// recognizePage reads single scanned page, data is returned together with error when only the participant is missing
+func recognizePage(
+	page image.Image,
+	fileName string,
+	tests map[string]scannedTest,
+	userData authdtos.LoggedUserDTO,
+	userRole enums.CourseUserRoleEnum,
+) (*RecognizerTestSaveRequest, float64, *common.ErrorResponse) {
+	if page == nil {
+		return nil, 0, &common.ErrorResponse{
+			Code:    422,
+			Message: "Page does not contain scanned image",
+		}
+	}
+
+	sheet, err := helpers.LocateSheet(page)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cached, ok := tests[sheet.Variant]
+	if !ok {
+		cached.test, cached.err = loadScannedTest(sheet.Variant, userData, userRole)
+		tests[sheet.Variant] = cached
+	}
+	if cached.err != nil {
+		return nil, 0, cached.err
+	}
+
+	questions, confidence := readSheetAnswers(sheet, cached.test)
+	data := &RecognizerTestSaveRequest{
+		FileName:  fileName,
+		Login:     sheet.Login,
+		Variant:   sheet.Variant,
+		Questions: questions,
+	}
+
+	if sheet.Login == "" {
+		return data, confidence, &common.ErrorResponse{
+			Code:    422,
+			Message: "Participant QR code not found, login has to be filled manually",
+		}
+	}
+
+	return data, confidence, nil
+}
+
+// readSheetAnswers reads marks of all questions printed on the sheet, confidence is the lowest of all marks
+func readSheetAnswers(sheet *helpers.ScannedSheet, test *models.Test) ([]RecognizerTestSaveRequestQuestion, float64) {
+	answerCounts := make([]int, len(test.Questions))
+	for i, q := range test.Questions {
+		answerCounts[i] = printHelpers.AnswerBoxCount(q)
+	}
+
+	questions := make([]RecognizerTestSaveRequestQuestion, len(answerCounts))
+	confidence := float64(1)
+	for i, recognized := range sheet.ReadAnswers(answerCounts) {
+		questions[i] = RecognizerTestSaveRequestQuestion{
+			Order:      test.Questions[i].Order,
+			Answers:    recognized.Answers,
+			Confidence: recognized.Confidence,
+			Ambiguous:  recognized.IsAmbiguous(test.Questions[i].Question.QuestionFormat == enums.QuestionFormatOpen),
+		}
+		for _, c := range recognized.Confidence {
+			confidence = math.Min(confidence, c)
+		}
+	}
+
+	return questions, confidence
+}
+
+func loadScannedTest(variant string, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) (*models.Test, *common.ErrorResponse) {
+	testIdentifierData, err := helpers.ParseIdentifier(variant)
+	if err != nil {
+		return nil, err
+	}
+	if testIdentifierData.Type != helpers.SheetTypeStudent && testIdentifierData.Type != helpers.SheetTypeTeacher {
+		return nil, &common.ErrorResponse{
+			Code:    400,
+			Message: "Invalid type or sheet order",
+		}
+	}
+
+	if err := auth.GetClaimCourseRole(userData, testIdentifierData.CourseID, userRole); err != nil {
+		return nil, err
+	}
+
+	testData, err := loadSheetTest(initializers.DB, testIdentifierData)
+	if err != nil {
+		return nil, err
+	}
+	if testData.ID == 0 || testData.CourseID != testIdentifierData.CourseID {
+		return nil, &common.ErrorResponse{
+			Code:    404,
+			Message: "Test not found",
+		}
+	}
+	if len(testData.Questions) == 0 {
+		return nil, &common.ErrorResponse{
+			Code:    422,
+			Message: "Sheet does not match any questions of the test",
+		}
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	if _, err := courseItemService.GetCourseItemByID(initializers.DB, testData.CourseID, testData.CourseItemID, userData.ID, userRole, nil, false, nil); err != nil {
+		return nil, err
+	}
+
+	return testData, nil
+}
@@ -26,8 +26,10 @@ import (
 )
 
 type RecognizerTestSaveRequestQuestion struct {
-	Order   uint   `json:"order" binding:"required"`
-	Answers []bool `json:"answers" binding:"required"`
+	Order      uint      `json:"order" binding:"required"`
+	Answers    []bool    `json:"answers" binding:"required"`
+	Confidence []float64 `json:"confidence"` // Filled by built-in recognizer, confidence of every answer between 0 and 1
+	Ambiguous  bool      `json:"ambiguous"`  // Filled by built-in recognizer, marks should be confirmed by tutor
 }
 
 type RecognizerTestSaveRequest struct {
@@ -75,8 +77,8 @@ func RecognizerTestSave(c *gin.Context, userData authdtos.LoggedUserDTO, userRol
 	}
 
 	identifierParts := strings.Split(reqData.Variant, ";")
-	if strings.ToUpper(identifierParts[0]) == "V1" {
-		testIdentifierData, err := helpers.ParseV1Identifier(reqData.Variant)
+	if version := strings.ToUpper(identifierParts[0]); version == "V1" || version == "V2" {
+		testIdentifierData, err := helpers.ParseIdentifier(reqData.Variant)
 		if err != nil {
 			return err
 		}
@@ -145,156 +147,19 @@ func RecognizerTestSave(c *gin.Context, userData authdtos.LoggedUserDTO, userRol
 		}
 
 		// Get test data
-		instanceId, err := CreateOrVerifyInstance(initializers.DB, testIdentifierData.CourseID, testIdentifierData.TestID, loginData.Username, loginData.InstanceID)
+		instanceId, err := ResolveSheetInstance(initializers.DB, testIdentifierData, loginData)
 		if err != nil {
 			return err
 		}
 
 		transaction := initializers.DB.Begin()
-		events := make([]*models.TestInstanceEvent, 0)
 
-		instanceData, err := GetQuestionsByInstanceID(transaction, instanceId, testIdentifierData.Type, testIdentifierData.SheetOrder)
+		instanceData, err := SaveRecognizedAnswers(transaction, &userData, testIdentifierData, testData.CourseItem, instanceId, reqData.Questions)
 		if err != nil {
 			transaction.Rollback()
 			return err
 		}
 
-		if len(instanceData.Questions) != len(reqData.Questions) {
-			transaction.Rollback()
-			return &common.ErrorResponse{
-				Code:    0,
-				Message: "Failed to save recognized data",
-				Details: "Question count does not match",
-			}
-		}
-
-		for rq_i, rq := range reqData.Questions {
-			iq := instanceData.Questions[rq_i]
-
-			switch iq.TestQuestion.Question.QuestionFormat {
-			case enums.QuestionFormatOpen:
-				iq.TextAnswerPercentage = CalculatePercentage(rq.Answers)
-				iq.TextAnswerReviewedByID = &userData.ID
-
-				eventData, _ := json.Marshal(map[string]interface{}{
-					"QuestionOrder": iq.TestQuestion.Order,
-				})
-
-				events = append(events, &models.TestInstanceEvent{
-					TestInstanceID: iq.TestInstanceID,
-					UserID:         userData.ID,
-					OccuredAt:      time.Now(),
-					ReceivedAt:     time.Now(),
-					EventSource:    enums.TestInstanceEventSourceServer,
-					EventType:      enums.TestInstanceEventTypeQuestionUpdate,
-					EventData:      eventData,
-				})
-
-				if err := transaction.Save(&iq).Error; err != nil {
-					transaction.Rollback()
-					return &common.ErrorResponse{
-						Code:    500,
-						Message: "Failed to save answers for question",
-						Details: err.Error(),
-					}
-				}
-			case enums.QuestionFormatTest:
-				if len(rq.Answers) != len(iq.Answers) {
-					transaction.Rollback()
-					return &common.ErrorResponse{
-						Code:    0,
-						Message: "Failed to save recognized data",
-						Details: "Answer box count does not match",
-					}
-				}
-
-				for checked_i, checked := range rq.Answers {
-					iq.Answers[checked_i].Selected = checked
-
-					eventData, _ := json.Marshal(map[string]interface{}{
-						"QuestionOrder": iq.TestQuestion.Order,
-						"AnswerOrder":   rq.Order,
-						"AnswerData":    checked,
-					})
-
-					events = append(events, &models.TestInstanceEvent{
-						TestInstanceID: instanceData.ID,
-						UserID:         userData.ID,
-						OccuredAt:      time.Now(),
-						ReceivedAt:     time.Now(),
-						EventSource:    enums.TestInstanceEventSourceServer,
-						EventType:      enums.TestInstanceEventTypeQuestionUpdate,
-						EventData:      eventData,
-					})
-
-					if err := transaction.Save(&iq.Answers[checked_i]).Error; err != nil {
-						transaction.Rollback()
-						return &common.ErrorResponse{
-							Code:    500,
-							Message: "Failed to save answers for question",
-							Details: err.Error(),
-						}
-					}
-				}
-
-			default:
-				panic(fmt.Sprintf("unexpected enums.QuestionFormatEnum: %#v", iq.TestQuestion.Question.QuestionFormat))
-			}
-		}
-
-		switch instanceData.State {
-		case enums.TestInstanceStateReady:
-			instanceData.StartedAt = time.Now()
-			instanceData.EndsAt = time.Now()
-			instanceData.EndedAt = time.Now()
-			instanceData.State = enums.TestInstanceStateFinished
-		case enums.TestInstanceStateActive:
-			instanceData.EndedAt = time.Now()
-			instanceData.State = enums.TestInstanceStateFinished
-		}
-
-		if err := transaction.Save(&instanceData).Error; err != nil {
-			transaction.Rollback()
-			return &common.ErrorResponse{
-				Code:    500,
-				Message: "Failed to save instance",
-				Details: err.Error(),
-			}
-		}
-
-		err = testHandlers.EvaluateTestInstance(transaction, instanceData.ID, &userData, false)
-		if err != nil {
-			transaction.Rollback()
-			return &common.ErrorResponse{
-				Code:    500,
-				Message: "Failed to evaluate test",
-				Details: err,
-			}
-		}
-
-		rootCoureItem := testData.CourseItem.ID
-		if testData.CourseItem.ParentID != nil {
-			rootCoureItem = *testData.CourseItem.ParentID
-		}
-
-		services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
-		err = services_course_item.UpdateSelectedResults(transaction, testIdentifierData.CourseID, rootCoureItem, instanceData.ParticipantID)
-		if err != nil {
-			transaction.Rollback()
-			return err
-		}
-
-		if len(events) != 0 {
-			if err := transaction.Save(&events).Error; err != nil {
-				transaction.Rollback()
-				return &common.ErrorResponse{
-					Code:    500,
-					Message: "Failed to save events for question",
-					Details: err.Error(),
-				}
-			}
-		}
-
 		// Append parser file
 		file, header, file_err := c.Request.FormFile("file")
 		if file_err == nil {
@@ -397,6 +262,193 @@ func RecognizerTestSave(c *gin.Context, userData authdtos.LoggedUserDTO, userRol
 	}
 }
 
+// SaveRecognizedAnswers saves answers read from one sheet to the instance, then finishes and evaluates it.
+// Caller is responsible for the transaction.
+func SaveRecognizedAnswers(
+	dbRef *gorm.DB,
+	userData *authdtos.LoggedUserDTO,
+	identifier *helpers.SheetIdentifier,
+	courseItem *models.CourseItem,
+	instanceID uint,
+	questions []RecognizerTestSaveRequestQuestion,
+) (*models.TestInstance, *common.ErrorResponse) {
+	events := make([]*models.TestInstanceEvent, 0)
+
+	instanceData, err := GetQuestionsByInstanceID(dbRef, instanceID, identifier.Type, identifier.SheetOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(instanceData.Questions) != len(questions) {
+		return nil, &common.ErrorResponse{
+			Code:    0,
+			Message: "Failed to save recognized data",
+			Details: "Question count does not match",
+		}
+	}
+
+	for rq_i, rq := range questions {
+		iq := instanceData.Questions[rq_i]
+
+		switch iq.TestQuestion.Question.QuestionFormat {
+		case enums.QuestionFormatOpen:
+			iq.TextAnswerPercentage = CalculatePercentage(rq.Answers)
+			iq.TextAnswerReviewedByID = &userData.ID
+
+			eventData, _ := json.Marshal(map[string]interface{}{
+				"QuestionOrder": iq.TestQuestion.Order,
+			})
+
+			events = append(events, &models.TestInstanceEvent{
+				TestInstanceID: &iq.TestInstanceID,
+				UserID:         userData.ID,
+				OccuredAt:      time.Now(),
+				ReceivedAt:     time.Now(),
+				EventSource:    enums.TestInstanceEventSourceServer,
+				EventType:      enums.TestInstanceEventTypeQuestionUpdate,
+				EventData:      eventData,
+			})
+
+			if err := dbRef.Save(&iq).Error; err != nil {
+				return nil, &common.ErrorResponse{
+					Code:    500,
+					Message: "Failed to save answers for question",
+					Details: err.Error(),
+				}
+			}
+		case enums.QuestionFormatTest:
+			if len(rq.Answers) != len(iq.Answers) {
+				return nil, &common.ErrorResponse{
+					Code:    0,
+					Message: "Failed to save recognized data",
+					Details: "Answer box count does not match",
+				}
+			}
+
+			for checked_i, checked := range rq.Answers {
+				iq.Answers[checked_i].Selected = checked
+
+				eventData, _ := json.Marshal(map[string]interface{}{
+					"QuestionOrder": iq.TestQuestion.Order,
+					"AnswerOrder":   rq.Order,
+					"AnswerData":    checked,
+				})
+
+				events = append(events, &models.TestInstanceEvent{
+					TestInstanceID: &instanceData.ID,
+					UserID:         userData.ID,
+					OccuredAt:      time.Now(),
+					ReceivedAt:     time.Now(),
+					EventSource:    enums.TestInstanceEventSourceServer,
+					EventType:      enums.TestInstanceEventTypeQuestionUpdate,
+					EventData:      eventData,
+				})
+
+				if err := dbRef.Save(&iq.Answers[checked_i]).Error; err != nil {
+					return nil, &common.ErrorResponse{
+						Code:    500,
+						Message: "Failed to save answers for question",
+						Details: err.Error(),
+					}
+				}
+			}
+
+		default:
+			panic(fmt.Sprintf("unexpected enums.QuestionFormatEnum: %#v", iq.TestQuestion.Question.QuestionFormat))
+		}
+	}
+
+	switch instanceData.State {
+	case enums.TestInstanceStateReady:
+		instanceData.StartedAt = time.Now()
+		instanceData.EndsAt = time.Now()
+		instanceData.EndedAt = time.Now()
+	case enums.TestInstanceStateActive:
+		instanceData.EndedAt = time.Now()
+	}
+	if instanceData.State.CanTransitionTo(enums.TestInstanceStateFinished) {
+		if err := testHelpers.TransitionTestInstanceState(dbRef, instanceData, enums.TestInstanceStateFinished); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := dbRef.Save(&instanceData).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save instance",
+			Details: err.Error(),
+		}
+	}
+
+	err = testHandlers.EvaluateTestInstance(dbRef, instanceData.ID, userData, false)
+	if err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to evaluate test",
+			Details: err,
+		}
+	}
+
+	rootCoureItem := courseItem.ID
+	if courseItem.ParentID != nil {
+		rootCoureItem = *courseItem.ParentID
+	}
+
+	services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	err = services_course_item.UpdateSelectedResults(dbRef, identifier.CourseID, rootCoureItem, instanceData.ParticipantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) != 0 {
+		if err := dbRef.Save(&events).Error; err != nil {
+			return nil, &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to save events for question",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	return instanceData, nil
+}
+
+// ResolveSheetInstance finds instance the sheet belongs to. V2 sheets printed for participant carry signed instance,
+// which has to belong to the login. Other sheets are matched by login and missing instance is created.
+func ResolveSheetInstance(dbRef *gorm.DB, identifier *helpers.SheetIdentifier, loginData *helpers.V1Login) (uint, *common.ErrorResponse) {
+	if identifier.InstanceID == 0 {
+		return CreateOrVerifyInstance(dbRef, identifier.CourseID, identifier.TestID, loginData.Username, loginData.InstanceID)
+	}
+
+	if loginData.InstanceID != 0 && loginData.InstanceID != identifier.InstanceID {
+		return 0, &common.ErrorResponse{
+			Code:    422,
+			Message: "Participant does not match the sheet",
+		}
+	}
+
+	var testInstance *models.TestInstance
+	if err := dbRef.
+		Select("test_instances.id").
+		Where("test_id = ?", identifier.TestID).
+		InnerJoins("Participant", initializers.DB.Select("Participant.id").Where("Participant.username = ?", loginData.Username)).
+		Find(&testInstance, identifier.InstanceID).Error; err != nil {
+		return 0, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to find test instance",
+			Details: err.Error(),
+		}
+	}
+	if testInstance.ID == 0 {
+		return 0, &common.ErrorResponse{
+			Code:    422,
+			Message: "Participant does not match the sheet",
+		}
+	}
+
+	return testInstance.ID, nil
+}
+
 func CreateOrVerifyInstance(dbRef *gorm.DB, courseId uint, testId uint, username string, instanceId uint) (uint, *common.ErrorResponse) {
 	var testInstance *models.TestInstance
 	if instanceId != 0 {
@@ -0,0 +1,226 @@
+package helpers
+
+import (
+	"image"
+	"math"
+
+	"elogika.vsb.cz/backend/modules/common"
+	printHelpers "elogika.vsb.cz/backend/modules/print/helpers"
+	"elogika.vsb.cz/backend/utils"
+	"elogika.vsb.cz/backend/utils/omr"
+)
+
+// Share of dark pixels inside answer square from which the square counts as filled
+const markThreshold = 0.25
+
+// Distance of fill from markThreshold where recognition is fully confident
+const markUncertainty = 0.15
+
+// Margin inside printed square excluded from measurement, so the outline itself is not counted
+const markInset = 3.5
+
+// Margin around QR code area searched for the code
+const qrMargin = 8
+
+// Marks with lower confidence are considered ambiguous and have to be confirmed by tutor
+const ReviewConfidence = 0.5
+
+// ScannedSheet is answer sheet page located in scanned image
+type ScannedSheet struct {
+	Variant string // Content of test identifier QR code
+	Login   string // Content of participant QR code, empty for sheets printed without participant
+
+	image     image.Image
+	bitmap    *omr.Bitmap
+	transform omr.Affine
+}
+
+// RecognizedQuestion holds marks read from one question row of the sheet
+type RecognizedQuestion struct {
+	Answers    []bool
+	Confidence []float64 // Confidence of every answer between 0 and 1
+}
+
+// LocateSheet finds answer sheet printed by AnswerSheetPrinter in scanned page. The frame around answers
+// gives position and skew of the page, the corner triangle gives its rotation.
+func LocateSheet(img image.Image) (*ScannedSheet, *common.ErrorResponse) {
+	bitmap := omr.Binarize(img)
+	layout := printHelpers.NewAnswerSheetLayout(nil)
+
+	// Frame is the largest mark not touching border of the scan
+	var frame *omr.Component
+	components := omr.Components(bitmap, (bitmap.Width+bitmap.Height)/2)
+	for i, component := range components {
+		b := component.Bounds
+		if b.Min.X == 0 || b.Min.Y == 0 || b.Max.X == bitmap.Width || b.Max.Y == bitmap.Height {
+			continue
+		}
+		if b.Dx() < bitmap.Width/3 || b.Dy() < bitmap.Height/3 {
+			continue
+		}
+		if frame == nil || b.Dx()*b.Dy() > frame.Bounds.Dx()*frame.Bounds.Dy() {
+			frame = &components[i]
+		}
+	}
+	if frame == nil {
+		return nil, &common.ErrorResponse{
+			Code:    422,
+			Message: "Answer sheet frame not found",
+		}
+	}
+
+	// Extreme pixels of the frame are outer corners of its line
+	half := layout.LineWidth / 2
+	f := layout.Frame
+	sheetCorners := []omr.Point{
+		{X: f.X - half, Y: f.Y - half},
+		{X: f.X + f.W + half, Y: f.Y - half},
+		{X: f.X + f.W + half, Y: f.Y + f.H + half},
+		{X: f.X - half, Y: f.Y + f.H + half},
+	}
+	imageCorners := []omr.Point{frame.TopLeft, frame.TopRight, frame.BottomRight, frame.BottomLeft}
+
+	// Try all four rotations of the scan, only the right one has the triangle in place
+	var transform omr.Affine
+	bestScore := float64(-1)
+	for rotation := 0; rotation < 4; rotation++ {
+		rotated := make([]omr.Point, 4)
+		for i := range rotated {
+			rotated[i] = imageCorners[(i+rotation)%4]
+		}
+
+		candidate, ok := omr.FitAffine(sheetCorners, rotated)
+		if !ok {
+			continue
+		}
+
+		// Page is scaled equally in both directions
+		scaleX := math.Hypot(candidate.A, candidate.D)
+		scaleY := math.Hypot(candidate.B, candidate.E)
+		if math.Abs(scaleX/scaleY-1) > 0.1 {
+			continue
+		}
+
+		if score := triangleScore(bitmap, candidate, layout.Triangle); score > bestScore {
+			bestScore = score
+			transform = candidate
+		}
+	}
+	if bestScore < 0.7 {
+		return nil, &common.ErrorResponse{
+			Code:    422,
+			Message: "Answer sheet orientation mark not found",
+		}
+	}
+
+	sheet := &ScannedSheet{
+		image:     img,
+		bitmap:    bitmap,
+		transform: transform,
+	}
+
+	variant, err := sheet.decodeQR(layout.TestQR)
+	if err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    422,
+			Message: "Test identifier QR code not readable",
+			Details: err.Error(),
+		}
+	}
+	sheet.Variant = variant
+
+	// Sheets printed without participant have empty participant box
+	if login, err := sheet.decodeQR(layout.ParticipantQR); err == nil {
+		sheet.Login = login
+	}
+
+	return sheet, nil
+}
+
+// triangleScore returns share of dark samples along edges of the orientation triangle
+func triangleScore(bitmap *omr.Bitmap, transform omr.Affine, triangle printHelpers.SheetRect) float64 {
+	vertices := []omr.Point{
+		{X: triangle.X, Y: triangle.Y},
+		{X: triangle.X + triangle.W, Y: triangle.Y},
+		{X: triangle.X, Y: triangle.Y + triangle.H},
+	}
+
+	dark, total := 0, 0
+	for i, from := range vertices {
+		to := vertices[(i+1)%len(vertices)]
+		for step := 1; step < 10; step++ {
+			t := float64(step) / 10
+			p := omr.Point{X: from.X + (to.X-from.X)*t, Y: from.Y + (to.Y-from.Y)*t}
+
+			total++
+			if bitmap.Fill(transform, p.X-1.5, p.Y-1.5, 3, 3, 3) > 0 {
+				dark++
+			}
+		}
+	}
+
+	return float64(dark) / float64(total)
+}
+
+func (s *ScannedSheet) decodeQR(area printHelpers.SheetRect) (string, error) {
+	bounds := s.bitmap.Bounds(s.transform, area.X-qrMargin, area.Y-qrMargin, area.W+2*qrMargin, area.H+2*qrMargin)
+	return omr.DecodeQR(s.bitmap.Crop(bounds))
+}
+
+// ReadAnswers reads marks of question rows, answerCounts holds number of answer squares of every row.
+// When the repair box of row is marked, answers are read from the repair column.
+func (s *ScannedSheet) ReadAnswers(answerCounts []int) []RecognizedQuestion {
+	layout := printHelpers.NewAnswerSheetLayout(answerCounts)
+
+	result := make([]RecognizedQuestion, len(layout.Rows))
+	for i, row := range layout.Rows {
+		repairFill := s.fill(row.Repair)
+		repairConfidence := markConfidence(repairFill)
+
+		boxes := row.Answers
+		if repairFill >= markThreshold {
+			boxes = row.Repairs
+		}
+
+		question := RecognizedQuestion{
+			Answers:    make([]bool, len(boxes)),
+			Confidence: make([]float64, len(boxes)),
+		}
+		for j, box := range boxes {
+			fill := s.fill(box)
+			question.Answers[j] = fill >= markThreshold
+			question.Confidence[j] = math.Min(markConfidence(fill), repairConfidence)
+		}
+		result[i] = question
+	}
+
+	return result
+}
+
+// Render returns upright grayscale crop of the sheet area, scale is number of pixels per pt
+func (s *ScannedSheet) Render(area printHelpers.SheetRect, margin float64, scale float64) *image.Gray {
+	return omr.Resample(s.image, s.transform, area.X-margin, area.Y-margin, area.W+2*margin, area.H+2*margin, scale)
+}
+
+// IsAmbiguous reports marks which should be confirmed by tutor, that is half filled or crossed out squares
+// and more than one mark in the percentage scale of open question
+func (q RecognizedQuestion) IsAmbiguous(open bool) bool {
+	marked := 0
+	for i, answer := range q.Answers {
+		if q.Confidence[i] < ReviewConfidence {
+			return true
+		}
+		if answer {
+			marked++
+		}
+	}
+	return open && marked > 1
+}
+
+func (s *ScannedSheet) fill(box printHelpers.SheetRect) float64 {
+	return s.bitmap.Fill(s.transform, box.X+markInset, box.Y+markInset, box.W-2*markInset, box.H-2*markInset, 8)
+}
+
+func markConfidence(fill float64) float64 {
+	return utils.RoundToEven(math.Min(1, math.Abs(fill-markThreshold)/markUncertainty), 2)
+}
@@ -14,14 +14,31 @@ const (
 	SheetTypeStudent SheetTypeEnum = "S"
 )
 
-type V1Identifier struct {
+// SheetIdentifier is parsed content of test identifier QR code
+type SheetIdentifier struct {
 	CourseID   uint
 	TestID     uint
+	InstanceID uint // Only V2 sheets printed for participant carry instance, zero otherwise
 	Type       SheetTypeEnum
 	SheetOrder uint
 }
 
-func ParseV1Identifier(s string) (*V1Identifier, *common.ErrorResponse) {
+// ParseIdentifier parses identifier of any supported version
+func ParseIdentifier(s string) (*SheetIdentifier, *common.ErrorResponse) {
+	switch strings.ToUpper(strings.Split(s, ";")[0]) {
+	case "V1":
+		return ParseV1Identifier(s)
+	case "V2":
+		return ParseV2Identifier(s)
+	default:
+		return nil, &common.ErrorResponse{
+			Code:    422,
+			Message: "QR codes version not supported",
+		}
+	}
+}
+
+func ParseV1Identifier(s string) (*SheetIdentifier, *common.ErrorResponse) {
 	parts := strings.Split(s, ";")
 	if len(parts) != 4 || parts[0] != "V1" {
 		return nil, &common.ErrorResponse{
@@ -46,10 +63,23 @@ func ParseV1Identifier(s string) (*V1Identifier, *common.ErrorResponse) {
 		}
 	}
 
-	// Expecting exactly 1 character type + number (e.g. "S1" or "T12")
-	t := parts[3]
+	sheetType, sheetOrder, errResp := parseSheetType(parts[3])
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &SheetIdentifier{
+		CourseID:   uint(courseID),
+		TestID:     uint(testID),
+		Type:       sheetType,
+		SheetOrder: sheetOrder,
+	}, nil
+}
+
+// parseSheetType parses exactly 1 character type + number (e.g. "S1" or "T12")
+func parseSheetType(t string) (SheetTypeEnum, uint, *common.ErrorResponse) {
 	if len(t) < 2 {
-		return nil, &common.ErrorResponse{
+		return "", 0, &common.ErrorResponse{
 			Code:    400,
 			Message: "Invalid type or sheet order",
 		}
@@ -60,16 +90,11 @@ func ParseV1Identifier(s string) (*V1Identifier, *common.ErrorResponse) {
 
 	sheetOrder, err := strconv.ParseUint(orderPart, 10, 64)
 	if err != nil {
-		return nil, &common.ErrorResponse{
+		return "", 0, &common.ErrorResponse{
 			Code:    400,
 			Message: "Invalid sheet order",
 		}
 	}
 
-	return &V1Identifier{
-		CourseID:   uint(courseID),
-		TestID:     uint(testID),
-		Type:       SheetTypeEnum(typePart),
-		SheetOrder: uint(sheetOrder),
-	}, nil
+	return SheetTypeEnum(typePart), uint(sheetOrder), nil
 }
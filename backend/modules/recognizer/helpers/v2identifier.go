@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"strconv"
+	"strings"
+
+	"elogika.vsb.cz/backend/modules/common"
+	printHelpers "elogika.vsb.cz/backend/modules/print/helpers"
+)
+
+// ParseV2Identifier parses identifier in format V2;course;test;instance;S1;signature and verifies its signature
+func ParseV2Identifier(s string) (*SheetIdentifier, *common.ErrorResponse) {
+	parts := strings.Split(s, ";")
+	if len(parts) != 6 || parts[0] != "V2" {
+		return nil, &common.ErrorResponse{
+			Code:    400,
+			Message: "Invalid identifier format",
+		}
+	}
+
+	signature := printHelpers.SignIdentifier(strings.Join(parts[:5], ";"))
+	if !hmac.Equal([]byte(signature), []byte(strings.ToUpper(parts[5]))) {
+		return nil, &common.ErrorResponse{
+			Code:    422,
+			Message: "Invalid identifier checksum",
+		}
+	}
+
+	courseID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    400,
+			Message: "Invalid course id",
+		}
+	}
+
+	testID, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    400,
+			Message: "Invalid test id",
+		}
+	}
+
+	instanceID, err := strconv.ParseUint(parts[3], 10, 64)
+	if err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    400,
+			Message: "Invalid instance id",
+		}
+	}
+
+	sheetType, sheetOrder, errResp := parseSheetType(parts[4])
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	return &SheetIdentifier{
+		CourseID:   uint(courseID),
+		TestID:     uint(testID),
+		InstanceID: uint(instanceID),
+		Type:       sheetType,
+		SheetOrder: sheetOrder,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"elogika.vsb.cz/backend/initializers"
+	printHelpers "elogika.vsb.cz/backend/modules/print/helpers"
+)
+
+// withSheetSecret sets secret signing identifiers for the duration of the test
+func withSheetSecret(t *testing.T, secret string) {
+	previous := initializers.GlobalAppConfig
+	initializers.GlobalAppConfig = &initializers.AppConfig{SHEET_SECRET: []byte(secret)}
+	t.Cleanup(func() {
+		initializers.GlobalAppConfig = previous
+	})
+}
+
+func TestParseV2Identifier(t *testing.T) {
+	withSheetSecret(t, "test secret")
+
+	studentSheet := printHelpers.TestIdentifierV2(12, 345, 6789, printHelpers.SheetTypeStudent, 2)
+	teacherSheet := printHelpers.TestIdentifierV2(12, 345, 0, printHelpers.SheetTypeTeacher, 1)
+	signature := studentSheet[strings.LastIndex(studentSheet, ";")+1:]
+
+	withSheetSecret(t, "other secret")
+	foreignSheet := printHelpers.TestIdentifierV2(12, 345, 6789, printHelpers.SheetTypeStudent, 2)
+	withSheetSecret(t, "test secret")
+
+	signed := func(payload string) string {
+		return payload + ";" + printHelpers.SignIdentifier(payload)
+	}
+
+	tests := []struct {
+		name       string
+		identifier string
+		want       *SheetIdentifier
+		wantCode   int
+	}{
+		{"student sheet", studentSheet, &SheetIdentifier{CourseID: 12, TestID: 345, InstanceID: 6789, Type: SheetTypeStudent, SheetOrder: 2}, 0},
+		{"teacher sheet without instance", teacherSheet, &SheetIdentifier{CourseID: 12, TestID: 345, Type: SheetTypeTeacher, SheetOrder: 1}, 0},
+		{"lowercase signature", strings.TrimSuffix(studentSheet, signature) + strings.ToLower(signature), &SheetIdentifier{CourseID: 12, TestID: 345, InstanceID: 6789, Type: SheetTypeStudent, SheetOrder: 2}, 0},
+		{"changed instance", "V2;12;345;6780;S2;" + signature, nil, 422},
+		{"changed test", "V2;12;346;6789;S2;" + signature, nil, 422},
+		{"changed sheet", "V2;12;345;6789;S3;" + signature, nil, 422},
+		{"signed by other server", foreignSheet, nil, 422},
+		{"missing signature", "V2;12;345;6789;S2", nil, 400},
+		{"empty signature", "V2;12;345;6789;S2;", nil, 422},
+		{"other version", "V1;12;345;6789;S2;" + signature, nil, 400},
+		{"signed invalid course", signed("V2;x;345;6789;S2"), nil, 400},
+		{"signed invalid sheet order", signed("V2;12;345;6789;S"), nil, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseV2Identifier(tt.identifier)
+			if tt.want == nil {
+				if err == nil || err.Code != tt.wantCode {
+					t.Fatalf("got %+v, %+v, want error %d", got, err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %+v", err)
+			}
+			if *got != *tt.want {
+				t.Fatalf("got %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestSignIdentifier(t *testing.T) {
+	withSheetSecret(t, "test secret")
+	signature := printHelpers.SignIdentifier("V2;1;2;3;S1")
+
+	tests := []struct {
+		name     string
+		secret   string
+		payload  string
+		wantSame bool
+	}{
+		{"deterministic", "test secret", "V2;1;2;3;S1", true},
+		{"different payload", "test secret", "V2;1;2;4;S1", false},
+		{"different secret", "other secret", "V2;1;2;3;S1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withSheetSecret(t, tt.secret)
+			got := printHelpers.SignIdentifier(tt.payload)
+			if len(got) != 16 || strings.ToUpper(got) != got {
+				t.Fatalf("signature %q is not 16 uppercase hex characters", got)
+			}
+			if (got == signature) != tt.wantSame {
+				t.Fatalf("signature %q compared to %q: same %v, want %v", got, signature, got == signature, tt.wantSame)
+			}
+		})
+	}
+}
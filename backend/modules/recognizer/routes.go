@@ -9,5 +9,12 @@ import (
 func RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("recognizer/test/:identifier", wrappers.WithUserDataRole(handlers.RecognizerTestGet))
 	rg.POST("recognizer/test", wrappers.WithUserDataRole(handlers.RecognizerTestSave))
+	rg.POST("recognizer/scan", wrappers.WithUserDataRole(handlers.RecognizerScan))
+	rg.GET("courses/:courseId/recognizer/batches", wrappers.WithUserDataRole(handlers.RecognizerBatchList))
+	rg.GET("courses/:courseId/recognizer/batches/:batchId", wrappers.WithUserDataRole(handlers.RecognizerBatchGet))
+	rg.POST("courses/:courseId/recognizer/batches", wrappers.WithUserDataRole(handlers.RecognizerBatchInsert))
+	rg.GET("courses/:courseId/recognizer/review", wrappers.WithUserDataRole(handlers.RecognizerReviewList))
+	rg.GET("courses/:courseId/recognizer/review/:fileId/image", wrappers.WithUserDataRole(handlers.RecognizerReviewImage))
+	rg.POST("courses/:courseId/recognizer/review/:fileId", wrappers.WithUserDataRole(handlers.RecognizerReviewConfirm))
 
 }
@@ -0,0 +1,27 @@
+package dtos
+
+import "elogika.vsb.cz/backend/models"
+
+type RubricDTO struct {
+	ID        uint                     `json:"id"`
+	Version   uint                     `json:"version"`
+	Name      string                   `json:"name"`
+	Criteria  []models.RubricCriterion `json:"criteria"`
+	MaxPoints float64                  `json:"maxPoints"`
+}
+
+func (m RubricDTO) From(d *models.Rubric) RubricDTO {
+	dto := RubricDTO{
+		ID:        d.ID,
+		Version:   d.Version,
+		Name:      d.Name,
+		Criteria:  d.Criteria,
+		MaxPoints: d.MaxPoints(),
+	}
+
+	if dto.Criteria == nil {
+		dto.Criteria = make([]models.RubricCriterion, 0)
+	}
+
+	return dto
+}
@@ -0,0 +1,23 @@
+package dtos
+
+import (
+	"elogika.vsb.cz/backend/models"
+)
+
+type RubricListItemDTO struct {
+	ID            uint    `json:"id"`
+	Name          string  `json:"name"`
+	CriteriaCount int     `json:"criteriaCount"`
+	MaxPoints     float64 `json:"maxPoints"`
+}
+
+func (m RubricListItemDTO) From(d *models.Rubric) RubricListItemDTO {
+	dto := RubricListItemDTO{
+		ID:            d.ID,
+		Name:          d.Name,
+		CriteriaCount: len(d.Criteria),
+		MaxPoints:     d.MaxPoints(),
+	}
+
+	return dto
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/repositories"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type RubricDeleteResponse struct {
+	Success bool `json:"success"`
+}
+
+// @Summary Delete rubric that is not attached to any question or activity
+// @Tags Rubrics
+// @Security ApiKeyAuth
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param rubricId path int true "ID of the deleted rubric"
+// @Success 200 {object} RubricDeleteResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Rubric not found"
+// @Failure 409 {object} common.ErrorResponse "Rubric is in use"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/rubrics/{rubricId} [delete]
+func Delete(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			RubricID uint `uri:"rubricId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	// If not admin or garant
+	if userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	rubricRepo := repositories.NewRubricRepository()
+	rubric, err := rubricRepo.GetRubricByID(initializers.DB, params.CourseID, params.RubricID, nil)
+	if err != nil {
+		return err
+	}
+
+	var questionCount, activityCount int64
+	if err := initializers.DB.Model(&models.Question{}).Where("rubric_id = ?", rubric.ID).Count(&questionCount).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to check rubric usage",
+			Details: err.Error(),
+		}
+	}
+	if err := initializers.DB.Model(&models.CourseItemActivity{}).Where("rubric_id = ?", rubric.ID).Count(&activityCount).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to check rubric usage",
+			Details: err.Error(),
+		}
+	}
+	if questionCount != 0 || activityCount != 0 {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Rubric is used by questions or activities",
+		}
+	}
+
+	if err := initializers.DB.Delete(&rubric).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to delete rubric",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, RubricDeleteResponse{
+		Success: true,
+	})
+	return nil
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/rubrics/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Description Rubric data
+type RubricGetByIdResponse struct {
+	Data dtos.RubricDTO `json:"data"`
+}
+
+// @Summary Get rubric by id
+// @Tags Rubrics
+// @Security ApiKeyAuth
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param rubricId path int true "ID of the requested rubric"
+// @Success 200 {object} RubricGetByIdResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Rubric not found"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/rubrics/{rubricId} [get]
+func GetByID(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			RubricID uint `uri:"rubricId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant && userRole != enums.CourseUserRoleTutor {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	rubricRepo := repositories.NewRubricRepository()
+	rubric, err := rubricRepo.GetRubricByID(initializers.DB, params.CourseID, params.RubricID, nil)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(200, RubricGetByIdResponse{
+		Data: dtos.RubricDTO{}.From(rubric),
+	})
+	return nil
+}
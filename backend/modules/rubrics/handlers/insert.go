@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/rubrics/dtos"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Description Request to insert new rubric
+type RubricInsertRequest struct {
+	Name     string                   `json:"name" binding:"required"` // Name of the rubric
+	Criteria []models.RubricCriterion `json:"criteria"`                // Criteria with levels and their points
+}
+
+// @Description Newly created rubric
+type RubricInsertResponse struct {
+	Data dtos.RubricDTO `json:"data"`
+}
+
+// @Summary Create new rubric
+// @Tags Rubrics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param body body RubricInsertRequest true "New data for rubric"
+// @Success 200 {object} RubricInsertResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/rubrics [post]
+func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		RubricInsertRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	// If not admin or garant
+	if userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	if err := validateCriteria(reqData.Criteria); err != nil {
+		return err
+	}
+
+	rubric := &models.Rubric{
+		ID:          0,
+		Version:     1,
+		CourseID:    params.CourseID,
+		CreatedByID: userData.ID,
+		Name:        reqData.Name,
+		Criteria:    reqData.Criteria,
+	}
+
+	if err := initializers.DB.Save(&rubric).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to create rubric",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, RubricInsertResponse{
+		Data: dtos.RubricDTO{}.From(rubric),
+	})
+	return nil
+}
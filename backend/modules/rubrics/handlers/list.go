@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/rubrics/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type RubricListResponse struct {
+	Items      []dtos.RubricListItemDTO `json:"items"`
+	ItemsCount int64                    `json:"itemsCount"`
+}
+
+// @Summary List all rubrics in course
+// @Tags Rubrics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param search query string false "Ability to filter results"
+// @Success 200 {object} RubricListResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/rubrics [get]
+func List(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _, searchParams := utils.GetRequestDataWithSearch[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+		},
+		any,
+	](c, "search")
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant && userRole != enums.CourseUserRoleTutor {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	rubricRepo := repositories.NewRubricRepository()
+	rubrics, rubricCount, err := rubricRepo.ListRubrics(initializers.DB, params.CourseID, searchParams)
+	if err != nil {
+		return err
+	}
+
+	// Convert to DTOs
+	dtoList := make([]dtos.RubricListItemDTO, len(rubrics))
+	for i, r := range rubrics {
+		dtoList[i] = dtos.RubricListItemDTO{}.From(r)
+	}
+
+	c.JSON(200, RubricListResponse{
+		Items:      dtoList,
+		ItemsCount: rubricCount,
+	})
+	return nil
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/rubrics/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Description Request to update rubric
+type RubricUpdateRequest struct {
+	Name     string                   `json:"name" binding:"required"`    // Name of the rubric
+	Criteria []models.RubricCriterion `json:"criteria"`                   // Criteria with levels and their points
+	Version  uint                     `json:"version" binding:"required"` // Version signature to prevent concurrency problems
+}
+
+// @Description Updated rubric
+type RubricUpdateResponse struct {
+	Data dtos.RubricDTO `json:"data"`
+}
+
+// @Summary Modify rubric. Already graded answers keep points they were graded with.
+// @Tags Rubrics
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param rubricId path int true "ID of the updated rubric"
+// @Param body body RubricUpdateRequest true "New data for rubric"
+// @Success 200 {object} RubricUpdateResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Rubric not found"
+// @Failure 409 {object} common.ErrorResponse "Version mismatched"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/rubrics/{rubricId} [put]
+func Update(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID uint `uri:"courseId" binding:"required"`
+			RubricID uint `uri:"rubricId" binding:"required"`
+		},
+		RubricUpdateRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	// If not admin or garant
+	if userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	if err := validateCriteria(reqData.Criteria); err != nil {
+		return err
+	}
+
+	rubricRepo := repositories.NewRubricRepository()
+	rubric, err := rubricRepo.GetRubricByID(initializers.DB, params.CourseID, params.RubricID, &reqData.Version)
+	if err != nil {
+		return err
+	}
+
+	// Update only selected values
+	rubric.Version = reqData.Version + 1
+	rubric.Name = reqData.Name
+	rubric.Criteria = reqData.Criteria
+
+	if err := initializers.DB.Save(&rubric).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to update rubric",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, RubricUpdateResponse{
+		Data: dtos.RubricDTO{}.From(rubric),
+	})
+	return nil
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+)
+
+func validateCriteria(criteria []models.RubricCriterion) *common.ErrorResponse {
+	if len(criteria) == 0 {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Rubric must have at least one criterion",
+			FormErrors: common.ErrorObject{
+				"criteria": "Rubric must have at least one criterion",
+			},
+		}
+	}
+
+	for i, criterion := range criteria {
+		if criterion.Name == "" || len(criterion.Levels) == 0 {
+			return &common.ErrorResponse{
+				Code:    422,
+				Message: "Validation failed",
+				Details: fmt.Sprintf("Criterion %d must have name and at least one level", i),
+				FormErrors: common.ErrorObject{
+					fmt.Sprintf("criteria.%d", i): "Criterion must have name and at least one level",
+				},
+			}
+		}
+		for j, level := range criterion.Levels {
+			if level.Name == "" || level.Points < 0 {
+				return &common.ErrorResponse{
+					Code:    422,
+					Message: "Validation failed",
+					Details: fmt.Sprintf("Level %d of criterion %d must have name and non negative points", j, i),
+					FormErrors: common.ErrorObject{
+						fmt.Sprintf("criteria.%d.levels.%d", i, j): "Level must have name and non negative points",
+					},
+				}
+			}
+		}
+	}
+
+	return nil
+}
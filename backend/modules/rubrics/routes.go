@@ -0,0 +1,15 @@
+package rubrics
+
+import (
+	"elogika.vsb.cz/backend/modules/auth/wrappers"
+	"elogika.vsb.cz/backend/modules/rubrics/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("courses/:courseId/rubrics", wrappers.WithUserDataRole(handlers.List))
+	rg.POST("courses/:courseId/rubrics", wrappers.WithUserDataRole(handlers.Insert))
+	rg.GET("courses/:courseId/rubrics/:rubricId", wrappers.WithUserDataRole(handlers.GetByID))
+	rg.PUT("courses/:courseId/rubrics/:rubricId", wrappers.WithUserDataRole(handlers.Update))
+	rg.DELETE("courses/:courseId/rubrics/:rubricId", wrappers.WithUserDataRole(handlers.Delete))
+}
@@ -0,0 +1,17 @@
+package crons
+
+import (
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/similarity/handlers"
+	"elogika.vsb.cz/backend/utils"
+)
+
+// RunSimilarityJobs picks up jobs which were not started, e.g. because of server restart
+func RunSimilarityJobs() {
+	utils.FailStaleJobs(initializers.DB, &models.SimilarityJob{}, "Similarity check was interrupted")
+
+	for _, jobID := range utils.PendingJobIDs(initializers.DB, &models.SimilarityJob{}, "created_at ASC") {
+		handlers.RunSimilarityJob(jobID)
+	}
+}
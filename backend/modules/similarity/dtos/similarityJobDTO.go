@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type SimilarityJobDTO struct {
+	ID           uint                    `json:"id"`
+	CreatedAt    time.Time               `json:"createdAt"`
+	CourseItemID uint                    `json:"courseItemId"`
+	TermID       uint                    `json:"termId"`
+	Threshold    float64                 `json:"threshold"`
+	Status       enums.JobStatusEnum     `json:"status"`
+	Error        string                  `json:"error"`
+	FinishedAt   *time.Time              `json:"finishedAt"`
+	Compared     uint                    `json:"compared"`
+	Report       []models.SimilarityPair `json:"report"`
+}
+
+func (m SimilarityJobDTO) From(d *models.SimilarityJob) SimilarityJobDTO {
+	dto := SimilarityJobDTO{
+		ID:           d.ID,
+		CreatedAt:    d.CreatedAt,
+		CourseItemID: d.CourseItemID,
+		TermID:       d.TermID,
+		Threshold:    d.Threshold,
+		Status:       d.Status,
+		Error:        d.Error,
+		FinishedAt:   d.FinishedAt,
+		Compared:     d.Compared,
+		Report:       d.Report,
+	}
+
+	if dto.Report == nil {
+		dto.Report = []models.SimilarityPair{}
+	}
+
+	return dto
+}
@@ -0,0 +1,32 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type SimilarityJobListItemDTO struct {
+	ID         uint                `json:"id"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	Threshold  float64             `json:"threshold"`
+	Status     enums.JobStatusEnum `json:"status"`
+	Error      string              `json:"error"`
+	FinishedAt *time.Time          `json:"finishedAt"`
+	Compared   uint                `json:"compared"`
+	Pairs      uint                `json:"pairs"` // Number of reported pairs
+}
+
+func (m SimilarityJobListItemDTO) From(d *models.SimilarityJob) SimilarityJobListItemDTO {
+	return SimilarityJobListItemDTO{
+		ID:         d.ID,
+		CreatedAt:  d.CreatedAt,
+		Threshold:  d.Threshold,
+		Status:     d.Status,
+		Error:      d.Error,
+		FinishedAt: d.FinishedAt,
+		Compared:   d.Compared,
+		Pairs:      uint(len(d.Report)),
+	}
+}
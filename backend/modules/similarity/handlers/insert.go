@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Pairs sharing less than this part of their word shingles are not reported by default
+const defaultThreshold = 0.4
+
+type SimilarityInsertRequest struct {
+	Threshold *float64 `json:"threshold"` // Minimal similarity (0-1) of reported pairs
+}
+
+type SimilarityInsertResponse struct {
+	JobID uint `json:"jobId"`
+}
+
+// @Summary Starts similarity check of open answers within term
+// @Tags Similarity
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param body body SimilarityInsertRequest true "Check settings"
+// @Success 200 {object} SimilarityInsertResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 409 {object} common.ErrorResponse "Course item has no answers"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/similarity/{courseItemId}/{termId} [post]
+func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		SimilarityInsertRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+	if courseItem.Type != enums.CourseItemTypeTest && courseItem.Type != enums.CourseItemTypeActivity {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Course item has no answers",
+		}
+	}
+
+	threshold := defaultThreshold
+	if reqData.Threshold != nil {
+		threshold = *reqData.Threshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			FormErrors: common.ErrorObject{
+				"threshold": "Threshold must be between 0 and 1",
+			},
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	job, err := CreateSimilarityJob(transaction, params.CourseID, courseItem.ID, params.TermID, threshold, userData.ID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	go RunSimilarityJob(job.ID)
+
+	c.JSON(200, SimilarityInsertResponse{
+		JobID: job.ID,
+	})
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/utils"
+	"elogika.vsb.cz/backend/utils/similarity"
+	"gorm.io/gorm"
+)
+
+const (
+	// Length of word shingles, shorter passages are not considered as overlap
+	shingleSize = 5
+	// Report is capped so that huge terms do not produce unreadable results
+	maxReportedPairs = 500
+)
+
+// answer is plain text of one student answer prepared for comparison
+type answer struct {
+	sourceID      uint
+	studentID     uint
+	studentName   string
+	questionID    *uint
+	questionTitle string
+	text          string
+	document      *similarity.Document
+}
+
+// CreateSimilarityJob stores job comparing open answers of a term, run it by RunSimilarityJob after commit
+func CreateSimilarityJob(dbRef *gorm.DB, courseID uint, courseItemID uint, termID uint, threshold float64, userID uint) (*models.SimilarityJob, *common.ErrorResponse) {
+	job := &models.SimilarityJob{
+		CreatedByID:  userID,
+		CourseID:     courseID,
+		CourseItemID: courseItemID,
+		TermID:       termID,
+		Threshold:    threshold,
+		Status:       enums.JobStatusPending,
+	}
+
+	if err := dbRef.Create(job).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to create similarity job",
+			Details: err.Error(),
+		}
+	}
+
+	return job, nil
+}
+
+// RunSimilarityJob compares answers to the same question pairwise and reports pairs above threshold.
+func RunSimilarityJob(jobID uint) {
+	if !utils.ClaimJob(initializers.DB, &models.SimilarityJob{}, jobID) {
+		return
+	}
+
+	var job models.SimilarityJob
+	if err := initializers.DB.Preload("CourseItem").First(&job, jobID).Error; err != nil {
+		log.Println("Failed to load similarity job", jobID, err)
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			finishSimilarityJob(&job, enums.JobStatusFailed, fmt.Sprint(r))
+		}
+	}()
+
+	var groups [][]*answer
+	var err error
+	switch job.CourseItem.Type {
+	case enums.CourseItemTypeTest:
+		groups, err = loadTestAnswers(&job)
+	case enums.CourseItemTypeActivity:
+		groups, err = loadActivityAnswers(&job)
+	default:
+		err = fmt.Errorf("course item of type %s has no answers", job.CourseItem.Type)
+	}
+	if err != nil {
+		finishSimilarityJob(&job, enums.JobStatusFailed, err.Error())
+		return
+	}
+
+	job.Report = []models.SimilarityPair{}
+	for _, group := range groups {
+		for i := 0; i < len(group); i++ {
+			for j := i + 1; j < len(group); j++ {
+				a, b := group[i], group[j]
+				if a.studentID == b.studentID || a.document.Empty() || b.document.Empty() {
+					continue
+				}
+
+				job.Compared++
+				score := similarity.Jaccard(a.document, b.document)
+				if score < job.Threshold {
+					continue
+				}
+
+				job.Report = append(job.Report, similarityPair(a, b, score))
+			}
+		}
+	}
+
+	sort.SliceStable(job.Report, func(i, j int) bool {
+		return job.Report[i].Similarity > job.Report[j].Similarity
+	})
+	if len(job.Report) > maxReportedPairs {
+		job.Report = job.Report[:maxReportedPairs]
+	}
+
+	finishSimilarityJob(&job, enums.JobStatusFinished, "")
+}
+
+// loadTestAnswers groups text answers of open questions by bank question
+func loadTestAnswers(job *models.SimilarityJob) ([][]*answer, error) {
+	var instanceQuestions []*models.TestInstanceQuestion
+	if err := helpers.GradingTermQuery(initializers.DB, job.CourseItemID, job.TermID).
+		Joins("JOIN questions ON questions.id = test_questions.question_id").
+		Where("questions.question_format = ?", enums.QuestionFormatOpen).
+		Preload("TestInstance.Participant").
+		Preload("TestQuestion.Question").
+		Order("test_questions.question_id ASC").
+		Order("test_instance_questions.id ASC").
+		Find(&instanceQuestions).Error; err != nil {
+		return nil, err
+	}
+
+	groups := [][]*answer{}
+	indexes := map[uint]int{}
+	for _, ti_q := range instanceQuestions {
+		if ti_q.TextAnswer == nil {
+			continue
+		}
+
+		questionID := ti_q.TestQuestion.QuestionID
+		text := ti_q.TextAnswer.PlainText()
+		item := &answer{
+			sourceID:      ti_q.ID,
+			studentID:     ti_q.TestInstance.ParticipantID,
+			studentName:   ti_q.TestInstance.Participant.FullName(),
+			questionID:    &questionID,
+			questionTitle: ti_q.TestQuestion.Question.Title,
+			text:          text,
+			document:      similarity.NewDocument(text, shingleSize),
+		}
+
+		index, ok := indexes[questionID]
+		if !ok {
+			index = len(groups)
+			indexes[questionID] = index
+			groups = append(groups, []*answer{})
+		}
+		groups[index] = append(groups[index], item)
+	}
+
+	return groups, nil
+}
+
+// loadActivityAnswers returns submitted contents of the activity as one group
+func loadActivityAnswers(job *models.SimilarityJob) ([][]*answer, error) {
+	var activityInstances []*models.ActivityInstance
+	if err := initializers.DB.
+		Preload("Participant").
+		Where("course_item_id = ?", job.CourseItemID).
+		Where("term_id = ?", job.TermID).
+		Order("id ASC").
+		Find(&activityInstances).Error; err != nil {
+		return nil, err
+	}
+
+	group := []*answer{}
+	for _, activityInstance := range activityInstances {
+		if activityInstance.Content == nil {
+			continue
+		}
+
+		text := activityInstance.Content.PlainText()
+		group = append(group, &answer{
+			sourceID:      activityInstance.ID,
+			studentID:     activityInstance.ParticipantID,
+			studentName:   activityInstance.Participant.FullName(),
+			questionTitle: job.CourseItem.Name,
+			text:          text,
+			document:      similarity.NewDocument(text, shingleSize),
+		})
+	}
+
+	return [][]*answer{group}, nil
+}
+
+func similarityPair(a *answer, b *answer, score float64) models.SimilarityPair {
+	pair := models.SimilarityPair{
+		QuestionID:    a.questionID,
+		QuestionTitle: a.questionTitle,
+		SourceAID:     a.sourceID,
+		SourceBID:     b.sourceID,
+		StudentAID:    a.studentID,
+		StudentBID:    b.studentID,
+		StudentAName:  a.studentName,
+		StudentBName:  b.studentName,
+		Similarity:    score,
+		TextA:         a.text,
+		TextB:         b.text,
+		Passages:      []models.SimilarityPassage{},
+	}
+
+	for _, passage := range similarity.Passages(a.document, b.document, shingleSize) {
+		pair.Passages = append(pair.Passages, models.SimilarityPassage{
+			StartA: passage.StartA,
+			EndA:   passage.EndA,
+			StartB: passage.StartB,
+			EndB:   passage.EndB,
+		})
+	}
+
+	return pair
+}
+
+func finishSimilarityJob(job *models.SimilarityJob, status enums.JobStatusEnum, errorMessage string) {
+	finishedAt := time.Now()
+	job.Status = status
+	job.Error = errorMessage
+	job.FinishedAt = &finishedAt
+
+	if err := initializers.DB.Omit("CourseItem").Save(job).Error; err != nil {
+		log.Println("Failed to save similarity job", job.ID, err)
+	}
+}
@@ -0,0 +1,13 @@
+package similarity
+
+import (
+	"elogika.vsb.cz/backend/modules/auth/wrappers"
+	"elogika.vsb.cz/backend/modules/similarity/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("courses/:courseId/similarity/:courseItemId/jobs/:jobId", wrappers.WithUserDataRole(handlers.GetByID))
+	rg.GET("courses/:courseId/similarity/:courseItemId/:termId", wrappers.WithUserDataRole(handlers.List))
+	rg.POST("courses/:courseId/similarity/:courseItemId/:termId", wrappers.WithUserDataRole(handlers.Insert))
+}
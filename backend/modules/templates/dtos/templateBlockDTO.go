@@ -18,6 +18,8 @@ type TemplateBlockDTO struct {
 	AnswerDistribution    enums.AnswerDistributionEnum `json:"answerDistribution"`
 	WrongAnswerPercentage uint                         `json:"wrongAnswerPercentage"`
 	MixInsideBlock        bool                         `json:"mixInsideBlock"`
+	QuestionTimeLimit     uint                         `json:"questionTimeLimit"`
+	ScoringStrategy       enums.ScoringStrategyEnum    `json:"scoringStrategy"`
 	Segments              []TemplateBlockSegmentDTO    `json:"segments"`
 	AllowEmptyAnswers     bool                         `json:"allowEmptyAnswers"`
 }
@@ -36,6 +38,8 @@ func (TemplateBlockDTO) From(d *models.TemplateBlock) TemplateBlockDTO {
 		AnswerDistribution:    d.AnswerDistribution,
 		WrongAnswerPercentage: d.WrongAnswerPercentage,
 		MixInsideBlock:        d.MixInsideBlock,
+		QuestionTimeLimit:     d.QuestionTimeLimit,
+		ScoringStrategy:       d.ScoringStrategy,
 		AllowEmptyAnswers:     d.AllowEmptyAnswers,
 		Segments:              make([]TemplateBlockSegmentDTO, len(d.Segments)),
 	}
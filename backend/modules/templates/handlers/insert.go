@@ -37,6 +37,8 @@ type TemplateBlockInsertRequest struct {
 	AnswerDistribution    enums.AnswerDistributionEnum `json:"answerDistribution" binding:"required"`
 	WrongAnswerPercentage uint                         `json:"wrongAnswerPercentage" binding:"required"`
 	MixInsideBlock        bool                         `json:"mixInsideBlock"`
+	QuestionTimeLimit     uint                         `json:"questionTimeLimit"`
+	ScoringStrategy       enums.ScoringStrategyEnum    `json:"scoringStrategy"`
 	AllowEmptyAnswers     bool                         `json:"allowEmptyAnswers"`
 
 	Segments []TemplateBlockSegmentInsertRequest `json:"segments" binding:"required"`
@@ -99,6 +101,12 @@ func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 	blockWeight := 0
 	for _, block := range reqData.Blocks {
 		blockWeight += int(block.Weight)
+		if block.ScoringStrategy == enums.ScoringStrategySingleChoice && block.AnswerDistribution != enums.AnswerDistributionExactlyOneCorrect {
+			return &common.ErrorResponse{
+				Code:    422,
+				Message: "Single choice scoring requires exactly one correct answer",
+			}
+		}
 		if len(block.Segments) != 0 {
 			segmentQuestionsSum := uint(0)
 			for _, segment := range block.Segments {
@@ -156,6 +164,8 @@ func Insert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 			AnswerDistribution:    b.AnswerDistribution,
 			WrongAnswerPercentage: b.WrongAnswerPercentage,
 			MixInsideBlock:        b.MixInsideBlock,
+			QuestionTimeLimit:     b.QuestionTimeLimit,
+			ScoringStrategy:       b.ScoringStrategy,
 			TemplateID:            template.ID,
 			AllowEmptyAnswers:     b.AllowEmptyAnswers,
 		}
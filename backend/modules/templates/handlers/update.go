@@ -39,6 +39,8 @@ type TemplateBlockModelUpdateRequest struct {
 	AnswerDistribution    enums.AnswerDistributionEnum `json:"answerDistribution" binding:"required"`
 	WrongAnswerPercentage uint                         `json:"wrongAnswerPercentage" binding:"required"`
 	MixInsideBlock        bool                         `json:"mixInsideBlock"`
+	QuestionTimeLimit     uint                         `json:"questionTimeLimit"`
+	ScoringStrategy       enums.ScoringStrategyEnum    `json:"scoringStrategy"`
 	AllowEmptyAnswers     bool                         `json:"allowEmptyAnswers"`
 
 	Segments []TemplateBlockSegmentUpdateRequest `json:"segments" binding:"required"`
@@ -103,6 +105,12 @@ func Update(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 	blockWeight := 0
 	for _, block := range reqData.Blocks {
 		blockWeight += int(block.Weight)
+		if block.ScoringStrategy == enums.ScoringStrategySingleChoice && block.AnswerDistribution != enums.AnswerDistributionExactlyOneCorrect {
+			return &common.ErrorResponse{
+				Code:    422,
+				Message: "Single choice scoring requires exactly one correct answer",
+			}
+		}
 		if len(block.Segments) != 0 {
 			segmentQuestionsSum := uint(0)
 			for _, segment := range block.Segments {
@@ -167,6 +175,8 @@ func Update(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Cour
 		template.Blocks[i].AnswerDistribution = b.AnswerDistribution
 		template.Blocks[i].WrongAnswerPercentage = b.WrongAnswerPercentage
 		template.Blocks[i].MixInsideBlock = b.MixInsideBlock
+		template.Blocks[i].QuestionTimeLimit = b.QuestionTimeLimit
+		template.Blocks[i].ScoringStrategy = b.ScoringStrategy
 		template.Blocks[i].AllowEmptyAnswers = b.AllowEmptyAnswers
 
 		if err := transaction.Save(&template.Blocks[i]).Error; err != nil {
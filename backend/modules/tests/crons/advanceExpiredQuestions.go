@@ -0,0 +1,43 @@
+package crons
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/repositories"
+)
+
+// AdvanceExpiredQuestions switches students whose question time window passed, even when no client is asking
+func AdvanceExpiredQuestions() {
+	var expiredTestInstances []*models.TestInstance
+	initializers.DB.
+		Select("test_instances.id", "test_instances.participant_id").
+		Joins("JOIN test_instance_questions ON test_instance_questions.id = test_instances.current_question_id").
+		Where("test_instances.state = ?", enums.TestInstanceStateActive).
+		Where("test_instance_questions.deadline_at < ?", time.Now()).
+		Find(&expiredTestInstances)
+
+	testRepo := repositories.NewTestRepository()
+	for _, expiredTestInstance := range expiredTestInstances {
+		transaction := initializers.DB.Begin()
+
+		testInstance, err := testRepo.GetTestInstanceByID(transaction, expiredTestInstance.ID, expiredTestInstance.ParticipantID, nil, true, false, nil, &expiredTestInstance.ParticipantID)
+		if err != nil {
+			transaction.Rollback()
+			continue
+		}
+
+		if _, err := helpers.AdvanceExpiredQuestions(transaction, testInstance, testInstance.CourseItem.TestDetail, testInstance.ParticipantID, time.Now()); err != nil {
+			transaction.Rollback()
+			continue
+		}
+
+		if err := transaction.Commit().Error; err != nil {
+			transaction.Rollback()
+			continue
+		}
+	}
+}
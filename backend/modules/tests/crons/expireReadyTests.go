@@ -6,6 +6,7 @@ import (
 	"elogika.vsb.cz/backend/initializers"
 	"elogika.vsb.cz/backend/models"
 	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
 	"elogika.vsb.cz/backend/repositories"
 	services_course_item "elogika.vsb.cz/backend/services/courseItem"
 )
@@ -22,7 +23,11 @@ func ExpireReadyTests() {
 	for _, testInstance := range readyTestInstances {
 		transaction := initializers.DB.Begin()
 
-		testInstance.State = enums.TestInstanceStateExpired
+		if err := helpers.TransitionTestInstanceState(transaction, testInstance, enums.TestInstanceStateExpired); err != nil {
+			transaction.Rollback()
+			continue
+		}
+
 		rootCoureItem := testInstance.CourseItem.ID
 		if testInstance.CourseItem.ParentID != nil {
 			rootCoureItem = *testInstance.CourseItem.ParentID
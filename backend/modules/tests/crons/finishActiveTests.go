@@ -7,6 +7,7 @@ import (
 	"elogika.vsb.cz/backend/models"
 	"elogika.vsb.cz/backend/modules/common/enums"
 	"elogika.vsb.cz/backend/modules/tests/handlers"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
 	"elogika.vsb.cz/backend/repositories"
 	services_course_item "elogika.vsb.cz/backend/services/courseItem"
 )
@@ -22,7 +23,11 @@ func FinishActiveTests() {
 	for _, testInstance := range activeEndedTestInstances {
 		transaction := initializers.DB.Begin()
 
-		testInstance.State = enums.TestInstanceStateFinished
+		if err := helpers.TransitionTestInstanceState(transaction, testInstance, enums.TestInstanceStateFinished); err != nil {
+			transaction.Rollback()
+			continue
+		}
+
 		err := handlers.EvaluateTestInstance(transaction, testInstance.ID, nil, true)
 		if err != nil {
 			transaction.Rollback()
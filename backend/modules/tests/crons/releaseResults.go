@@ -0,0 +1,157 @@
+package crons
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/services"
+	"gorm.io/gorm"
+)
+
+// ReleaseResults records releases of visibility levels whose time or rule was met and notifies students of the term
+func ReleaseResults() {
+	automaticModes := []enums.ReleaseModeEnum{enums.ReleaseModeScheduled, enums.ReleaseModeAllFinal}
+
+	var courseItems []*models.CourseItem
+	if err := initializers.DB.
+		InnerJoins("TestDetail", initializers.DB.Where(
+			"TestDetail.results_release_mode IN ? OR TestDetail.test_release_mode IN ? OR TestDetail.correctness_release_mode IN ?",
+			automaticModes, automaticModes, automaticModes,
+		)).
+		Find(&courseItems).Error; err != nil {
+		log.Println("Failed to load tests with scheduled release", err)
+		return
+	}
+
+	now := time.Now()
+	for _, courseItem := range courseItems {
+		// Tests inside group are written in terms of the group
+		courseItemIDs := []uint{courseItem.ID}
+		if courseItem.ParentID != nil {
+			courseItemIDs = append(courseItemIDs, *courseItem.ParentID)
+		}
+
+		var terms []*models.Term
+		if err := initializers.DB.
+			Where("course_item_id IN ?", courseItemIDs).
+			Preload("Releases", "course_item_id = ?", courseItem.ID).
+			Find(&terms).Error; err != nil {
+			log.Println("Failed to load terms of test", courseItem.ID, err)
+			continue
+		}
+
+		for _, term := range terms {
+			levels := dueReleaseLevels(courseItem, term, now)
+			if len(levels) == 0 {
+				continue
+			}
+
+			if err := releaseTermLevels(courseItem, term, levels); err != nil {
+				log.Println("Failed to release results of term", term.ID, err)
+			}
+		}
+	}
+}
+
+// dueReleaseLevels returns levels of term which should be released but were not yet
+func dueReleaseLevels(courseItem *models.CourseItem, term *models.Term, now time.Time) []enums.ReleaseLevelEnum {
+	levels := []enums.ReleaseLevelEnum{}
+	allFinal := (*bool)(nil)
+
+	for _, level := range enums.ReleaseLevelEnumAll {
+		mode, releaseAt, _ := courseItem.TestDetail.ReleaseMode(level)
+		if mode == enums.ReleaseModeManual || termReleased(courseItem.ID, term, level) {
+			continue
+		}
+
+		switch mode {
+		case enums.ReleaseModeScheduled:
+			if releaseAt == nil || now.Before(*releaseAt) {
+				continue
+			}
+		case enums.ReleaseModeAllFinal:
+			if now.Before(term.ActiveTo) {
+				continue
+			}
+			if allFinal == nil {
+				final := isTermFinal(courseItem.ID, term.ID)
+				allFinal = &final
+			}
+			if !*allFinal {
+				continue
+			}
+		}
+
+		levels = append(levels, level)
+	}
+
+	return levels
+}
+
+func termReleased(courseItemID uint, term *models.Term, level enums.ReleaseLevelEnum) bool {
+	for _, release := range term.Releases {
+		if release.CourseItemID == courseItemID && release.Level == level {
+			return true
+		}
+	}
+	return false
+}
+
+// isTermFinal reports whether term has results and none of them waits for evaluation
+func isTermFinal(courseItemID uint, termID uint) bool {
+	var total, pending int64
+	query := initializers.DB.
+		Model(&models.CourseItemResult{}).
+		Where("course_item_id = ?", courseItemID).
+		Where("term_id = ?", termID)
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil || total == 0 {
+		return false
+	}
+	if err := query.Where("final = ?", false).Count(&pending).Error; err != nil {
+		return false
+	}
+	return pending == 0
+}
+
+func releaseTermLevels(courseItem *models.CourseItem, term *models.Term, levels []enums.ReleaseLevelEnum) error {
+	transaction := initializers.DB.Begin()
+
+	var studentIDs []uint
+	if err := transaction.
+		Model(&models.CourseItemResult{}).
+		Distinct("student_id").
+		Where("course_item_id = ?", courseItem.ID).
+		Where("term_id = ?", term.ID).
+		Pluck("student_id", &studentIDs).Error; err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	notificationService := services.NewNotificationService()
+	for _, studentID := range studentIDs {
+		if err := notificationService.QueueReleaseNotification(transaction, studentID, courseItem.Name, levels); err != nil {
+			transaction.Rollback()
+			return fmt.Errorf("%s: %v", err.Message, err.Details)
+		}
+	}
+
+	for _, level := range levels {
+		release := models.ResultRelease{
+			CourseItemID: courseItem.ID,
+			TermID:       term.ID,
+			Level:        level,
+			Notified:     uint(len(studentIDs)),
+		}
+		// Unique index guards against releasing twice by concurrent runs
+		if err := transaction.Create(&release).Error; err != nil {
+			transaction.Rollback()
+			return err
+		}
+	}
+
+	return transaction.Commit().Error
+}
@@ -0,0 +1,17 @@
+package crons
+
+import (
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/tests/handlers"
+	"elogika.vsb.cz/backend/utils"
+)
+
+// RunReevaluationJobs picks up jobs which were not started, e.g. because of server restart
+func RunReevaluationJobs() {
+	utils.FailStaleJobs(initializers.DB, &models.ReevaluationJob{}, "Reevaluation was interrupted")
+
+	for _, jobID := range utils.PendingJobIDs(initializers.DB, &models.ReevaluationJob{}, "created_at ASC") {
+		handlers.RunReevaluationJob(jobID)
+	}
+}
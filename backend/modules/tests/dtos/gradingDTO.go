@@ -0,0 +1,84 @@
+package dtos
+
+import (
+	"elogika.vsb.cz/backend/models"
+)
+
+type GradingQuestionDTO struct {
+	QuestionID uint   `json:"questionId"`
+	Title      string `json:"title"`
+	Total      uint   `json:"total"`
+	Graded     uint   `json:"graded"`
+}
+
+type GradingQueueItemDTO struct {
+	ID                   uint                     `json:"id"`
+	TestInstanceID       *uint                    `json:"testInstanceId"` // Hidden in blind mode
+	Student              *TestParticipantDTO      `json:"student"`        // Hidden in blind mode
+	TextAnswer           *models.TipTapContent    `json:"textAnswer"`
+	TextAnswerPercentage float64                  `json:"textAnswerPercentage"`
+	TextAnswerReviewed   bool                     `json:"textAnswerReviewed"`
+	Feedback             *models.TipTapContent    `json:"feedback"`
+	RubricSelections     []models.RubricSelection `json:"rubricSelections"`
+	AssignedGraderID     *uint                    `json:"assignedGraderId"`
+}
+
+func (m GradingQueueItemDTO) From(d *models.TestInstanceQuestion, blind bool) GradingQueueItemDTO {
+	dto := GradingQueueItemDTO{
+		ID:                   d.ID,
+		TextAnswer:           d.TextAnswer,
+		TextAnswerPercentage: d.TextAnswerPercentage,
+		TextAnswerReviewed:   d.TextAnswerReviewedByID != nil,
+		Feedback:             d.Feedback,
+		RubricSelections:     d.RubricSelections,
+		AssignedGraderID:     d.AssignedGraderID,
+	}
+
+	if !blind {
+		dto.TestInstanceID = &d.TestInstanceID
+		if d.TestInstance != nil && d.TestInstance.Participant != nil {
+			student := TestParticipantDTO{}.From(d.TestInstance.Participant)
+			dto.Student = &student
+		}
+	}
+
+	return dto
+}
+
+type GraderProgressDTO struct {
+	Grader   *TestParticipantDTO `json:"grader"` // Empty for unassigned answers
+	Assigned uint                `json:"assigned"`
+	Graded   uint                `json:"graded"`
+}
+
+// GradingProgress groups queue by assigned grader
+func GradingProgress(items []*models.TestInstanceQuestion) []GraderProgressDTO {
+	progress := make([]GraderProgressDTO, 0)
+	indexes := make(map[uint]int)
+
+	for _, item := range items {
+		graderID := uint(0)
+		if item.AssignedGraderID != nil {
+			graderID = *item.AssignedGraderID
+		}
+
+		index, ok := indexes[graderID]
+		if !ok {
+			entry := GraderProgressDTO{}
+			if item.AssignedGrader != nil {
+				grader := TestParticipantDTO{}.From(item.AssignedGrader)
+				entry.Grader = &grader
+			}
+			progress = append(progress, entry)
+			index = len(progress) - 1
+			indexes[graderID] = index
+		}
+
+		progress[index].Assigned++
+		if item.TextAnswerReviewedByID != nil {
+			progress[index].Graded++
+		}
+	}
+
+	return progress
+}
@@ -0,0 +1,78 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type ModerationMarkDTO struct {
+	ID                     uint                  `json:"id"`
+	TestInstanceQuestionID uint                  `json:"testInstanceQuestionId"`
+	QuestionTitle          string                `json:"questionTitle"`
+	TextAnswer             *models.TipTapContent `json:"textAnswer"`
+	FirstPercentage        *float64              `json:"firstPercentage"` // Hidden from second marker until marks are submitted
+	SecondPercentage       *float64              `json:"secondPercentage"`
+	Flagged                bool                  `json:"flagged"`
+	ResolvedPercentage     *float64              `json:"resolvedPercentage"`
+}
+
+type ModerationDTO struct {
+	ID             uint                       `json:"id"`
+	TestInstanceID *uint                      `json:"testInstanceId"` // Hidden in blind mode
+	Student        *TestParticipantDTO        `json:"student"`        // Hidden in blind mode
+	SecondGrader   TestParticipantDTO         `json:"secondGrader"`
+	Status         enums.ModerationStatusEnum `json:"status"`
+	CompletedAt    *time.Time                 `json:"completedAt"`
+	Marks          []ModerationMarkDTO        `json:"marks"`
+}
+
+func (m ModerationDTO) From(d *models.Moderation, blind bool, hideFirstMarks bool) ModerationDTO {
+	dto := ModerationDTO{
+		ID:           d.ID,
+		SecondGrader: TestParticipantDTO{}.From(d.SecondGrader),
+		Status:       d.Status,
+		CompletedAt:  d.CompletedAt,
+		Marks:        make([]ModerationMarkDTO, len(d.Marks)),
+	}
+
+	if !blind {
+		dto.TestInstanceID = &d.TestInstanceID
+		if d.TestInstance != nil && d.TestInstance.Participant != nil {
+			student := TestParticipantDTO{}.From(d.TestInstance.Participant)
+			dto.Student = &student
+		}
+	}
+
+	for i, mark := range d.Marks {
+		markDTO := ModerationMarkDTO{
+			ID:                     mark.ID,
+			TestInstanceQuestionID: mark.TestInstanceQuestionID,
+			SecondPercentage:       mark.SecondPercentage,
+			Flagged:                mark.Flagged,
+			ResolvedPercentage:     mark.ResolvedPercentage,
+		}
+		if question := mark.TestInstanceQuestion; question != nil {
+			markDTO.TextAnswer = question.TextAnswer
+			if question.TestQuestion != nil && question.TestQuestion.Question != nil {
+				markDTO.QuestionTitle = question.TestQuestion.Question.Title
+			}
+		}
+
+		if !hideFirstMarks {
+			if d.Status == enums.ModerationStatusPending {
+				// First mark can still change until second marking is submitted
+				if question := mark.TestInstanceQuestion; question != nil && question.TextAnswerReviewedByID != nil {
+					markDTO.FirstPercentage = &question.TextAnswerPercentage
+				}
+			} else {
+				markDTO.FirstPercentage = &mark.FirstPercentage
+			}
+		}
+
+		dto.Marks[i] = markDTO
+	}
+
+	return dto
+}
@@ -0,0 +1,44 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type QuestionInvalidationDTO struct {
+	ID            uint                               `json:"id"`
+	CreatedAt     time.Time                          `json:"createdAt"`
+	TermID        uint                               `json:"termId"`
+	TestID        *uint                              `json:"testId"`
+	QuestionID    uint                               `json:"questionId"`
+	QuestionTitle string                             `json:"questionTitle"`
+	AnswerID      *uint                              `json:"answerId"`
+	Mode          enums.QuestionInvalidationModeEnum `json:"mode"`
+	Reason        string                             `json:"reason"`
+
+	CreatedBy TestParticipantDTO `json:"createdBy"`
+}
+
+func (m QuestionInvalidationDTO) From(d *models.QuestionInvalidation) QuestionInvalidationDTO {
+	dto := QuestionInvalidationDTO{
+		ID:         d.ID,
+		CreatedAt:  d.CreatedAt,
+		TermID:     d.TermID,
+		TestID:     d.TestID,
+		QuestionID: d.QuestionID,
+		AnswerID:   d.AnswerID,
+		Mode:       d.Mode,
+		Reason:     d.Reason,
+	}
+
+	if d.Question != nil {
+		dto.QuestionTitle = d.Question.Title
+	}
+	if d.CreatedBy != nil {
+		dto.CreatedBy = TestParticipantDTO{}.From(d.CreatedBy)
+	}
+
+	return dto
+}
@@ -0,0 +1,40 @@
+package dtos
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+type ReevaluationJobDTO struct {
+	ID           uint                            `json:"id"`
+	CreatedAt    time.Time                       `json:"createdAt"`
+	CourseItemID uint                            `json:"courseItemId"`
+	TermID       uint                            `json:"termId"`
+	TestID       *uint                           `json:"testId"`
+	Status       enums.JobStatusEnum             `json:"status"`
+	Error        string                          `json:"error"`
+	FinishedAt   *time.Time                      `json:"finishedAt"`
+	Report       []models.ReevaluationReportItem `json:"report"`
+}
+
+func (m ReevaluationJobDTO) From(d *models.ReevaluationJob) ReevaluationJobDTO {
+	dto := ReevaluationJobDTO{
+		ID:           d.ID,
+		CreatedAt:    d.CreatedAt,
+		CourseItemID: d.CourseItemID,
+		TermID:       d.TermID,
+		TestID:       d.TestID,
+		Status:       d.Status,
+		Error:        d.Error,
+		FinishedAt:   d.FinishedAt,
+		Report:       d.Report,
+	}
+
+	if dto.Report == nil {
+		dto.Report = []models.ReevaluationReportItem{}
+	}
+
+	return dto
+}
@@ -11,4 +11,6 @@ type StudentTestDTO struct {
 	ActiveFrom     time.Time `json:"activeFrom"`
 	ActiveTo       time.Time `json:"activeTo"`
 	CanStart       bool      `json:"canStart"`
+
+	RequiresAccessCode bool `json:"requiresAccessCode"`
 }
@@ -32,6 +32,12 @@ type TestInstanceDTO struct {
 	ShowContent     bool `json:"showContent"`
 	ShowCorrectness bool `json:"showCorrectness"`
 
+	RequiresAccessCode bool `json:"requiresAccessCode"`
+
+	NavigationMode     enums.TestNavigationModeEnum `json:"navigationMode"`
+	CurrentQuestionID  *uint                        `json:"currentQuestionId"`
+	QuestionTimeLimits bool                         `json:"questionTimeLimits"`
+
 	RecognizerFiles []FileDTO `json:"recognizerFiles"`
 }
 
@@ -39,19 +45,14 @@ func (m TestInstanceDTO) From(
 	d *models.TestInstance,
 	isTutor bool,
 ) TestInstanceDTO {
-	showResults := true
-	showCorrectness := d.CourseItem.TestDetail.ShowCorrectness
+	now := time.Now()
+	showResults := d.CourseItem.TestDetail.IsReleased(enums.ReleaseLevelResults, d.CourseItemID, d.Term, now)
+	showCorrectness := d.CourseItem.TestDetail.IsReleased(enums.ReleaseLevelCorrectness, d.CourseItemID, d.Term, now)
 	showTestContent := false
 	showLayout := d.CourseItem.TestDetail.IsPaper
 
-	if !d.CourseItem.TestDetail.ShowResults {
-		if d.Term.ActiveTo.After(time.Now()) {
-			showResults = false
-		}
-	}
-
 	if d.State == enums.TestInstanceStateFinished {
-		showTestContent = d.CourseItem.TestDetail.ShowTest
+		showTestContent = d.CourseItem.TestDetail.IsReleased(enums.ReleaseLevelTest, d.CourseItemID, d.Term, now)
 	}
 
 	if d.State == enums.TestInstanceStateActive {
@@ -77,12 +78,24 @@ func (m TestInstanceDTO) From(
 		QuestionCount: uint(len(d.Questions)),
 		TimeLimit:     d.CourseItem.TestDetail.TimeLimit,
 		Participant:   TestParticipantDTO{}.From(d.Participant),
+
+		RequiresAccessCode: d.Term.RequiresAccessCode,
+
+		NavigationMode:     d.CourseItem.TestDetail.NavigationMode,
+		CurrentQuestionID:  d.CurrentQuestionID,
+		QuestionTimeLimits: d.CourseItem.TestDetail.QuestionTimeLimits,
+	}
+
+	// In sequential modes, student can see only currently unlocked questions while writing
+	questions := d.Questions
+	if !isTutor && d.State == enums.TestInstanceStateActive && dto.NavigationMode.IsSequential() {
+		questions = d.UnlockedQuestions(dto.NavigationMode)
 	}
 
 	if showLayout || showTestContent {
-		dto.Questions = make([]TestInstanceQuestionDTO, len(d.Questions))
-		for q_i, q := range d.Questions {
-			dto.Questions[q_i] = TestInstanceQuestionDTO{}.From(q, isTutor, showTestContent, showCorrectness)
+		dto.Questions = make([]TestInstanceQuestionDTO, len(questions))
+		for q_i, q := range questions {
+			dto.Questions[q_i] = TestInstanceQuestionDTO{}.From(q, isTutor, showTestContent, showCorrectness, showResults)
 		}
 	}
 
@@ -1,6 +1,8 @@
 package dtos
 
 import (
+	"time"
+
 	"elogika.vsb.cz/backend/models"
 	"elogika.vsb.cz/backend/modules/common/enums"
 )
@@ -18,6 +20,11 @@ type TestInstanceQuestionDTO struct {
 	Content              *models.TipTapContent           `json:"content,omitempty"`
 	QuestionFormat       enums.QuestionFormatEnum        `json:"questionFormat"`
 	QuestionID           uint                            `json:"questionId"`
+	OpenedAt             *time.Time                      `json:"openedAt"`
+	DeadlineAt           *time.Time                      `json:"deadlineAt"`
+	TimeSpent            *uint                           `json:"timeSpent,omitempty"`
+	RubricSelections     []models.RubricSelection        `json:"rubricSelections,omitempty"`
+	Feedback             *models.TipTapContent           `json:"feedback,omitempty"`
 }
 
 func (m TestInstanceQuestionDTO) From(
@@ -25,6 +32,7 @@ func (m TestInstanceQuestionDTO) From(
 	showTutor bool,
 	showTest bool,
 	showCorrectness bool,
+	showResults bool,
 ) TestInstanceQuestionDTO {
 	dto := TestInstanceQuestionDTO{
 		ID:             d.ID,
@@ -33,6 +41,8 @@ func (m TestInstanceQuestionDTO) From(
 		QuestionID:     d.TestQuestion.QuestionID,
 		Order:          d.TestQuestion.Order,
 		QuestionFormat: d.TestQuestion.Question.QuestionFormat,
+		OpenedAt:       d.OpenedAt,
+		DeadlineAt:     d.DeadlineAt,
 		Answers:        make([]TestInstanceQuestionAnswerDTO, len(d.Answers)),
 	}
 
@@ -44,9 +54,15 @@ func (m TestInstanceQuestionDTO) From(
 		dto.Answers[a_i] = TestInstanceQuestionAnswerDTO{}.From(a, showTest, showCorrectness)
 	}
 
+	if showResults {
+		dto.RubricSelections = d.RubricSelections
+		dto.Feedback = d.Feedback
+	}
+
 	if showTutor {
 		dto.Title = &d.TestQuestion.Question.Title
 		dto.TextAnswerPercentage = d.TextAnswerPercentage
+		dto.TimeSpent = &d.TimeSpent
 		dto.OpenAnswers = make([]TestInstanceOpenAnswerDTO, len(d.TestQuestion.OpenAnswers))
 		for a_i, a := range d.TestQuestion.OpenAnswers {
 			dto.OpenAnswers[a_i] = TestInstanceOpenAnswerDTO{}.From(&a)
@@ -16,6 +16,8 @@ type TestInstanceStudentListItemDTO struct {
 	EndsAt         time.Time                   `json:"endsAt"`
 	TermName       string                      `json:"termName"`
 	CourseItemName string                      `json:"courseItemName"`
+
+	RequiresAccessCode bool `json:"requiresAccessCode"`
 }
 
 func (m TestInstanceStudentListItemDTO) From(d *models.TestInstance) TestInstanceStudentListItemDTO {
@@ -28,6 +30,8 @@ func (m TestInstanceStudentListItemDTO) From(d *models.TestInstance) TestInstanc
 		EndsAt:         d.EndsAt,
 		TermName:       d.Term.Name,
 		CourseItemName: d.CourseItem.Name,
+
+		RequiresAccessCode: d.Term.RequiresAccessCode,
 	}
 
 	return dto
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"time"
 
 	"elogika.vsb.cz/backend/auth"
 	"elogika.vsb.cz/backend/initializers"
@@ -9,6 +10,7 @@ import (
 	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
 	"elogika.vsb.cz/backend/repositories"
 	services_course_item "elogika.vsb.cz/backend/services/courseItem"
 	"elogika.vsb.cz/backend/utils"
@@ -169,9 +171,9 @@ type EvaluationBlock struct {
 	QuestionFormat enums.QuestionFormatEnum
 
 	// Question format = ABCD questions
-	TotalAnswers          float64
-	CorrectlyAnswered     float64
-	IncorrectlyAnswered   float64
+	ScoringStrategy       enums.ScoringStrategyEnum
+	Score                 float64
+	ScoreMax              float64
 	WrongAnswerPercentage uint
 	AllowEmptyAnswers     bool
 
@@ -216,12 +218,26 @@ func EvaluateTestInstance(dbRef *gorm.DB, instanceID uint, userData *authdtos.Lo
 		}
 	}
 
+	var invalidations []*models.QuestionInvalidation
+	if err := dbRef.
+		Where("course_item_id = ?", testInstance.CourseItemID).
+		Where("term_id = ?", testInstance.TermID).
+		Where("test_id IS NULL OR test_id = ?", testInstance.TestID).
+		Find(&invalidations).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load question invalidations",
+			Details: err.Error(),
+		}
+	}
+
 	// remap back into blocks
 	evaluationMap := make(map[uint]*EvaluationBlock)
 
 	for _, block := range testInstance.Test.Blocks {
 		evaluationMap[block.ID] = &EvaluationBlock{
 			Weight:                block.Weight,
+			ScoringStrategy:       block.ScoringStrategy,
 			WrongAnswerPercentage: block.WrongAnswerPercentage,
 			AllowEmptyAnswers:     block.AllowEmptyAnswers,
 		}
@@ -229,18 +245,30 @@ func EvaluateTestInstance(dbRef *gorm.DB, instanceID uint, userData *authdtos.Lo
 
 	for _, q := range testInstance.Questions {
 		block := evaluationMap[q.TestQuestion.BlockID]
+		questionMode, acceptedAnswerIDs := helpers.QuestionInvalidationFor(invalidations, q.TestQuestion.QuestionID)
+
+		if questionMode == enums.QuestionInvalidationModeDrop {
+			block.QuestionFormat = q.TestQuestion.Question.QuestionFormat
+			continue
+		}
 
 		switch q.TestQuestion.Question.QuestionFormat {
 		case enums.QuestionFormatOpen:
 			block.TotalQuestions++
-			block.TextAnswerPercentageSum += float64(q.TextAnswerPercentage)
-			block.TextAnswerReviewed = block.TextAnswerReviewed && q.TextAnswerReviewedByID != nil
+			if questionMode == enums.QuestionInvalidationModeFullCredit {
+				block.TextAnswerPercentageSum += 100
+			} else {
+				block.TextAnswerPercentageSum += float64(q.TextAnswerPercentage)
+				block.TextAnswerReviewed = block.TextAnswerReviewed && q.TextAnswerReviewedByID != nil
+			}
 			block.QuestionFormat = q.TestQuestion.Question.QuestionFormat
 		case enums.QuestionFormatTest:
-			total, correct, incorrect := QuestionAnswersScore(q.Answers, block.AllowEmptyAnswers)
-			block.TotalAnswers += total
-			block.CorrectlyAnswered += correct
-			block.IncorrectlyAnswered += incorrect
+			score, scoreMax := helpers.QuestionScore(block.ScoringStrategy, q.Answers, block.WrongAnswerPercentage, block.AllowEmptyAnswers, acceptedAnswerIDs)
+			if questionMode == enums.QuestionInvalidationModeFullCredit {
+				score = scoreMax
+			}
+			block.Score += score
+			block.ScoreMax += scoreMax
 			block.QuestionFormat = q.TestQuestion.Question.QuestionFormat
 		default:
 			panic(fmt.Sprintf("unexpected enums.QuestionFormatEnum: %#v", q.TestQuestion.Question.QuestionFormat))
@@ -261,12 +289,17 @@ func EvaluateTestInstance(dbRef *gorm.DB, instanceID uint, userData *authdtos.Lo
 				final = false
 			}
 			textAnswerPercentage := block.TextAnswerPercentageSum / 100 * block.TotalQuestions
+			// Block with all questions dropped is awarded in full, nobody should lose points for invalidated block
+			if block.TotalQuestions == 0 {
+				textAnswerPercentage = 1
+			}
 
 			points += textAnswerPercentage * (testPointsMax * blockWeight)
 		case enums.QuestionFormatTest:
-			wrongAnswerPercentage := utils.ToPercentage(block.WrongAnswerPercentage)
-
-			ratio := (block.CorrectlyAnswered - wrongAnswerPercentage*block.IncorrectlyAnswered) / block.TotalAnswers
+			ratio := float64(1)
+			if block.ScoreMax != 0 {
+				ratio = block.Score / block.ScoreMax
+			}
 			points += ratio * (testPointsMax * blockWeight)
 		default:
 			utils.DebugPrintJSON(evaluationMap)
@@ -276,8 +309,28 @@ func EvaluateTestInstance(dbRef *gorm.DB, instanceID uint, userData *authdtos.Lo
 
 	points += testInstance.BonusPoints
 
+	if final {
+		pending, err := helpers.IsModerationPending(dbRef, &testInstance)
+		if err != nil {
+			return err
+		}
+		final = !pending
+	}
+
+	// Regrade window starts when result becomes final for the first time
+	if final && (!testInstance.Result.Final || testInstance.Result.EvaluatedAt == nil) {
+		evaluatedAt := time.Now()
+		testInstance.Result.EvaluatedAt = &evaluatedAt
+	}
+
 	testInstance.Result.Version = testInstance.Result.Version + 1
-	testInstance.Result.Points = utils.RoundToEven(points, 2)
+	if err := testInstance.Result.SetRawPoints(dbRef, utils.RoundToEven(points, 2), testInstance.CourseItem.PointsMax); err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to apply point scaling",
+			Details: err.Error(),
+		}
+	}
 	testInstance.Result.Final = final
 	if userData != nil {
 		testInstance.Result.UpdatedByID = &userData.ID
@@ -293,30 +346,3 @@ func EvaluateTestInstance(dbRef *gorm.DB, instanceID uint, userData *authdtos.Lo
 
 	return nil
 }
-
-func QuestionAnswersScore(answers []*models.TestInstanceQuestionAnswer, allowEmptyAnswers bool) (float64, float64, float64) {
-	var numAnswers = float64(0)
-	var numChecked = float64(0)
-	var numCorrect = float64(0)
-	var numIncorrect = float64(0)
-
-	// Assings answer statistics for question
-	for _, qa := range answers {
-		numAnswers++
-		if qa.Selected {
-			numChecked++
-		}
-
-		if qa.TestQuestionAnswer.Answer.Correct == qa.Selected {
-			numCorrect++
-		} else {
-			numIncorrect++
-		}
-	}
-
-	if allowEmptyAnswers && (numChecked == 0 || numChecked == numAnswers) {
-		return numAnswers, 0, 0
-	}
-
-	return numAnswers, numCorrect, numIncorrect
-}
@@ -192,6 +192,12 @@ func Generate(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Co
 			}
 		}
 
+		variantTests, err := loadSeatedVariantTests(transaction, params.CourseItemID, params.TermID)
+		if err != nil {
+			transaction.Rollback()
+			return err
+		}
+
 		generatedTestInstances := make([]*models.TestInstance, 0)
 
 		for _, ju := range joinedUsers {
@@ -216,29 +222,33 @@ func Generate(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.Co
 				}
 			}
 
-			generatedTest, err := GenerateTest(
-				transaction,
-				template,
-				generatorCache,
-				params.CourseID,
-				params.CourseItemID,
-				params.TermID,
-				&userData,
-				ju.User.FamilyName+" "+ju.User.FirstName,
-				"",
-				reqData.ForceUnique,
-			)
-			if err != nil {
-				transaction.Rollback()
-				return err
-			}
+			// Students seated by seating plan write their assigned variant instead of unique test
+			generatedTest, ok := variantTests[ju.UserID]
+			if !ok {
+				generatedTest, err = GenerateTest(
+					transaction,
+					template,
+					generatorCache,
+					params.CourseID,
+					params.CourseItemID,
+					params.TermID,
+					&userData,
+					ju.User.FamilyName+" "+ju.User.FirstName,
+					"",
+					reqData.ForceUnique,
+				)
+				if err != nil {
+					transaction.Rollback()
+					return err
+				}
 
-			if err := transaction.Save(&generatedTest).Error; err != nil {
-				transaction.Rollback()
-				return &common.ErrorResponse{
-					Code:    500,
-					Message: "Failed to save generated test",
-					Details: err.Error(),
+				if err := transaction.Save(&generatedTest).Error; err != nil {
+					transaction.Rollback()
+					return &common.ErrorResponse{
+						Code:    500,
+						Message: "Failed to save generated test",
+						Details: err.Error(),
+					}
 				}
 			}
 
@@ -368,6 +378,62 @@ func GenerateSingleTest(
 	return generatedTestVariant, nil
 }
 
+// loadSeatedVariantTests returns test variants assigned to students by seating plan of term. The latest test of
+// every variant is used.
+func loadSeatedVariantTests(dbRef *gorm.DB, courseItemID uint, termID uint) (map[uint]*models.Test, *common.ErrorResponse) {
+	var termSeats []*models.TermSeat
+	if err := dbRef.
+		Where("term_id = ?", termID).
+		Where("variant <> ?", "").
+		Find(&termSeats).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to fetch seating plan",
+			Details: err.Error(),
+		}
+	}
+	if len(termSeats) == 0 {
+		return map[uint]*models.Test{}, nil
+	}
+
+	groups := []string{}
+	for _, termSeat := range termSeats {
+		if !slices.Contains(groups, termSeat.Variant) {
+			groups = append(groups, termSeat.Variant)
+		}
+	}
+
+	var tests []*models.Test
+	if err := dbRef.
+		Where("course_item_id = ?", courseItemID).
+		Where("term_id = ?", termID).
+		Where("\"group\" IN ?", groups).
+		Preload("Questions").
+		Preload("Questions.Answers").
+		Order("id ASC").
+		Find(&tests).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to fetch test variants",
+			Details: err.Error(),
+		}
+	}
+
+	testsByGroup := make(map[string]*models.Test)
+	for _, test := range tests {
+		testsByGroup[test.Group] = test
+	}
+
+	testsByUser := make(map[uint]*models.Test)
+	for _, termSeat := range termSeats {
+		if test, ok := testsByGroup[termSeat.Variant]; ok {
+			testsByUser[termSeat.UserID] = test
+		}
+	}
+
+	return testsByUser, nil
+}
+
 func GenerateTest(
 	transaction *gorm.DB,
 	template *models.Template,
@@ -417,6 +483,8 @@ func GenerateTest(
 				Weight:                tb.Weight,
 				WrongAnswerPercentage: tb.WrongAnswerPercentage,
 				AllowEmptyAnswers:     tb.AllowEmptyAnswers,
+				QuestionTimeLimit:     tb.QuestionTimeLimit,
+				ScoringStrategy:       tb.ScoringStrategy,
 			}
 		}
 
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type GradingListResponse struct {
+	Items []dtos.GradingQuestionDTO `json:"items"`
+}
+
+// @Summary Lists open questions of term waiting for grading
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Success 200 {object} GradingListResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/grading [get]
+func GradingList(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	items := make([]dtos.GradingQuestionDTO, 0)
+	if err := helpers.GradingTermQuery(initializers.DB.Table("test_instance_questions"), courseItem.ID, params.TermID).
+		Joins("JOIN questions ON questions.id = test_questions.question_id").
+		Where("questions.question_format = ?", enums.QuestionFormatOpen).
+		Select("test_questions.question_id AS question_id, questions.title AS title, COUNT(*) AS total, " +
+			"SUM(CASE WHEN test_instance_questions.text_answer_reviewed_by_id IS NOT NULL THEN 1 ELSE 0 END) AS graded").
+		Group("test_questions.question_id, questions.title").
+		Order("questions.title").
+		Scan(&items).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load questions for grading",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, GradingListResponse{
+		Items: items,
+	})
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type GradingQueueResponse struct {
+	QuestionID uint                       `json:"questionId"`
+	Title      string                     `json:"title"`
+	Content    *models.TipTapContent      `json:"content"`
+	Blind      bool                       `json:"blind"`
+	Items      []dtos.GradingQueueItemDTO `json:"items"`
+	Progress   []dtos.GraderProgressDTO   `json:"progress"`
+}
+
+// @Summary Lists answers of one open question across term for grading
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param questionId path int true "ID of the graded question"
+// @Param onlyMine query bool false "Return only answers assigned to current user"
+// @Success 200 {object} GradingQueueResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Question not found"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/grading/{questionId} [get]
+func GradingQueue(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+			QuestionID   uint `uri:"questionId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, true, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	var question models.Question
+	if err := initializers.DB.
+		Select("id", "title", "content").
+		Where("question_format = ?", enums.QuestionFormatOpen).
+		First(&question, params.QuestionID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Question not found",
+		}
+	}
+
+	var items []*models.TestInstanceQuestion
+	if err := helpers.GradingQueueQuery(initializers.DB.Model(&models.TestInstanceQuestion{}), courseItem.ID, params.TermID, question.ID).
+		Preload("TestInstance.Participant").
+		Preload("AssignedGrader").
+		Order("test_instance_questions.id").
+		Find(&items).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load grading queue",
+			Details: err.Error(),
+		}
+	}
+
+	blind := courseItem.TestDetail != nil && courseItem.TestDetail.BlindGrading
+	onlyMine := c.Query("onlyMine") != ""
+
+	response := GradingQueueResponse{
+		QuestionID: question.ID,
+		Title:      question.Title,
+		Content:    question.Content,
+		Blind:      blind,
+		Items:      make([]dtos.GradingQueueItemDTO, 0, len(items)),
+		Progress:   dtos.GradingProgress(items),
+	}
+	for _, item := range items {
+		if onlyMine && (item.AssignedGraderID == nil || *item.AssignedGraderID != userData.ID) {
+			continue
+		}
+		response.Items = append(response.Items, dtos.GradingQueueItemDTO{}.From(item, blind))
+	}
+
+	c.JSON(200, response)
+
+	return nil
+}
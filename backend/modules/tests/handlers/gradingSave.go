@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	"elogika.vsb.cz/backend/services"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type GradingSaveRequest struct {
+	TextAnswerPercentage float64                  `json:"textAnswerPercentage"`
+	Feedback             *models.TipTapContent    `json:"feedback"`
+	RubricSelections     []models.RubricSelection `json:"rubricSelections"` // When question has rubric, percentage is computed from selections
+	NotifyFeedback       bool                     `json:"notifyFeedback"`   // Notify student when new feedback was added
+}
+
+type GradingSaveResponse struct {
+	Item dtos.GradingQueueItemDTO `json:"item"`
+}
+
+// @Summary Grades one answer from grading queue
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param questionId path int true "ID of the graded question"
+// @Param instanceQuestionId path int true "ID of the graded answer"
+// @Param body body GradingSaveRequest true "Grade of the answer"
+// @Success 200 {object} GradingSaveResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Answer not found"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/grading/{questionId}/{instanceQuestionId} [put]
+func GradingSave(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID           uint `uri:"courseId" binding:"required"`
+			CourseItemID       uint `uri:"courseItemId" binding:"required"`
+			TermID             uint `uri:"termId" binding:"required"`
+			QuestionID         uint `uri:"questionId" binding:"required"`
+			InstanceQuestionID uint `uri:"instanceQuestionId" binding:"required"`
+		},
+		GradingSaveRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, true, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	if reqData.TextAnswerPercentage < 0 || reqData.TextAnswerPercentage > 100 {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Percentage must be between 0 and 100",
+			FormErrors: common.ErrorObject{
+				"textAnswerPercentage": "Percentage must be between 0 and 100",
+			},
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	var item models.TestInstanceQuestion
+	if err := helpers.GradingQueueQuery(transaction.Model(&models.TestInstanceQuestion{}), courseItem.ID, params.TermID, params.QuestionID).
+		Preload("TestInstance.Participant").
+		Preload("TestQuestion.Question", initializers.DB.Unscoped()).
+		Where("test_instance_questions.id = ?", params.InstanceQuestionID).
+		First(&item).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Answer not found",
+		}
+	}
+
+	// Answers assigned to another grader can be overridden only by admin or garant
+	if item.AssignedGraderID != nil && *item.AssignedGraderID != userData.ID && userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Answer is assigned to another grader",
+		}
+	}
+
+	newFeedback := !item.Feedback.Equal(reqData.Feedback) && reqData.Feedback.HasText()
+
+	item.TextAnswerReviewedByID = &userData.ID
+	item.TextAnswerPercentage = reqData.TextAnswerPercentage
+	item.Feedback = reqData.Feedback
+	if reqData.RubricSelections != nil {
+		if err := helpers.ApplyRubricSelections(transaction, &item, item.TestQuestion.Question.RubricID, reqData.RubricSelections); err != nil {
+			transaction.Rollback()
+			return err
+		}
+	}
+	// Struct update, so json serializers of feedback and selections are applied
+	if err := transaction.
+		Model(&item).
+		Select("text_answer_reviewed_by_id", "text_answer_percentage", "feedback", "rubric_selections").
+		Updates(&item).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save grade",
+			Details: err.Error(),
+		}
+	}
+
+	if err := EvaluateTestInstance(transaction, item.TestInstanceID, &userData, false); err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to evaluate test",
+			Details: err,
+		}
+	}
+
+	rootCoureItem := courseItem.ID
+	if courseItem.ParentID != nil {
+		rootCoureItem = *courseItem.ParentID
+	}
+	if err := services_course_item.UpdateSelectedResults(transaction, params.CourseID, rootCoureItem, item.TestInstance.ParticipantID); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if newFeedback && reqData.NotifyFeedback {
+		if err := services.NewNotificationService().QueueFeedbackNotification(transaction, item.TestInstance.ParticipantID, courseItem.Name); err != nil {
+			transaction.Rollback()
+			return err
+		}
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, GradingSaveResponse{
+		Item: dtos.GradingQueueItemDTO{}.From(&item, courseItem.TestDetail != nil && courseItem.TestDetail.BlindGrading),
+	})
+
+	return nil
+}
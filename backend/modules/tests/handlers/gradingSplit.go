@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type GradingSplitRequest struct {
+	GraderIDs []uint `json:"graderIds" binding:"required"`
+	Reassign  bool   `json:"reassign"` // Redistribute also answers already assigned to someone
+}
+
+type GradingSplitResponse struct {
+	Progress []dtos.GraderProgressDTO `json:"progress"`
+}
+
+// @Summary Splits ungraded answers of question between graders
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param questionId path int true "ID of the graded question"
+// @Param body body GradingSplitRequest true "Graders to split queue between"
+// @Success 200 {object} GradingSplitResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/grading/{questionId}/split [post]
+func GradingSplit(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+			QuestionID   uint `uri:"questionId" binding:"required"`
+		},
+		GradingSplitRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	graders, err := helpers.ValidateGraders(initializers.DB, params.CourseID, reqData.GraderIDs)
+	if err != nil {
+		return err
+	}
+
+	transaction := initializers.DB.Begin()
+
+	query := helpers.GradingQueueQuery(transaction.Model(&models.TestInstanceQuestion{}), courseItem.ID, params.TermID, params.QuestionID).
+		Where("test_instance_questions.text_answer_reviewed_by_id IS NULL")
+	if !reqData.Reassign {
+		query = query.Where("test_instance_questions.assigned_grader_id IS NULL")
+	}
+	var itemIDs []uint
+	if err := query.Order("test_instance_questions.id").Pluck("test_instance_questions.id", &itemIDs).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load grading queue",
+			Details: err.Error(),
+		}
+	}
+
+	// Round robin keeps shares of graders even
+	assignments := make(map[uint][]uint)
+	for i, itemID := range itemIDs {
+		graderID := graders[i%len(graders)]
+		assignments[graderID] = append(assignments[graderID], itemID)
+	}
+	for graderID, ids := range assignments {
+		if err := transaction.
+			Model(&models.TestInstanceQuestion{}).
+			Where("id IN ?", ids).
+			Update("assigned_grader_id", graderID).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to assign graders",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	var items []*models.TestInstanceQuestion
+	if err := helpers.GradingQueueQuery(transaction.Model(&models.TestInstanceQuestion{}), courseItem.ID, params.TermID, params.QuestionID).
+		Preload("AssignedGrader").
+		Find(&items).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load grading queue",
+			Details: err.Error(),
+		}
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, GradingSplitResponse{
+		Progress: dtos.GradingProgress(items),
+	})
+
+	return nil
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"elogika.vsb.cz/backend/initializers"
@@ -95,8 +96,9 @@ func TestInstanceFinish(c *gin.Context, userData authdtos.LoggedUserDTO, userRol
 
 	if courseItem.TestDetail.IPRanges != "" {
 		if !utils.IsIPAllowed(courseItem.TestDetail.IPRanges, c.ClientIP()) {
+			transaction.Rollback()
 			if err := initializers.DB.Create(&models.TestInstanceEvent{
-				TestInstanceID: params.InstanceID,
+				TestInstanceID: &params.InstanceID,
 				UserID:         userData.ID,
 				OccuredAt:      time.Time{},
 				ReceivedAt:     time.Time{},
@@ -116,6 +118,11 @@ func TestInstanceFinish(c *gin.Context, userData authdtos.LoggedUserDTO, userRol
 		}
 	}
 
+	if err := helpers.CheckSafeExamBrowser(c, courseItem, &testInstance, userData.ID); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
 	if testInstance.IsExpired(time.Now()) {
 		transaction.Rollback()
 		return &common.ErrorResponse{
@@ -124,8 +131,20 @@ func TestInstanceFinish(c *gin.Context, userData authdtos.LoggedUserDTO, userRol
 		}
 	}
 
-	testInstance.State = enums.TestInstanceStateFinished
+	navigationMode := courseItem.TestDetail.NavigationMode
+	unlockedQuestions := testInstance.UnlockedQuestions(navigationMode)
+
+	if err := helpers.TransitionTestInstanceState(transaction, &testInstance, enums.TestInstanceStateFinished); err != nil {
+		transaction.Rollback()
+		return err
+	}
 	testInstance.EndedAt = time.Now()
+
+	if err := helpers.CloseQuestions(transaction, &testInstance, courseItem.TestDetail, testInstance.EndedAt); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
 	events := make([]*models.TestInstanceEvent, 0)
 	for _, rd_q := range reqData.Questions {
 		ti_q := helpers.FindQuestion(&testInstance, rd_q.QuestionID)
@@ -137,6 +156,11 @@ func TestInstanceFinish(c *gin.Context, userData authdtos.LoggedUserDTO, userRol
 			}
 		}
 
+		// Answers of already passed questions were saved before and cannot be changed anymore
+		if !slices.Contains(unlockedQuestions, ti_q) || helpers.IsQuestionTimeExpired(ti_q, testInstance.EndedAt) {
+			continue
+		}
+
 		switch ti_q.TestQuestion.Question.QuestionFormat {
 		case enums.QuestionFormatOpen:
 			err = helpers.UpdateOpenQuestion(ti_q, &rd_q, transaction, userData.ID, false, &events)
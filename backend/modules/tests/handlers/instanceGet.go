@@ -10,6 +10,7 @@ import (
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
 	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
 	"elogika.vsb.cz/backend/repositories"
 	"elogika.vsb.cz/backend/utils"
 	"github.com/gin-gonic/gin"
@@ -57,11 +58,18 @@ func TestInstanceGet(c *gin.Context, userData authdtos.LoggedUserDTO, userRole e
 	if err != nil {
 		return err
 	}
+	if err := models.LoadTermReleases(initializers.DB, testInstance.Term); err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to fetch result releases",
+			Details: err.Error(),
+		}
+	}
 
 	if testInstance.State == enums.TestInstanceStateActive && testInstance.CourseItem.TestDetail.IPRanges != "" {
 		if !utils.IsIPAllowed(testInstance.CourseItem.TestDetail.IPRanges, c.ClientIP()) {
 			if err := initializers.DB.Create(&models.TestInstanceEvent{
-				TestInstanceID: params.InstanceID,
+				TestInstanceID: &params.InstanceID,
 				UserID:         userData.ID,
 				OccuredAt:      time.Time{},
 				ReceivedAt:     time.Time{},
@@ -81,6 +89,25 @@ func TestInstanceGet(c *gin.Context, userData authdtos.LoggedUserDTO, userRole e
 		}
 	}
 
+	if err := helpers.CheckSafeExamBrowser(c, testInstance.CourseItem, testInstance, userData.ID); err != nil {
+		return err
+	}
+
+	if testInstance.State == enums.TestInstanceStateActive {
+		transaction := initializers.DB.Begin()
+		if _, err := helpers.AdvanceExpiredQuestions(transaction, testInstance, testInstance.CourseItem.TestDetail, userData.ID, time.Now()); err != nil {
+			transaction.Rollback()
+			return err
+		}
+		if err := transaction.Commit().Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to commit changes",
+			}
+		}
+	}
+
 	c.JSON(200, TestInstanceGetResponse{
 		InstanceData: dtos.TestInstanceDTO{}.From(
 			testInstance,
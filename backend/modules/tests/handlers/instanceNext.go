@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type TestInstanceNextRequest struct {
+	CurrentQuestionID uint `json:"currentQuestionId" binding:"required"` // Question the student is leaving, protects against skipping on repeated requests
+}
+
+type TestInstanceNextResponse struct {
+	CurrentQuestionID uint `json:"currentQuestionId"`
+}
+
+// @Summary Moves to next question in sequential navigation mode
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param instanceId path int true "ID of the corresponding test instance"
+// @Param body body TestInstanceNextRequest true "Question the student is leaving"
+// @Success 200 {object} TestInstanceNextResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 409 {object} common.ErrorResponse "Question already switched or no more questions"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/tests/{instanceId}/next [put]
+func TestInstanceNext(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			InstanceID uint `uri:"instanceId" binding:"required"`
+		},
+		TestInstanceNextRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	if userRole != enums.CourseUserRoleStudent {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "User is not a student",
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	testRepo := repositories.NewTestRepository()
+	testInstance, err := testRepo.GetTestInstanceByID(transaction, params.InstanceID, userData.ID, nil, true, false, nil, &userData.ID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if testInstance.State != enums.TestInstanceStateActive {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Instance is not active",
+		}
+	}
+
+	if err := helpers.CheckSafeExamBrowser(c, testInstance.CourseItem, testInstance, userData.ID); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if testInstance.IsExpired(time.Now()) {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Time expired",
+		}
+	}
+
+	navigationMode := testInstance.CourseItem.TestDetail.NavigationMode
+	if !navigationMode.IsSequential() {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    400,
+			Message: "Test does not use sequential navigation",
+		}
+	}
+
+	if testInstance.CurrentQuestionID == nil || *testInstance.CurrentQuestionID != reqData.CurrentQuestionID {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Question was already switched",
+		}
+	}
+
+	nextQuestion, err := helpers.SwitchQuestion(transaction, testInstance, testInstance.CourseItem.TestDetail, userData.ID, time.Now())
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+		}
+	}
+
+	c.JSON(200, TestInstanceNextResponse{
+		CurrentQuestionID: nextQuestion.ID,
+	})
+
+	return nil
+}
@@ -13,8 +13,9 @@ import (
 )
 
 type TestInstancePrepareRequest struct {
-	TermID       uint `json:"termId" uri:"termId" binding:"required"`             // TODO check that user has permission for this term
-	CourseItemID uint `json:"courseItemId" uri:"courseItemId" binding:"required"` // TODO check that user has permission for this term
+	TermID       uint   `json:"termId" uri:"termId" binding:"required"`             // TODO check that user has permission for this term
+	CourseItemID uint   `json:"courseItemId" uri:"courseItemId" binding:"required"` // TODO check that user has permission for this term
+	AccessCode   string `json:"accessCode"`                                         // Required only if term requires access code
 }
 
 type TestInstancePrepareResponse struct {
@@ -74,6 +75,11 @@ func TestInstancePrepare(c *gin.Context, userData authdtos.LoggedUserDTO, userRo
 		}
 	}
 
+	if err := helpers.CheckAccessCode(&term, nil, userData.ID, reqData.AccessCode, c.ClientIP()); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
 	courseItemQuery := `
 		WITH course_tree AS (
 			-- start with the course_item linked to the term
@@ -88,8 +88,9 @@ func TestInstanceSave(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 
 	if courseItem.TestDetail.IPRanges != "" {
 		if !utils.IsIPAllowed(courseItem.TestDetail.IPRanges, c.ClientIP()) {
+			transaction.Rollback()
 			if err := initializers.DB.Create(&models.TestInstanceEvent{
-				TestInstanceID: params.InstanceID,
+				TestInstanceID: &params.InstanceID,
 				UserID:         userData.ID,
 				OccuredAt:      time.Time{},
 				ReceivedAt:     time.Time{},
@@ -109,6 +110,65 @@ func TestInstanceSave(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 		}
 	}
 
+	if err := helpers.CheckSafeExamBrowser(c, courseItem, testInstanceQuestion.TestInstance, userData.ID); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	navigationMode := courseItem.TestDetail.NavigationMode
+	if navigationMode.IsSequential() {
+		var instanceQuestions []*models.TestInstanceQuestion
+		if err := transaction.
+			Joins("TestQuestion", initializers.DB.Unscoped()).
+			Joins("TestQuestion.Question", initializers.DB.Unscoped()).
+			Where("test_instance_id = ?", testInstanceQuestion.TestInstanceID).
+			Find(&instanceQuestions).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to fetch test instance questions",
+				Details: err.Error(),
+			}
+		}
+		testInstanceQuestion.TestInstance.Questions = instanceQuestions
+
+		// Switch to next question is kept even though the late save itself is rejected
+		advanced, err := helpers.AdvanceExpiredQuestions(transaction, testInstanceQuestion.TestInstance, courseItem.TestDetail, userData.ID, time.Now())
+		if err != nil {
+			transaction.Rollback()
+			return err
+		}
+		if advanced {
+			if err := transaction.Commit().Error; err != nil {
+				transaction.Rollback()
+				return &common.ErrorResponse{
+					Code:    500,
+					Message: "Failed to commit changes",
+				}
+			}
+			return &common.ErrorResponse{
+				Code:    409,
+				Message: "Question time expired",
+			}
+		}
+
+		if !testInstanceQuestion.TestInstance.IsQuestionUnlocked(testInstanceQuestion.ID, navigationMode) {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    409,
+				Message: "Question is locked",
+			}
+		}
+	}
+
+	if helpers.IsQuestionTimeExpired(&testInstanceQuestion, time.Now()) {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Question time expired",
+		}
+	}
+
 	if testInstanceQuestion.TestInstance.IsExpired(time.Now()) {
 		transaction.Rollback()
 		return &common.ErrorResponse{
@@ -9,11 +9,16 @@ import (
 	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
 	"elogika.vsb.cz/backend/repositories"
 	"elogika.vsb.cz/backend/utils"
 	"github.com/gin-gonic/gin"
 )
 
+type TestInstanceStartRequest struct {
+	AccessCode string `json:"accessCode"` // Required only if term requires access code
+}
+
 type TestInstanceStartResponse struct {
 	InstanceID uint `json:"instanceId"`
 }
@@ -24,19 +29,19 @@ type TestInstanceStartResponse struct {
 // @Accept  json
 // @Produce  json
 // @Param courseId path int true "ID of the corresponding course"
-// @Param body body TestGeneratorRequest true "Ability to filter results"
-// @Success 200 {object} TestGeneratorResponse "Successful operation"
+// @Param body body TestInstanceStartRequest true "Access code for the term"
+// @Success 200 {object} TestInstanceStartResponse "Successful operation"
 // @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
 // @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
 // @Failure 500 {object} common.ErrorResponse "Fatal failure"
 // @Router /api/v2/courses/{courseId}/tests/instance/start [post]
 func TestInstanceStart(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
 	// Load request data
-	err, params, _ := utils.GetRequestData[
+	err, params, reqData := utils.GetRequestData[
 		struct {
 			InstanceID uint `uri:"instanceId" binding:"required"`
 		},
-		any,
+		TestInstanceStartRequest,
 	](c)
 	if err != nil {
 		return err
@@ -55,6 +60,12 @@ func TestInstanceStart(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 
 	transaction := initializers.DB.Begin()
 
+	// Starts of one participant are serialized, so check for running instances below cannot race
+	if err := helpers.LockParticipantInstances(transaction, userData.ID); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
 	testRepo := repositories.NewTestRepository()
 	testInstance, err := testRepo.GetTestInstanceByID(transaction, params.InstanceID, userData.ID, nil, true, true, nil, &userData.ID)
 	if err != nil {
@@ -64,8 +75,9 @@ func TestInstanceStart(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 
 	if testInstance.CourseItem.TestDetail.IPRanges != "" {
 		if !utils.IsIPAllowed(testInstance.CourseItem.TestDetail.IPRanges, c.ClientIP()) {
+			transaction.Rollback()
 			if err := initializers.DB.Create(&models.TestInstanceEvent{
-				TestInstanceID: params.InstanceID,
+				TestInstanceID: &params.InstanceID,
 				UserID:         userData.ID,
 				OccuredAt:      time.Time{},
 				ReceivedAt:     time.Time{},
@@ -85,6 +97,16 @@ func TestInstanceStart(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 		}
 	}
 
+	if err := helpers.CheckSafeExamBrowser(c, testInstance.CourseItem, testInstance, userData.ID); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := helpers.CheckAccessCode(testInstance.Term, &testInstance.ID, userData.ID, reqData.AccessCode, c.ClientIP()); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
 	if testInstance.Form != enums.TestInstanceFormOnline {
 		transaction.Rollback()
 		return &common.ErrorResponse{
@@ -101,19 +123,6 @@ func TestInstanceStart(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 		}
 	}
 
-	testInstance.State = enums.TestInstanceStateActive
-	testInstance.StartedAt = timeFreeze
-
-	endsAt1 := timeFreeze.Add(time.Minute * time.Duration(testInstance.CourseItem.TestDetail.TimeLimit))
-	endsAt2 := testInstance.Term.ActiveTo
-
-	// TODO CONSULT Jak určovat čas konce ? takto ?
-	if endsAt1.Before(endsAt2) {
-		testInstance.EndsAt = endsAt1
-	} else {
-		testInstance.EndsAt = endsAt2
-	}
-
 	var runningUserInstances []*models.TestInstance
 	if err := transaction.
 		Where("state = ?", enums.TestInstanceStateActive).
@@ -127,16 +136,47 @@ func TestInstanceStart(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 		}
 	}
 
-	// Check that no test is actively running
-	// TODO maybe lock table ?
+	// Check that no test is actively running, unique index on active participant instances is the last resort
 	if len(runningUserInstances) != 0 {
 		transaction.Rollback()
 		return &common.ErrorResponse{
-			Code:    500,
+			Code:    409,
 			Message: "Another test instance is already running",
 		}
 	}
 
+	if err := helpers.TransitionTestInstanceState(transaction, testInstance, enums.TestInstanceStateActive); err != nil {
+		transaction.Rollback()
+		return err
+	}
+	testInstance.StartedAt = timeFreeze
+
+	endsAt1 := timeFreeze.Add(time.Minute * time.Duration(testInstance.CourseItem.TestDetail.TimeLimit))
+	endsAt2 := testInstance.Term.ActiveTo
+
+	// TODO CONSULT Jak určovat čas konce ? takto ?
+	if endsAt1.Before(endsAt2) {
+		testInstance.EndsAt = endsAt1
+	} else {
+		testInstance.EndsAt = endsAt2
+	}
+
+	events := make([]*models.TestInstanceEvent, 0)
+	navigationMode := testInstance.CourseItem.TestDetail.NavigationMode
+	if navigationMode.IsSequential() {
+		firstQuestion := testInstance.NextQuestion(navigationMode)
+		if firstQuestion != nil {
+			testInstance.CurrentQuestionID = &firstQuestion.ID
+			events = append(events, helpers.QuestionSwitchedEvent(testInstance, nil, firstQuestion, userData.ID))
+		}
+	}
+
+	// In free mode all questions are unlocked and opened right away
+	if err := helpers.OpenQuestions(transaction, testInstance, testInstance.CourseItem.TestDetail, timeFreeze); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
 	if err := transaction.Save(&testInstance).Error; err != nil {
 		transaction.Rollback()
 		return &common.ErrorResponse{
@@ -146,6 +186,16 @@ func TestInstanceStart(c *gin.Context, userData authdtos.LoggedUserDTO, userRole
 		}
 	}
 
+	if len(events) != 0 {
+		if err := transaction.Save(&events).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to save events",
+			}
+		}
+	}
+
 	if err := transaction.Commit().Error; err != nil {
 		transaction.Rollback()
 		return &common.ErrorResponse{
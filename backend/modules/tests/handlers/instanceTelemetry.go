@@ -9,6 +9,7 @@ import (
 	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
 	"elogika.vsb.cz/backend/utils"
 	"github.com/gin-gonic/gin"
 )
@@ -62,20 +63,25 @@ func TestInstanceTelemetry(c *gin.Context, userData authdtos.LoggedUserDTO, user
 
 	var testInstance models.TestInstance
 	if err := initializers.DB.
-		Select("id").
-		Where("participant_id = ?", userData.ID).
-		Find(&testInstance, params.InstanceID).Error; err != nil {
+		InnerJoins("CourseItem").
+		InnerJoins("CourseItem.TestDetail").
+		Where("test_instances.participant_id = ?", userData.ID).
+		First(&testInstance, params.InstanceID).Error; err != nil {
 		return &common.ErrorResponse{
 			Code:    404,
 			Message: "Failed to find instance",
 		}
 	}
 
+	if err := helpers.CheckSafeExamBrowser(c, testInstance.CourseItem, &testInstance, userData.ID); err != nil {
+		return err
+	}
+
 	events := make([]models.TestInstanceEvent, len(reqData.Events))
 
 	for i, event := range reqData.Events {
 		events[i] = models.TestInstanceEvent{
-			TestInstanceID: params.InstanceID,
+			TestInstanceID: &params.InstanceID,
 			UserID:         userData.ID,
 			OccuredAt:      event.OccuredAt,
 			ReceivedAt:     time.Now(),
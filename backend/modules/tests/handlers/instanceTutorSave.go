@@ -13,6 +13,7 @@ import (
 	"elogika.vsb.cz/backend/modules/tests/dtos"
 	"elogika.vsb.cz/backend/modules/tests/helpers"
 	"elogika.vsb.cz/backend/repositories"
+	"elogika.vsb.cz/backend/services"
 	services_course_item "elogika.vsb.cz/backend/services/courseItem"
 	"elogika.vsb.cz/backend/utils"
 	"github.com/gin-gonic/gin"
@@ -22,6 +23,7 @@ type TestInstanceTutorSaveRequest struct {
 	Questions         []helpers.TestInstanceQuestion `json:"questions" binding:"required"`
 	BonusPoints       float64                        `json:"bonusPoints"`
 	BonusPointsReason string                         `json:"bonusPointsReason"`
+	NotifyFeedback    bool                           `json:"notifyFeedback"` // Notify student when new feedback was added
 }
 
 type TestInstanceTutorSaveResponse struct {
@@ -94,7 +96,7 @@ func TestInstanceTutorSave(c *gin.Context, userData authdtos.LoggedUserDTO, user
 		rawData, _ := json.Marshal(data)
 
 		events = append(events, &models.TestInstanceEvent{
-			TestInstanceID: testInstance.ID,
+			TestInstanceID: &testInstance.ID,
 			UserID:         userData.ID,
 			OccuredAt:      time.Now(),
 			ReceivedAt:     time.Now(),
@@ -110,12 +112,17 @@ func TestInstanceTutorSave(c *gin.Context, userData authdtos.LoggedUserDTO, user
 		testInstance.StartedAt = time.Now()
 		testInstance.EndsAt = time.Now()
 		testInstance.EndedAt = time.Now()
-		testInstance.State = enums.TestInstanceStateFinished
 	case enums.TestInstanceStateActive:
 		testInstance.EndedAt = time.Now()
-		testInstance.State = enums.TestInstanceStateFinished
+	}
+	if testInstance.State.CanTransitionTo(enums.TestInstanceStateFinished) {
+		if err := helpers.TransitionTestInstanceState(transaction, testInstance, enums.TestInstanceStateFinished); err != nil {
+			transaction.Rollback()
+			return err
+		}
 	}
 
+	newFeedback := false
 	for _, rd_q := range reqData.Questions {
 		ti_q := helpers.FindQuestion(testInstance, rd_q.QuestionID)
 		if ti_q == nil {
@@ -126,6 +133,13 @@ func TestInstanceTutorSave(c *gin.Context, userData authdtos.LoggedUserDTO, user
 			}
 		}
 
+		if changed, err := helpers.UpdateQuestionFeedback(transaction, ti_q, rd_q.Feedback, userData.ID); err != nil {
+			transaction.Rollback()
+			return err
+		} else if changed {
+			newFeedback = true
+		}
+
 		switch ti_q.TestQuestion.Question.QuestionFormat {
 		case enums.QuestionFormatOpen:
 			err = helpers.UpdateOpenQuestion(ti_q, &rd_q, transaction, userData.ID, true, &events)
@@ -133,7 +147,7 @@ func TestInstanceTutorSave(c *gin.Context, userData authdtos.LoggedUserDTO, user
 				transaction.Rollback()
 				return err
 			}
-			if rd_q.TextAnswerReviewed != nil {
+			if rd_q.TextAnswerReviewed != nil && rd_q.RubricSelections == nil {
 				ti_q.TextAnswerReviewedByID = &userData.ID
 				ti_q.TextAnswerPercentage = *rd_q.TextAnswerPercentage
 			}
@@ -192,6 +206,13 @@ func TestInstanceTutorSave(c *gin.Context, userData authdtos.LoggedUserDTO, user
 		return err
 	}
 
+	if newFeedback && reqData.NotifyFeedback {
+		if err := services.NewNotificationService().QueueFeedbackNotification(transaction, testInstance.ParticipantID, courseItem.Name); err != nil {
+			transaction.Rollback()
+			return err
+		}
+	}
+
 	if err := transaction.Commit().Error; err != nil {
 		transaction.Rollback()
 		return &common.ErrorResponse{
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type QuestionInvalidationDeleteResponse struct {
+	JobID uint `json:"jobId"` // Reevaluation job of affected instances
+}
+
+// @Summary Removes question invalidation and reevaluates affected instances
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param invalidationId path int true "ID of the invalidation"
+// @Success 200 {object} QuestionInvalidationDeleteResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/invalidations/{invalidationId} [delete]
+func QuestionInvalidationDelete(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID       uint `uri:"courseId" binding:"required"`
+			CourseItemID   uint `uri:"courseItemId" binding:"required"`
+			TermID         uint `uri:"termId" binding:"required"`
+			InvalidationID uint `uri:"invalidationId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	var invalidation models.QuestionInvalidation
+	if err := transaction.
+		Where("course_item_id = ?", courseItem.ID).
+		Where("term_id = ?", params.TermID).
+		First(&invalidation, params.InvalidationID).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Failed to load invalidation",
+			Details: err.Error(),
+		}
+	}
+
+	if err := transaction.Delete(&invalidation).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to delete invalidation",
+			Details: err.Error(),
+		}
+	}
+
+	job, err := CreateReevaluationJob(transaction, params.CourseID, courseItem.ID, invalidation.TermID, invalidation.TestID, userData.ID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+		}
+	}
+
+	go RunReevaluationJob(job.ID)
+
+	c.JSON(200, QuestionInvalidationDeleteResponse{
+		JobID: job.ID,
+	})
+
+	return nil
+}
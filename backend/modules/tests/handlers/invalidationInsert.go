@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"slices"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type QuestionInvalidationInsertRequest struct {
+	TestID     *uint                              `json:"testId"`                        // When empty, all tests of the term are affected
+	QuestionID uint                               `json:"questionId" binding:"required"` // Bank question, not the generated one
+	AnswerID   *uint                              `json:"answerId"`                      // Required for ACCEPT_ANSWER mode, other modes apply to whole question
+	Mode       enums.QuestionInvalidationModeEnum `json:"mode" binding:"required"`
+	Reason     string                             `json:"reason"`
+}
+
+type QuestionInvalidationInsertResponse struct {
+	Data  dtos.QuestionInvalidationDTO `json:"data"`
+	JobID uint                         `json:"jobId"` // Reevaluation job of affected instances
+}
+
+// @Summary Invalidates question or answer and reevaluates affected instances
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param body body QuestionInvalidationInsertRequest true "Invalidation data"
+// @Success 200 {object} QuestionInvalidationInsertResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/invalidations [post]
+func QuestionInvalidationInsert(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		QuestionInvalidationInsertRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	if !slices.Contains(enums.QuestionInvalidationModeEnumAll, reqData.Mode) {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Unknown invalidation mode",
+			FormErrors: common.ErrorObject{
+				"mode": "Unknown invalidation mode",
+			},
+		}
+	}
+	if reqData.Mode == enums.QuestionInvalidationModeAcceptAnswer && reqData.AnswerID == nil {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Answer must be selected to be accepted",
+			FormErrors: common.ErrorObject{
+				"answerId": "Answer must be selected to be accepted",
+			},
+		}
+	}
+	// Dropping or crediting single answer is not supported, scoring strategies work with whole questions
+	if reqData.Mode != enums.QuestionInvalidationModeAcceptAnswer && reqData.AnswerID != nil {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Only accepted answer can be selected, other modes apply to whole question",
+			FormErrors: common.ErrorObject{
+				"answerId": "Only accepted answer can be selected, other modes apply to whole question",
+			},
+		}
+	}
+
+	// Tests inside group are written in terms of the group
+	courseItemIDs := []uint{courseItem.ID}
+	if courseItem.ParentID != nil {
+		courseItemIDs = append(courseItemIDs, *courseItem.ParentID)
+	}
+
+	var term models.Term
+	if err := initializers.DB.
+		Where("course_item_id IN ?", courseItemIDs).
+		First(&term, params.TermID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Failed to load term",
+			Details: err.Error(),
+		}
+	}
+
+	// Question (and answer) must be part of some test generated for the term
+	query := initializers.DB.
+		Table("test_questions").
+		Joins("JOIN tests ON tests.id = test_questions.test_id").
+		Where("tests.term_id = ?", term.ID).
+		Where("test_questions.question_id = ?", reqData.QuestionID)
+	if reqData.TestID != nil {
+		query = query.Where("tests.id = ?", *reqData.TestID)
+	}
+	if reqData.AnswerID != nil {
+		query = query.
+			Joins("JOIN test_question_answers ON test_question_answers.test_question_id = test_questions.id").
+			Where("test_question_answers.answer_id = ?", *reqData.AnswerID)
+	}
+	var usages int64
+	if err := query.Count(&usages).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to check question usage",
+			Details: err.Error(),
+		}
+	}
+	if usages == 0 {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Question is not used in tests of this term",
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	invalidation := models.QuestionInvalidation{
+		CreatedByID:  userData.ID,
+		CourseItemID: courseItem.ID,
+		TermID:       term.ID,
+		TestID:       reqData.TestID,
+		QuestionID:   reqData.QuestionID,
+		AnswerID:     reqData.AnswerID,
+		Mode:         reqData.Mode,
+		Reason:       reqData.Reason,
+	}
+	if err := transaction.Create(&invalidation).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to insert invalidation",
+			Details: err.Error(),
+		}
+	}
+
+	job, err := CreateReevaluationJob(transaction, params.CourseID, courseItem.ID, term.ID, reqData.TestID, userData.ID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+		}
+	}
+
+	go RunReevaluationJob(job.ID)
+
+	c.JSON(200, QuestionInvalidationInsertResponse{
+		Data:  dtos.QuestionInvalidationDTO{}.From(&invalidation),
+		JobID: job.ID,
+	})
+
+	return nil
+}
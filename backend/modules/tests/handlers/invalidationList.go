@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type QuestionInvalidationListResponse struct {
+	Items []dtos.QuestionInvalidationDTO `json:"items"`
+}
+
+// @Summary Lists question invalidations of term
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Success 200 {object} QuestionInvalidationListResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/invalidations [get]
+func QuestionInvalidationList(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, false, nil)
+	if err != nil {
+		return err
+	}
+
+	var invalidations []*models.QuestionInvalidation
+	if err := initializers.DB.
+		InnerJoins("Question").
+		InnerJoins("CreatedBy").
+		Where("question_invalidations.course_item_id = ?", courseItem.ID).
+		Where("question_invalidations.term_id = ?", params.TermID).
+		Order("question_invalidations.created_at DESC").
+		Find(&invalidations).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load invalidations",
+			Details: err.Error(),
+		}
+	}
+
+	response := QuestionInvalidationListResponse{
+		Items: make([]dtos.QuestionInvalidationDTO, len(invalidations)),
+	}
+	for i, invalidation := range invalidations {
+		response.Items[i] = dtos.QuestionInvalidationDTO{}.From(invalidation)
+	}
+
+	c.JSON(200, response)
+
+	return nil
+}
@@ -98,6 +98,8 @@ func ListAvailable(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enu
 						ActiveFrom:     t.ActiveFrom,
 						ActiveTo:       t.ActiveTo,
 						CanStart:       triesLeft != 0,
+
+						RequiresAccessCode: t.RequiresAccessCode,
 					})
 				}
 			}
@@ -116,6 +118,8 @@ func ListAvailable(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enu
 				ActiveFrom:     t.ActiveFrom,
 				ActiveTo:       t.ActiveTo,
 				CanStart:       triesLeft != 0,
+
+				RequiresAccessCode: t.RequiresAccessCode,
 			})
 		}
 	}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ModerationListResponse struct {
+	Percentage uint                 `json:"percentage"`
+	Threshold  float64              `json:"threshold"`
+	Items      []dtos.ModerationDTO `json:"items"`
+}
+
+// @Summary Lists moderation sample of term. Tutors see only instances assigned to them as second marker.
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Success 200 {object} ModerationListResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/moderation [get]
+func ModerationList(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, true, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable || courseItem.TestDetail == nil {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	isModerator := userRole == enums.CourseUserRoleAdmin || userRole == enums.CourseUserRoleGarant
+
+	query := initializers.DB.
+		Preload("TestInstance.Participant").
+		Preload("SecondGrader").
+		Preload("Marks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("moderation_marks.id")
+		}).
+		Preload("Marks.TestInstanceQuestion.TestQuestion.Question", initializers.DB.Unscoped()).
+		Where("course_item_id = ?", courseItem.ID).
+		Where("term_id = ?", params.TermID)
+	if !isModerator {
+		query = query.Where("second_grader_id = ?", userData.ID)
+	}
+
+	var moderations []*models.Moderation
+	if err := query.Order("id").Find(&moderations).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load moderations",
+			Details: err.Error(),
+		}
+	}
+
+	response := ModerationListResponse{
+		Percentage: courseItem.TestDetail.ModerationPercentage,
+		Threshold:  courseItem.TestDetail.ModerationThreshold,
+		Items:      make([]dtos.ModerationDTO, len(moderations)),
+	}
+	for i, moderation := range moderations {
+		// Second marking is blind to first marks
+		hideFirstMarks := !isModerator && moderation.Status == enums.ModerationStatusPending
+		response.Items[i] = dtos.ModerationDTO{}.From(moderation, courseItem.TestDetail.BlindGrading, hideFirstMarks)
+	}
+
+	c.JSON(200, response)
+
+	return nil
+}
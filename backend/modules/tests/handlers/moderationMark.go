@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ModerationMarkItem struct {
+	TestInstanceQuestionID uint    `json:"testInstanceQuestionId" binding:"required"`
+	Percentage             float64 `json:"percentage"`
+}
+
+type ModerationMarkRequest struct {
+	Marks []ModerationMarkItem `json:"marks" binding:"required"`
+}
+
+type ModerationMarkResponse struct {
+	Data dtos.ModerationDTO `json:"data"`
+}
+
+// @Summary Submits marks of second marker. Disagreements above threshold are flagged for moderator.
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param moderationId path int true "ID of the moderation"
+// @Param body body ModerationMarkRequest true "Marks of all answers of the instance"
+// @Success 200 {object} ModerationMarkResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Moderation not found"
+// @Failure 409 {object} common.ErrorResponse "Moderation is not waiting for second marks or first marking is not finished"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/moderation/{moderationId}/mark [put]
+func ModerationMarkSave(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+			ModerationID uint `uri:"moderationId" binding:"required"`
+		},
+		ModerationMarkRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, true, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable || courseItem.TestDetail == nil {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	moderation, err := loadModeration(transaction, courseItem.ID, params.TermID, params.ModerationID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+	if moderation.SecondGraderID != userData.ID {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Instance is assigned to another second marker",
+		}
+	}
+	if moderation.Status != enums.ModerationStatusPending {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Instance was already second-marked",
+		}
+	}
+
+	percentages, err := moderationPercentages(moderation, reqData.Marks)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	flagged := false
+	for _, mark := range moderation.Marks {
+		if mark.TestInstanceQuestion.TextAnswerReviewedByID == nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    409,
+				Message: "First marking is not finished",
+			}
+		}
+
+		second := percentages[mark.TestInstanceQuestionID]
+		mark.FirstPercentage = mark.TestInstanceQuestion.TextAnswerPercentage
+		mark.SecondPercentage = &second
+		mark.Flagged = helpers.IsModerationDisagreement(mark.FirstPercentage, second, courseItem.TestDetail.ModerationThreshold)
+		flagged = flagged || mark.Flagged
+
+		if err := transaction.
+			Model(mark).
+			Select("first_percentage", "second_percentage", "flagged").
+			Updates(mark).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to save marks",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	if flagged {
+		moderation.Status = enums.ModerationStatusFlagged
+		if err := transaction.Model(moderation).Update("status", moderation.Status).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to update moderation",
+				Details: err.Error(),
+			}
+		}
+	} else if err := completeModeration(transaction, params.CourseID, courseItem, moderation, &userData); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, ModerationMarkResponse{
+		Data: dtos.ModerationDTO{}.From(moderation, courseItem.TestDetail.BlindGrading, false),
+	})
+
+	return nil
+}
+
+func loadModeration(dbRef *gorm.DB, courseItemID uint, termID uint, moderationID uint) (*models.Moderation, *common.ErrorResponse) {
+	var moderation models.Moderation
+	if err := dbRef.
+		Preload("TestInstance.Participant").
+		Preload("SecondGrader").
+		Preload("Marks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("moderation_marks.id")
+		}).
+		Preload("Marks.TestInstanceQuestion.TestQuestion.Question", initializers.DB.Unscoped()).
+		Where("course_item_id = ?", courseItemID).
+		Where("term_id = ?", termID).
+		First(&moderation, moderationID).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    404,
+			Message: "Moderation not found",
+		}
+	}
+
+	return &moderation, nil
+}
+
+// moderationPercentages maps request marks by answer and checks that they belong to moderation
+func moderationPercentages(moderation *models.Moderation, marks []ModerationMarkItem) (map[uint]float64, *common.ErrorResponse) {
+	percentages := make(map[uint]float64)
+	for _, mark := range marks {
+		if mark.Percentage < 0 || mark.Percentage > 100 {
+			return nil, &common.ErrorResponse{
+				Code:    422,
+				Message: "Validation failed",
+				Details: "Percentage must be between 0 and 100",
+				FormErrors: common.ErrorObject{
+					"marks": "Percentage must be between 0 and 100",
+				},
+			}
+		}
+		percentages[mark.TestInstanceQuestionID] = mark.Percentage
+	}
+
+	for _, mark := range moderation.Marks {
+		if _, ok := percentages[mark.TestInstanceQuestionID]; !ok {
+			return nil, &common.ErrorResponse{
+				Code:    422,
+				Message: "Validation failed",
+				Details: "All answers of instance must be marked",
+				FormErrors: common.ErrorObject{
+					"marks": "All answers of instance must be marked",
+				},
+			}
+		}
+	}
+	if len(percentages) != len(moderation.Marks) {
+		return nil, &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Marks contain answers outside of moderated instance",
+			FormErrors: common.ErrorObject{
+				"marks": "Marks contain answers outside of moderated instance",
+			},
+		}
+	}
+
+	return percentages, nil
+}
+
+// completeModeration closes moderation and reevaluates instance, so its result can become final
+func completeModeration(transaction *gorm.DB, courseID uint, courseItem *models.CourseItem, moderation *models.Moderation, userData *authdtos.LoggedUserDTO) *common.ErrorResponse {
+	timeNow := time.Now()
+	moderation.Status = enums.ModerationStatusCompleted
+	moderation.CompletedAt = &timeNow
+	if err := transaction.
+		Model(moderation).
+		Select("status", "completed_at").
+		Updates(moderation).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to update moderation",
+			Details: err.Error(),
+		}
+	}
+
+	if err := EvaluateTestInstance(transaction, moderation.TestInstanceID, userData, false); err != nil {
+		return err
+	}
+
+	rootCoureItem := courseItem.ID
+	if courseItem.ParentID != nil {
+		rootCoureItem = *courseItem.ParentID
+	}
+	return services_course_item.UpdateSelectedResults(transaction, courseID, rootCoureItem, moderation.TestInstance.ParticipantID)
+}
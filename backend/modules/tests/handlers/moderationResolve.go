@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/dtos"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationResolveRequest struct {
+	Marks []ModerationMarkItem `json:"marks" binding:"required"` // Final percentages of all answers of the instance
+}
+
+type ModerationResolveResponse struct {
+	Data dtos.ModerationDTO `json:"data"`
+}
+
+// @Summary Resolves flagged moderation. Decided percentages replace first marks and result becomes final.
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param moderationId path int true "ID of the moderation"
+// @Param body body ModerationResolveRequest true "Decided marks"
+// @Success 200 {object} ModerationResolveResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 404 {object} common.ErrorResponse "Moderation not found"
+// @Failure 409 {object} common.ErrorResponse "Moderation is not flagged"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/moderation/{moderationId}/resolve [put]
+func ModerationResolve(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+			ModerationID uint `uri:"moderationId" binding:"required"`
+		},
+		ModerationResolveRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity, only garant moderates
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, true, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable || courseItem.TestDetail == nil {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	transaction := initializers.DB.Begin()
+
+	moderation, err := loadModeration(transaction, courseItem.ID, params.TermID, params.ModerationID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+	if moderation.Status != enums.ModerationStatusFlagged {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Moderation is not flagged",
+		}
+	}
+
+	percentages, err := moderationPercentages(moderation, reqData.Marks)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	for _, mark := range moderation.Marks {
+		resolved := percentages[mark.TestInstanceQuestionID]
+		mark.ResolvedByID = &userData.ID
+		mark.ResolvedPercentage = &resolved
+		if err := transaction.
+			Model(mark).
+			Select("resolved_by_id", "resolved_percentage").
+			Updates(mark).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to save marks",
+				Details: err.Error(),
+			}
+		}
+
+		mark.TestInstanceQuestion.TextAnswerPercentage = resolved
+		if err := transaction.
+			Model(&models.TestInstanceQuestion{}).
+			Where("id = ?", mark.TestInstanceQuestionID).
+			Update("text_answer_percentage", resolved).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to save answer percentage",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	if err := completeModeration(transaction, params.CourseID, courseItem, moderation, &userData); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	c.JSON(200, ModerationResolveResponse{
+		Data: dtos.ModerationDTO{}.From(moderation, courseItem.TestDetail.BlindGrading, false),
+	})
+
+	return nil
+}
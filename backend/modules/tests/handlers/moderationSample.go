@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"math"
+	"math/rand"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationSampleRequest struct {
+	GraderIDs []uint `json:"graderIds" binding:"required"` // Second markers, instance is never assigned to its first marker
+}
+
+type ModerationSampleResponse struct {
+	Sampled uint `json:"sampled"` // Newly sampled instances
+	Total   uint `json:"total"`   // All sampled instances of term
+	JobID   uint `json:"jobId"`   // Reevaluation job finalizing results of instances outside of sample
+}
+
+// @Summary Draws moderation sample of term and assigns it to second markers. Repeated call tops sample up to configured percentage.
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Param body body ModerationSampleRequest true "Second markers"
+// @Success 200 {object} ModerationSampleResponse "Successful operation"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 409 {object} common.ErrorResponse "Moderation is not enabled"
+// @Failure 422 {object} common.ErrorResponse "Data validation errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/moderation/sample [post]
+func ModerationSample(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, reqData := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		ModerationSampleRequest,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+	if userRole != enums.CourseUserRoleAdmin && userRole != enums.CourseUserRoleGarant {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+
+	courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+	courseItem, err := courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, true, nil)
+	if err != nil {
+		return err
+	}
+	if !courseItem.Editable {
+		return &common.ErrorResponse{
+			Code:    403,
+			Message: "Not enough permissions",
+		}
+	}
+	if courseItem.TestDetail == nil || courseItem.TestDetail.ModerationPercentage == 0 {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Moderation is not enabled",
+		}
+	}
+
+	graders, err := helpers.ValidateGraders(initializers.DB, params.CourseID, reqData.GraderIDs)
+	if err != nil {
+		return err
+	}
+
+	transaction := initializers.DB.Begin()
+
+	// Only instances with open questions can be double-marked
+	var instanceIDs []uint
+	if err := transaction.
+		Model(&models.TestInstance{}).
+		Where("course_item_id = ?", courseItem.ID).
+		Where("term_id = ?", params.TermID).
+		Where("state IN ?", []enums.TestInstanceStateEnum{enums.TestInstanceStateFinished, enums.TestInstanceStateExpired}).
+		Where(helpers.OpenAnswerInstanceCondition, enums.QuestionFormatOpen).
+		Pluck("id", &instanceIDs).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load instances",
+			Details: err.Error(),
+		}
+	}
+
+	var sampledIDs []uint
+	if err := transaction.
+		Model(&models.Moderation{}).
+		Where("course_item_id = ?", courseItem.ID).
+		Where("term_id = ?", params.TermID).
+		Pluck("test_instance_id", &sampledIDs).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load moderations",
+			Details: err.Error(),
+		}
+	}
+	sampled := make(map[uint]bool)
+	for _, id := range sampledIDs {
+		sampled[id] = true
+	}
+
+	candidates := make([]uint, 0)
+	for _, id := range instanceIDs {
+		if !sampled[id] {
+			candidates = append(candidates, id)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	target := int(math.Ceil(float64(len(instanceIDs)) * float64(courseItem.TestDetail.ModerationPercentage) / 100))
+	missing := max(target-len(sampledIDs), 0)
+	candidates = candidates[:min(missing, len(candidates))]
+
+	for i, instanceID := range candidates {
+		var questions []*models.TestInstanceQuestion
+		if err := helpers.GradingTermQuery(transaction.Model(&models.TestInstanceQuestion{}), courseItem.ID, params.TermID).
+			Joins("JOIN questions ON questions.id = test_questions.question_id").
+			Where("questions.question_format = ?", enums.QuestionFormatOpen).
+			Where("test_instance_questions.test_instance_id = ?", instanceID).
+			Find(&questions).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to load instance questions",
+				Details: err.Error(),
+			}
+		}
+
+		// Second marker must differ from anybody who marked or was assigned the answers
+		firstMarkers := make(map[uint]bool)
+		for _, question := range questions {
+			if question.TextAnswerReviewedByID != nil {
+				firstMarkers[*question.TextAnswerReviewedByID] = true
+			}
+			if question.AssignedGraderID != nil {
+				firstMarkers[*question.AssignedGraderID] = true
+			}
+		}
+		secondGraderID := uint(0)
+		for offset := range graders {
+			graderID := graders[(i+offset)%len(graders)]
+			if !firstMarkers[graderID] {
+				secondGraderID = graderID
+				break
+			}
+		}
+		if secondGraderID == 0 {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    422,
+				Message: "Validation failed",
+				Details: "Some instances were marked by all selected graders, add another second marker",
+				FormErrors: common.ErrorObject{
+					"graderIds": "Some instances were marked by all selected graders, add another second marker",
+				},
+			}
+		}
+
+		moderation := models.Moderation{
+			CourseItemID:   courseItem.ID,
+			TermID:         params.TermID,
+			TestInstanceID: instanceID,
+			SecondGraderID: secondGraderID,
+			Status:         enums.ModerationStatusPending,
+			Marks:          make([]*models.ModerationMark, len(questions)),
+		}
+		for q_i, question := range questions {
+			moderation.Marks[q_i] = &models.ModerationMark{
+				TestInstanceQuestionID: question.ID,
+			}
+		}
+		if err := transaction.Create(&moderation).Error; err != nil {
+			transaction.Rollback()
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to create moderation",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	// Sample is recorded even when no instance was selected, so results do not wait for it anymore
+	sample := models.ModerationSample{
+		CourseItemID: courseItem.ID,
+		TermID:       params.TermID,
+		DrawnByID:    userData.ID,
+	}
+	if err := transaction.
+		Where("course_item_id = ?", courseItem.ID).
+		Where("term_id = ?", params.TermID).
+		FirstOrCreate(&sample).Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save moderation sample",
+			Details: err.Error(),
+		}
+	}
+
+	// Results of instances outside of sample can become final now
+	job, err := CreateReevaluationJob(transaction, params.CourseID, courseItem.ID, params.TermID, nil, userData.ID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	go RunReevaluationJob(job.ID)
+
+	c.JSON(200, ModerationSampleResponse{
+		Sampled: uint(len(candidates)),
+		Total:   uint(len(sampledIDs) + len(candidates)),
+		JobID:   job.ID,
+	})
+
+	return nil
+}
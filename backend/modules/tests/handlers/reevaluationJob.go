@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"gorm.io/gorm"
+)
+
+// CreateReevaluationJob stores job for all evaluated instances of a term (or one test), run it by RunReevaluationJob
+// after commit
+func CreateReevaluationJob(dbRef *gorm.DB, courseID uint, courseItemID uint, termID uint, testID *uint, userID uint) (*models.ReevaluationJob, *common.ErrorResponse) {
+	job := &models.ReevaluationJob{
+		CreatedByID:  userID,
+		CourseID:     courseID,
+		CourseItemID: courseItemID,
+		TermID:       termID,
+		TestID:       testID,
+		Status:       enums.JobStatusPending,
+	}
+
+	if err := dbRef.Create(job).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to create reevaluation job",
+			Details: err.Error(),
+		}
+	}
+
+	return job, nil
+}
+
+// RunReevaluationJob evaluates affected instances one by one and records points before and after.
+func RunReevaluationJob(jobID uint) {
+	if !utils.ClaimJob(initializers.DB, &models.ReevaluationJob{}, jobID) {
+		return
+	}
+
+	var job models.ReevaluationJob
+	if err := initializers.DB.First(&job, jobID).Error; err != nil {
+		log.Println("Failed to load reevaluation job", jobID, err)
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			finishReevaluationJob(&job, enums.JobStatusFailed, fmt.Sprint(r))
+		}
+	}()
+
+	var testInstances []*models.TestInstance
+	query := initializers.DB.
+		InnerJoins("CourseItem").
+		InnerJoins("Participant").
+		Preload("Result").
+		Where("test_instances.course_item_id = ?", job.CourseItemID).
+		Where("test_instances.term_id = ?", job.TermID).
+		Where("test_instances.state IN ?", []enums.TestInstanceStateEnum{enums.TestInstanceStateFinished, enums.TestInstanceStateExpired})
+	if job.TestID != nil {
+		query = query.Where("test_instances.test_id = ?", *job.TestID)
+	}
+	if err := query.Find(&testInstances).Error; err != nil {
+		finishReevaluationJob(&job, enums.JobStatusFailed, err.Error())
+		return
+	}
+
+	userData := &authdtos.LoggedUserDTO{ID: job.CreatedByID}
+
+	for _, testInstance := range testInstances {
+		item := models.ReevaluationReportItem{
+			TestInstanceID: testInstance.ID,
+			StudentID:      testInstance.ParticipantID,
+			StudentName:    testInstance.Participant.FullName(),
+		}
+		if testInstance.Result != nil {
+			item.PointsBefore = testInstance.Result.Points
+		}
+
+		if err := reevaluateTestInstance(&job, testInstance, userData); err != nil {
+			item.Error = err.Message
+			item.PointsAfter = item.PointsBefore
+			job.Report = append(job.Report, item)
+			continue
+		}
+
+		var instanceResult models.CourseItemResult
+		if err := initializers.DB.
+			Where("test_instance_id = ?", testInstance.ID).
+			First(&instanceResult).Error; err == nil {
+			item.PointsAfter = instanceResult.Points
+		}
+		job.Report = append(job.Report, item)
+	}
+
+	finishReevaluationJob(&job, enums.JobStatusFinished, "")
+}
+
+func reevaluateTestInstance(job *models.ReevaluationJob, testInstance *models.TestInstance, userData *authdtos.LoggedUserDTO) *common.ErrorResponse {
+	transaction := initializers.DB.Begin()
+
+	if err := EvaluateTestInstance(transaction, testInstance.ID, userData, true); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	rootCoureItem := testInstance.CourseItem.ID
+	if testInstance.CourseItem.ParentID != nil {
+		rootCoureItem = *testInstance.CourseItem.ParentID
+	}
+
+	if err := services_course_item.UpdateSelectedResults(transaction, job.CourseID, rootCoureItem, testInstance.ParticipantID); err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if err := transaction.Commit().Error; err != nil {
+		transaction.Rollback()
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to commit changes",
+			Details: err.Error(),
+		}
+	}
+
+	return nil
+}
+
+func finishReevaluationJob(job *models.ReevaluationJob, status enums.JobStatusEnum, errorMessage string) {
+	finishedAt := time.Now()
+	job.Status = status
+	job.Error = errorMessage
+	job.FinishedAt = &finishedAt
+
+	if err := initializers.DB.Save(job).Error; err != nil {
+		log.Println("Failed to save reevaluation job", job.ID, err)
+	}
+}
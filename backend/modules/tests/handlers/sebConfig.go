@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+
+	"elogika.vsb.cz/backend/auth"
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	authdtos "elogika.vsb.cz/backend/modules/auth/dtos"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/modules/tests/helpers"
+	"elogika.vsb.cz/backend/repositories"
+	services_course_item "elogika.vsb.cz/backend/services/courseItem"
+	"elogika.vsb.cz/backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Download Safe Exam Browser config for test term
+// @Tags Tests
+// @Security ApiKeyAuth
+// @Produce  application/seb
+// @Param courseId path int true "ID of the corresponding course"
+// @Param courseItemId path int true "ID of the corresponding course item"
+// @Param termId path int true "ID of the corresponding term"
+// @Success 200 {file} file "SEB config file"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource or patch"
+// @Failure 403 {object} common.ErrorResponse "Permission or atuhentication errors"
+// @Failure 500 {object} common.ErrorResponse "Fatal failure"
+// @Router /api/v2/courses/{courseId}/tests/{courseItemId}/{termId}/seb [get]
+func SafeExamBrowserConfig(c *gin.Context, userData authdtos.LoggedUserDTO, userRole enums.CourseUserRoleEnum) *common.ErrorResponse {
+	// Load request data
+	err, params, _ := utils.GetRequestData[
+		struct {
+			CourseID     uint `uri:"courseId" binding:"required"`
+			CourseItemID uint `uri:"courseItemId" binding:"required"`
+			TermID       uint `uri:"termId" binding:"required"`
+		},
+		any,
+	](c)
+	if err != nil {
+		return err
+	}
+
+	// Check role validity
+	if err := auth.GetClaimCourseRole(userData, params.CourseID, userRole); err != nil {
+		return err
+	}
+
+	var courseItem *models.CourseItem
+	if userRole == enums.CourseUserRoleStudent {
+		if err := initializers.DB.
+			Where("course_id = ?", params.CourseID).
+			InnerJoins("TestDetail").
+			First(&courseItem, params.CourseItemID).Error; err != nil {
+			return &common.ErrorResponse{
+				Code:    404,
+				Message: "Failed to fetch course item",
+				Details: err.Error(),
+			}
+		}
+	} else {
+		courseItemService := services_course_item.NewCourseItemService(repositories.NewCourseItemRepository())
+		courseItem, err = courseItemService.GetCourseItemByID(initializers.DB, params.CourseID, params.CourseItemID, userData.ID, userRole, nil, true, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if courseItem.TestDetail == nil {
+		return &common.ErrorResponse{
+			Code:    400,
+			Message: "Course item is not a test",
+		}
+	}
+
+	// Term can belong to the test itself or to its parent group
+	termQuery := initializers.DB.
+		Where("course_id = ?", params.CourseID).
+		Where("course_item_id = ? OR course_item_id = ?", courseItem.ID, courseItem.ParentID)
+	if userRole == enums.CourseUserRoleStudent {
+		termQuery = termQuery.Joins("JOIN user_terms ON user_terms.term_id = terms.id AND user_terms.user_id = ? AND user_terms.deleted_at is NULL", userData.ID)
+	}
+
+	var term models.Term
+	if err := termQuery.First(&term, params.TermID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    404,
+			Message: "Failed to fetch term",
+			Details: err.Error(),
+		}
+	}
+
+	configData, err2 := helpers.SafeExamBrowserConfig(courseItem, term.ID).Plist()
+	if err2 != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to generate Safe Exam Browser config",
+			Details: err2.Error(),
+		}
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"elogika-%d-%d.seb\"", courseItem.ID, term.ID))
+	c.Data(200, "application/seb", configData)
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+const (
+	accessCodeMaxAttempts   = 5
+	accessCodeAttemptWindow = 15 * time.Minute
+)
+
+// CheckAccessCode validates code entered by student against the one revealed by supervisor.
+// Failed attempts are logged as events (outside of any running transaction) and used for rate limiting.
+func CheckAccessCode(term *models.Term, testInstanceID *uint, userID uint, accessCode string, clientIP string) *common.ErrorResponse {
+	if !term.RequiresAccessCode {
+		return nil
+	}
+
+	var failedAttempts int64
+	if err := initializers.DB.
+		Model(&models.TestInstanceEvent{}).
+		Where("user_id = ?", userID).
+		Where("term_id = ?", term.ID).
+		Where("event_type = ?", enums.TestInstanceEventTypeAccessCodeInvalid).
+		Where("received_at > ?", time.Now().Add(-accessCodeAttemptWindow)).
+		Count(&failedAttempts).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to check access code attempts",
+			Details: err.Error(),
+		}
+	}
+
+	if failedAttempts >= accessCodeMaxAttempts {
+		return &common.ErrorResponse{
+			Code:    429,
+			Message: "Too many invalid access code attempts, try again later",
+		}
+	}
+
+	given := strings.TrimSpace(accessCode)
+	if term.AccessCode != "" && subtle.ConstantTimeCompare([]byte(given), []byte(term.AccessCode)) == 1 {
+		return nil
+	}
+
+	eventData, _ := json.Marshal(map[string]interface{}{
+		"IP":         clientIP,
+		"AccessCode": given,
+	})
+
+	if err := initializers.DB.Create(&models.TestInstanceEvent{
+		TestInstanceID: testInstanceID,
+		TermID:         &term.ID,
+		UserID:         userID,
+		OccuredAt:      time.Now(),
+		ReceivedAt:     time.Now(),
+		EventSource:    enums.TestInstanceEventSourceServer,
+		EventType:      enums.TestInstanceEventTypeAccessCodeInvalid,
+		EventData:      eventData,
+	}).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to insert log report",
+		}
+	}
+
+	return &common.ErrorResponse{
+		Code:    403,
+		Message: "Invalid access code",
+	}
+}
@@ -0,0 +1,22 @@
+package helpers
+
+import (
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"gorm.io/gorm"
+)
+
+// GradingQueueQuery selects instance questions of evaluated instances for one bank question in term
+func GradingQueueQuery(dbRef *gorm.DB, courseItemID uint, termID uint, questionID uint) *gorm.DB {
+	return GradingTermQuery(dbRef, courseItemID, termID).
+		Where("test_questions.question_id = ?", questionID)
+}
+
+// GradingTermQuery selects instance questions of evaluated instances in term
+func GradingTermQuery(dbRef *gorm.DB, courseItemID uint, termID uint) *gorm.DB {
+	return dbRef.
+		Joins("JOIN test_instances ON test_instances.id = test_instance_questions.test_instance_id AND test_instances.deleted_at IS NULL").
+		Joins("JOIN test_questions ON test_questions.id = test_instance_questions.test_question_id").
+		Where("test_instances.course_item_id = ?", courseItemID).
+		Where("test_instances.term_id = ?", termID).
+		Where("test_instances.state IN ?", []enums.TestInstanceStateEnum{enums.TestInstanceStateFinished, enums.TestInstanceStateExpired})
+}
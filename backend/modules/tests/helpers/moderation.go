@@ -0,0 +1,126 @@
+package helpers
+
+import (
+	"math"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"gorm.io/gorm"
+)
+
+// OpenAnswerInstanceCondition selects instances containing open question, only those can be double-marked
+const OpenAnswerInstanceCondition = `EXISTS (
+	SELECT 1 FROM test_instance_questions
+	JOIN test_questions ON test_questions.id = test_instance_questions.test_question_id
+	JOIN questions ON questions.id = test_questions.question_id
+	WHERE test_instance_questions.test_instance_id = test_instances.id AND questions.question_format = ?
+)`
+
+// IsModerationPending reports if result of instance has to wait for moderation. With moderation enabled, results
+// of instances with open questions are not final until sample of term is drawn, and sampled instances until their
+// moderation completes.
+func IsModerationPending(dbRef *gorm.DB, testInstance *models.TestInstance) (bool, *common.ErrorResponse) {
+	if testInstance.CourseItem == nil || testInstance.CourseItem.TestDetailID == nil {
+		return false, nil
+	}
+
+	var testDetail models.CourseItemTest
+	if err := dbRef.
+		Select("id", "moderation_percentage").
+		First(&testDetail, *testInstance.CourseItem.TestDetailID).Error; err != nil {
+		return false, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load test settings",
+			Details: err.Error(),
+		}
+	}
+	if testDetail.ModerationPercentage == 0 {
+		return false, nil
+	}
+
+	// Instances without open questions are never sampled
+	var openAnswers int64
+	if err := dbRef.
+		Model(&models.TestInstance{}).
+		Where("test_instances.id = ?", testInstance.ID).
+		Where(OpenAnswerInstanceCondition, enums.QuestionFormatOpen).
+		Count(&openAnswers).Error; err != nil {
+		return false, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load instance questions",
+			Details: err.Error(),
+		}
+	}
+	if openAnswers == 0 {
+		return false, nil
+	}
+
+	var moderations []models.Moderation
+	if err := dbRef.
+		Select("id", "status").
+		Where("test_instance_id = ?", testInstance.ID).
+		Find(&moderations).Error; err != nil {
+		return false, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load moderations",
+			Details: err.Error(),
+		}
+	}
+	if len(moderations) != 0 {
+		return moderations[0].Status != enums.ModerationStatusCompleted, nil
+	}
+
+	var samples int64
+	if err := dbRef.
+		Model(&models.ModerationSample{}).
+		Where("course_item_id = ?", testInstance.CourseItemID).
+		Where("term_id = ?", testInstance.TermID).
+		Count(&samples).Error; err != nil {
+		return false, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load moderation sample",
+			Details: err.Error(),
+		}
+	}
+	return samples == 0, nil
+}
+
+// IsModerationDisagreement reports if marks differ by more than threshold (both in percent)
+func IsModerationDisagreement(first float64, second float64, threshold float64) bool {
+	return math.Abs(first-second) > threshold
+}
+
+// ValidateGraders checks that all users are tutors or garants of the course
+func ValidateGraders(dbRef *gorm.DB, courseID uint, graderIDs []uint) ([]uint, *common.ErrorResponse) {
+	var courseUsers []models.CourseUser
+	if err := dbRef.
+		Where("course_id = ?", courseID).
+		Where("user_id IN ?", graderIDs).
+		Find(&courseUsers).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load graders",
+			Details: err.Error(),
+		}
+	}
+
+	graders := make([]uint, 0, len(courseUsers))
+	for _, courseUser := range courseUsers {
+		if courseUser.HasRole(enums.CourseUserRoleTutor) || courseUser.HasRole(enums.CourseUserRoleGarant) {
+			graders = append(graders, courseUser.UserID)
+		}
+	}
+	if len(graders) == 0 || len(graders) != len(graderIDs) {
+		return nil, &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "All graders must be tutors or garants of the course",
+			FormErrors: common.ErrorObject{
+				"graderIds": "All graders must be tutors or garants of the course",
+			},
+		}
+	}
+
+	return graders, nil
+}
@@ -0,0 +1,191 @@
+package helpers
+
+import (
+	"time"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"gorm.io/gorm"
+)
+
+// Saves arriving shortly after deadline are still accepted to compensate network latency
+const questionDeadlineTolerance = 5 * time.Second
+
+// QuestionTimeLimit returns time window of question. Limit set on template block takes precedence over question estimates.
+func QuestionTimeLimit(blocks []models.TestBlock, question *models.TestInstanceQuestion) time.Duration {
+	for _, block := range blocks {
+		if block.ID == question.TestQuestion.BlockID && block.QuestionTimeLimit != 0 {
+			return time.Duration(block.QuestionTimeLimit) * time.Second
+		}
+	}
+
+	return time.Duration(question.TestQuestion.Question.TimeToRead+question.TestQuestion.Question.TimeToProcess) * time.Second
+}
+
+func IsQuestionTimeExpired(question *models.TestInstanceQuestion, timeNow time.Time) bool {
+	return question.DeadlineAt != nil && timeNow.After(question.DeadlineAt.Add(questionDeadlineTolerance))
+}
+
+// OpenQuestions marks currently unlocked questions (all of them in free mode) as opened. With question time limits enabled,
+// all questions of the unlocked block share a deadline equal to the sum of their time windows.
+func OpenQuestions(transaction *gorm.DB, testInstance *models.TestInstance, testDetail *models.CourseItemTest, timeNow time.Time) *common.ErrorResponse {
+	unlocked := testInstance.UnlockedQuestions(testDetail.NavigationMode)
+	if len(unlocked) == 0 {
+		return nil
+	}
+
+	var deadlineAt *time.Time
+	if testDetail.QuestionTimeLimits {
+		var test models.Test
+		if err := transaction.
+			Select("id", "blocks").
+			First(&test, testInstance.TestID).Error; err != nil {
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to fetch test blocks",
+				Details: err.Error(),
+			}
+		}
+
+		var timeLimit time.Duration
+		for _, question := range unlocked {
+			timeLimit += QuestionTimeLimit(test.Blocks, question)
+		}
+		deadline := timeNow.Add(timeLimit)
+		deadlineAt = &deadline
+	}
+
+	for _, question := range unlocked {
+		if question.OpenedAt != nil {
+			continue
+		}
+		question.OpenedAt = &timeNow
+		question.DeadlineAt = deadlineAt
+
+		if err := transaction.
+			Model(&models.TestInstanceQuestion{}).
+			Where("id = ?", question.ID).
+			Updates(map[string]interface{}{
+				"opened_at":   question.OpenedAt,
+				"deadline_at": question.DeadlineAt,
+			}).Error; err != nil {
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to open question",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	return nil
+}
+
+// CloseQuestions stores time spent on currently unlocked questions. Time after deadline is not counted.
+func CloseQuestions(transaction *gorm.DB, testInstance *models.TestInstance, testDetail *models.CourseItemTest, timeNow time.Time) *common.ErrorResponse {
+	for _, question := range testInstance.UnlockedQuestions(testDetail.NavigationMode) {
+		if question.OpenedAt == nil {
+			continue
+		}
+
+		closedAt := timeNow
+		if question.DeadlineAt != nil && question.DeadlineAt.Before(closedAt) {
+			closedAt = *question.DeadlineAt
+		}
+		question.TimeSpent = 0
+		if closedAt.After(*question.OpenedAt) {
+			question.TimeSpent = uint(closedAt.Sub(*question.OpenedAt).Seconds())
+		}
+
+		if err := transaction.
+			Model(&models.TestInstanceQuestion{}).
+			Where("id = ?", question.ID).
+			Update("time_spent", question.TimeSpent).Error; err != nil {
+			return &common.ErrorResponse{
+				Code:    500,
+				Message: "Failed to close question",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	return nil
+}
+
+// SwitchQuestion closes currently unlocked questions and unlocks the following ones.
+// Update is conditional on current question, so parallel requests cannot skip a question.
+func SwitchQuestion(transaction *gorm.DB, testInstance *models.TestInstance, testDetail *models.CourseItemTest, userID uint, timeNow time.Time) (*models.TestInstanceQuestion, *common.ErrorResponse) {
+	if testInstance.CurrentQuestionID == nil {
+		return nil, &common.ErrorResponse{
+			Code:    409,
+			Message: "No question is currently unlocked",
+		}
+	}
+
+	currentQuestion := FindQuestion(testInstance, *testInstance.CurrentQuestionID)
+	nextQuestion := testInstance.NextQuestion(testDetail.NavigationMode)
+	if nextQuestion == nil {
+		return nil, &common.ErrorResponse{
+			Code:    409,
+			Message: "No more questions left",
+		}
+	}
+
+	result := transaction.
+		Model(&models.TestInstance{}).
+		Where("id = ?", testInstance.ID).
+		Where("current_question_id = ?", *testInstance.CurrentQuestionID).
+		Update("current_question_id", nextQuestion.ID)
+	if result.Error != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to switch question",
+			Details: result.Error.Error(),
+		}
+	}
+	if result.RowsAffected != 1 {
+		return nil, &common.ErrorResponse{
+			Code:    409,
+			Message: "Question was already switched",
+		}
+	}
+
+	if err := CloseQuestions(transaction, testInstance, testDetail, timeNow); err != nil {
+		return nil, err
+	}
+
+	testInstance.CurrentQuestionID = &nextQuestion.ID
+	if err := OpenQuestions(transaction, testInstance, testDetail, timeNow); err != nil {
+		return nil, err
+	}
+
+	if err := transaction.Create(QuestionSwitchedEvent(testInstance, currentQuestion, nextQuestion, userID)).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save events",
+		}
+	}
+
+	return nextQuestion, nil
+}
+
+// AdvanceExpiredQuestions moves student to next question when time window of current one passed.
+// Returns true when question was switched. After the last question there is nowhere to advance.
+func AdvanceExpiredQuestions(transaction *gorm.DB, testInstance *models.TestInstance, testDetail *models.CourseItemTest, userID uint, timeNow time.Time) (bool, *common.ErrorResponse) {
+	if !testDetail.QuestionTimeLimits || !testDetail.NavigationMode.IsSequential() || testInstance.CurrentQuestionID == nil {
+		return false, nil
+	}
+
+	currentQuestion := FindQuestion(testInstance, *testInstance.CurrentQuestionID)
+	if currentQuestion == nil || !IsQuestionTimeExpired(currentQuestion, timeNow) {
+		return false, nil
+	}
+
+	if testInstance.NextQuestion(testDetail.NavigationMode) == nil {
+		return false, CloseQuestions(transaction, testInstance, testDetail, timeNow)
+	}
+
+	if _, err := SwitchQuestion(transaction, testInstance, testDetail, userID, timeNow); err != nil {
+		return false, err
+	}
+	return true, nil
+}
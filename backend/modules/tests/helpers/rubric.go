@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"gorm.io/gorm"
+)
+
+// ApplyRubricSelections evaluates grader selections with rubric of the question and sets
+// percentage of open answer to ratio of gained and maximal rubric points.
+func ApplyRubricSelections(dbRef *gorm.DB, question *models.TestInstanceQuestion, rubricID *uint, selections []models.RubricSelection) *common.ErrorResponse {
+	if rubricID == nil {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Question has no rubric",
+			FormErrors: common.ErrorObject{
+				"rubricSelections": "Question has no rubric",
+			},
+		}
+	}
+
+	var rubric models.Rubric
+	if err := dbRef.Unscoped().First(&rubric, *rubricID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load rubric",
+			Details: err.Error(),
+		}
+	}
+
+	evaluated, points, err := rubric.Evaluate(selections)
+	if err != nil {
+		return err
+	}
+
+	question.RubricSelections = evaluated
+	question.TextAnswerPercentage = 0
+	if maxPoints := rubric.MaxPoints(); maxPoints > 0 {
+		question.TextAnswerPercentage = points / maxPoints * 100
+	}
+
+	return nil
+}
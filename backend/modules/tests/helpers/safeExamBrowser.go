@@ -0,0 +1,139 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils/seb"
+	"github.com/gin-gonic/gin"
+)
+
+// SafeExamBrowserConfig builds SEB settings for given course item and term.
+// Settings must stay deterministic, because config key is recalculated from them on every request.
+func SafeExamBrowserConfig(courseItem *models.CourseItem, termID uint) seb.Config {
+	frontendURL := strings.TrimSuffix(initializers.GlobalAppConfig.FRONTEND_URL, "/")
+
+	config := seb.Config{
+		"originatorVersion":              "eLogika",
+		"startURL":                       fmt.Sprintf("%s/app/%d/student/tests?termId=%d&courseItemId=%d", frontendURL, courseItem.CourseID, termID, courseItem.ID),
+		"sendBrowserExamKey":             true,
+		"allowQuit":                      true,
+		"browserWindowAllowReload":       true,
+		"allowSpellCheck":                false,
+		"allowDownUploads":               false,
+		"enablePrintScreen":              false,
+		"examSessionClearCookiesOnStart": true,
+	}
+
+	if courseItem.TestDetail.SEBQuitPassword != "" {
+		config["hashedQuitPassword"] = seb.HashQuitPassword(courseItem.TestDetail.SEBQuitPassword)
+	}
+
+	return config
+}
+
+// CheckSafeExamBrowser validates headers sent by SEB while instance is being written, course item must have
+// loaded TestDetail. Callers must verify ownership of instance first, rejected requests are logged as events of user.
+func CheckSafeExamBrowser(c *gin.Context, courseItem *models.CourseItem, testInstance *models.TestInstance, userID uint) *common.ErrorResponse {
+	if !courseItem.TestDetail.RequireSEB {
+		return nil
+	}
+	// Finished instances can be reviewed outside of SEB
+	if testInstance.State != enums.TestInstanceStateReady && testInstance.State != enums.TestInstanceStateActive {
+		return nil
+	}
+
+	absoluteURL := requestAbsoluteURL(c)
+	reason := ""
+
+	configKey, err := SafeExamBrowserConfig(courseItem, testInstance.TermID).ConfigKey()
+	if err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to calculate Safe Exam Browser config key",
+			Details: err.Error(),
+		}
+	}
+
+	configKeyHash := c.GetHeader(seb.HeaderConfigKeyHash)
+	if configKeyHash == "" {
+		reason = "Missing config key hash"
+	} else if !seb.VerifyHash(absoluteURL, configKey, configKeyHash) {
+		reason = "Invalid config key hash"
+	}
+
+	if reason == "" && courseItem.TestDetail.SEBBrowserExamKeys != "" {
+		requestHash := c.GetHeader(seb.HeaderRequestHash)
+		valid := false
+		for _, browserExamKey := range strings.Split(courseItem.TestDetail.SEBBrowserExamKeys, ";") {
+			browserExamKey = strings.TrimSpace(browserExamKey)
+			if browserExamKey != "" && seb.VerifyHash(absoluteURL, browserExamKey, requestHash) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			reason = "Invalid browser exam key hash"
+		}
+	}
+
+	if reason == "" {
+		return nil
+	}
+
+	eventData, _ := json.Marshal(map[string]interface{}{
+		"IP":     c.ClientIP(),
+		"URL":    absoluteURL,
+		"Reason": reason,
+	})
+
+	if err := initializers.DB.Create(&models.TestInstanceEvent{
+		TestInstanceID: &testInstance.ID,
+		TermID:         &testInstance.TermID,
+		UserID:         userID,
+		OccuredAt:      time.Now(),
+		ReceivedAt:     time.Now(),
+		EventSource:    enums.TestInstanceEventSourceServer,
+		EventType:      enums.TestInstanceEventTypeInvalidSEB,
+		EventData:      eventData,
+	}).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to insert log report",
+		}
+	}
+
+	return &common.ErrorResponse{
+		Code:    403,
+		Message: "Test must be written in Safe Exam Browser",
+		Details: reason,
+	}
+}
+
+// requestAbsoluteURL reconstructs URL as seen by browser, which SEB uses for hashing
+func requestAbsoluteURL(c *gin.Context) string {
+	if base := initializers.GlobalAppConfig.PUBLIC_API_URL; base != "" {
+		return strings.TrimSuffix(base, "/") + c.Request.RequestURI
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	host := c.Request.Host
+	if forwardedHost := c.GetHeader("X-Forwarded-Host"); forwardedHost != "" {
+		host = forwardedHost
+	}
+
+	return scheme + "://" + host + c.Request.RequestURI
+}
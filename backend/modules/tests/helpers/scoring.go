@@ -0,0 +1,101 @@
+package helpers
+
+import (
+	"math"
+	"slices"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils"
+)
+
+// QuestionScore returns score gained for ABCD question and maximal score of the question.
+// Block ratio is then sum of scores divided by sum of maximal scores.
+// Tests generated before strategies were introduced have empty strategy and are scored per answer.
+// Accepted answers (by invalidation) are correct whether they are selected or not.
+func QuestionScore(strategy enums.ScoringStrategyEnum, answers []*models.TestInstanceQuestionAnswer, wrongAnswerPercentage uint, allowEmptyAnswers bool, acceptedAnswerIDs []uint) (float64, float64) {
+	total, checked, correct, incorrect := QuestionAnswersStats(answers, acceptedAnswerIDs)
+	penalty := utils.ToPercentage(wrongAnswerPercentage)
+	empty := checked == 0 || checked == total
+
+	switch strategy {
+	case enums.ScoringStrategyAllOrNothing:
+		if total == 0 || incorrect != 0 || (allowEmptyAnswers && empty) {
+			return 0, 1
+		}
+		return 1, 1
+	case enums.ScoringStrategyPerQuestionPartial:
+		if total == 0 || (allowEmptyAnswers && empty) {
+			return 0, 1
+		}
+		return math.Max(0, (correct-penalty*incorrect)/total), 1
+	case enums.ScoringStrategySingleChoice:
+		if checked == 0 {
+			return 0, 1
+		}
+		if checked == 1 {
+			for _, qa := range answers {
+				if qa.Selected && (qa.TestQuestionAnswer.Answer.Correct || slices.Contains(acceptedAnswerIDs, qa.TestQuestionAnswer.AnswerID)) {
+					return 1, 1
+				}
+			}
+		}
+		return -penalty, 1
+	default:
+		if allowEmptyAnswers && empty {
+			return 0, total
+		}
+		return correct - penalty*incorrect, total
+	}
+}
+
+// QuestionAnswersStats counts answers of question, answer is correct when its selection matches the key
+func QuestionAnswersStats(answers []*models.TestInstanceQuestionAnswer, acceptedAnswerIDs []uint) (float64, float64, float64, float64) {
+	var numAnswers = float64(0)
+	var numChecked = float64(0)
+	var numCorrect = float64(0)
+	var numIncorrect = float64(0)
+
+	for _, qa := range answers {
+		numAnswers++
+		if qa.Selected {
+			numChecked++
+		}
+
+		if qa.TestQuestionAnswer.Answer.Correct == qa.Selected || slices.Contains(acceptedAnswerIDs, qa.TestQuestionAnswer.AnswerID) {
+			numCorrect++
+		} else {
+			numIncorrect++
+		}
+	}
+
+	return numAnswers, numChecked, numCorrect, numIncorrect
+}
+
+// QuestionInvalidationFor returns question level invalidation mode (empty when question is valid)
+// and answers accepted as correct for given bank question
+func QuestionInvalidationFor(invalidations []*models.QuestionInvalidation, questionID uint) (enums.QuestionInvalidationModeEnum, []uint) {
+	var mode enums.QuestionInvalidationModeEnum
+	acceptedAnswerIDs := make([]uint, 0)
+
+	for _, invalidation := range invalidations {
+		if invalidation.QuestionID != questionID {
+			continue
+		}
+
+		switch invalidation.Mode {
+		case enums.QuestionInvalidationModeDrop:
+			mode = enums.QuestionInvalidationModeDrop
+		case enums.QuestionInvalidationModeFullCredit:
+			if mode != enums.QuestionInvalidationModeDrop {
+				mode = enums.QuestionInvalidationModeFullCredit
+			}
+		case enums.QuestionInvalidationModeAcceptAnswer:
+			if invalidation.AnswerID != nil {
+				acceptedAnswerIDs = append(acceptedAnswerIDs, *invalidation.AnswerID)
+			}
+		}
+	}
+
+	return mode, acceptedAnswerIDs
+}
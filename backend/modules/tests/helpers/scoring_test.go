@@ -0,0 +1,109 @@
+package helpers
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+// scoringAnswers builds answers of question with IDs from 1, key marks correct answers
+func scoringAnswers(key []bool, selected []bool) []*models.TestInstanceQuestionAnswer {
+	answers := make([]*models.TestInstanceQuestionAnswer, len(key))
+	for i := range key {
+		answers[i] = &models.TestInstanceQuestionAnswer{
+			Selected: selected[i],
+			TestQuestionAnswer: &models.TestQuestionAnswer{
+				AnswerID: uint(i + 1),
+				Answer:   &models.Answer{Correct: key[i]},
+			},
+		}
+	}
+	return answers
+}
+
+func TestQuestionScore(t *testing.T) {
+	key := []bool{true, false, false, true}
+
+	tests := []struct {
+		name       string
+		strategy   enums.ScoringStrategyEnum
+		selected   []bool
+		allowEmpty bool
+		accepted   []uint
+		wantScore  float64
+		wantMax    float64
+	}{
+		{"per answer all correct", enums.ScoringStrategyPerAnswer, []bool{true, false, false, true}, false, nil, 4, 4},
+		{"per answer one missing", enums.ScoringStrategyPerAnswer, []bool{true, false, false, false}, false, nil, 2.5, 4},
+		{"per answer all wrong goes below zero", enums.ScoringStrategyPerAnswer, []bool{false, true, true, false}, false, nil, -2, 4},
+		{"per answer empty allowed", enums.ScoringStrategyPerAnswer, []bool{false, false, false, false}, true, nil, 0, 4},
+		{"per answer empty not allowed", enums.ScoringStrategyPerAnswer, []bool{false, false, false, false}, false, nil, 1, 4},
+		{"per answer everything selected counts as empty", enums.ScoringStrategyPerAnswer, []bool{true, true, true, true}, true, nil, 0, 4},
+		{"legacy empty strategy is per answer", "", []bool{true, false, false, false}, false, nil, 2.5, 4},
+
+		{"all or nothing correct", enums.ScoringStrategyAllOrNothing, []bool{true, false, false, true}, false, nil, 1, 1},
+		{"all or nothing one missing", enums.ScoringStrategyAllOrNothing, []bool{true, false, false, false}, false, nil, 0, 1},
+		{"all or nothing empty allowed", enums.ScoringStrategyAllOrNothing, []bool{false, false, false, false}, true, nil, 0, 1},
+		{"all or nothing accepted answer", enums.ScoringStrategyAllOrNothing, []bool{true, false, false, false}, false, []uint{4}, 1, 1},
+
+		{"partial all correct", enums.ScoringStrategyPerQuestionPartial, []bool{true, false, false, true}, false, nil, 1, 1},
+		{"partial one missing", enums.ScoringStrategyPerQuestionPartial, []bool{true, false, false, false}, false, nil, 0.625, 1},
+		{"partial does not go below zero", enums.ScoringStrategyPerQuestionPartial, []bool{false, true, true, false}, false, nil, 0, 1},
+		{"partial empty not allowed", enums.ScoringStrategyPerQuestionPartial, []bool{false, false, false, false}, false, nil, 0.25, 1},
+
+		{"single choice correct", enums.ScoringStrategySingleChoice, []bool{true, false, false, false}, false, nil, 1, 1},
+		{"single choice wrong", enums.ScoringStrategySingleChoice, []bool{false, true, false, false}, false, nil, -0.5, 1},
+		{"single choice more answers", enums.ScoringStrategySingleChoice, []bool{true, false, false, true}, false, nil, -0.5, 1},
+		{"single choice nothing selected", enums.ScoringStrategySingleChoice, []bool{false, false, false, false}, false, nil, 0, 1},
+		{"single choice accepted answer", enums.ScoringStrategySingleChoice, []bool{false, true, false, false}, false, []uint{2}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, scoreMax := QuestionScore(tt.strategy, scoringAnswers(key, tt.selected), 50, tt.allowEmpty, tt.accepted)
+			if math.Abs(score-tt.wantScore) > 1e-9 || scoreMax != tt.wantMax {
+				t.Fatalf("got %v/%v, want %v/%v", score, scoreMax, tt.wantScore, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestQuestionInvalidationFor(t *testing.T) {
+	answerID := func(id uint) *uint { return &id }
+
+	tests := []struct {
+		name          string
+		invalidations []*models.QuestionInvalidation
+		wantMode      enums.QuestionInvalidationModeEnum
+		wantAccepted  []uint
+	}{
+		{"valid question", nil, "", []uint{}},
+		{"other question", []*models.QuestionInvalidation{
+			{QuestionID: 2, Mode: enums.QuestionInvalidationModeDrop},
+		}, "", []uint{}},
+		{"full credit", []*models.QuestionInvalidation{
+			{QuestionID: 1, Mode: enums.QuestionInvalidationModeFullCredit},
+		}, enums.QuestionInvalidationModeFullCredit, []uint{}},
+		{"drop takes precedence", []*models.QuestionInvalidation{
+			{QuestionID: 1, Mode: enums.QuestionInvalidationModeDrop},
+			{QuestionID: 1, Mode: enums.QuestionInvalidationModeFullCredit},
+		}, enums.QuestionInvalidationModeDrop, []uint{}},
+		{"accepted answers", []*models.QuestionInvalidation{
+			{QuestionID: 1, Mode: enums.QuestionInvalidationModeAcceptAnswer, AnswerID: answerID(3)},
+			{QuestionID: 1, Mode: enums.QuestionInvalidationModeAcceptAnswer, AnswerID: answerID(5)},
+			{QuestionID: 2, Mode: enums.QuestionInvalidationModeAcceptAnswer, AnswerID: answerID(7)},
+		}, "", []uint{3, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, accepted := QuestionInvalidationFor(tt.invalidations, 1)
+			if mode != tt.wantMode || !slices.Equal(accepted, tt.wantAccepted) {
+				t.Fatalf("got %q %v, want %q %v", mode, accepted, tt.wantMode, tt.wantAccepted)
+			}
+		})
+	}
+}
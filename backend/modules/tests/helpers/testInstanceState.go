@@ -0,0 +1,80 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"gorm.io/gorm"
+)
+
+// Name of filtered unique index guaranteeing single active instance per participant
+const activeParticipantIndex = "ux_test_instances_active_participant"
+
+// TransitionTestInstanceState changes instance state only if it is allowed and state was not changed by concurrent request.
+// Update is conditional on previous state, so only one of parallel transitions succeeds.
+func TransitionTestInstanceState(transaction *gorm.DB, testInstance *models.TestInstance, to enums.TestInstanceStateEnum) *common.ErrorResponse {
+	from := testInstance.State
+	if !from.CanTransitionTo(to) {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Invalid test instance state transition",
+			Details: fmt.Sprintf("%s -> %s", from, to),
+		}
+	}
+
+	result := transaction.
+		Model(&models.TestInstance{}).
+		Where("id = ?", testInstance.ID).
+		Where("state = ?", from).
+		Update("state", to)
+	if result.Error != nil {
+		if strings.Contains(result.Error.Error(), activeParticipantIndex) {
+			return &common.ErrorResponse{
+				Code:    409,
+				Message: "Another test instance is already running",
+			}
+		}
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to change test instance state",
+			Details: result.Error.Error(),
+		}
+	}
+	if result.RowsAffected != 1 {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Test instance state was changed concurrently",
+		}
+	}
+
+	testInstance.State = to
+	return nil
+}
+
+// LockParticipantInstances serializes state changes of all instances of one participant until transaction ends
+func LockParticipantInstances(transaction *gorm.DB, participantID uint) *common.ErrorResponse {
+	var result int
+	if err := transaction.Raw(
+		"DECLARE @result int; EXEC @result = sp_getapplock @Resource = ?, @LockMode = 'Exclusive', @LockOwner = 'Transaction', @LockTimeout = 10000; SELECT @result",
+		fmt.Sprintf("test_instances_participant_%d", participantID),
+	).Scan(&result).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to lock participant test instances",
+			Details: err.Error(),
+		}
+	}
+
+	// Negative values mean timeout, deadlock or other failure
+	if result < 0 {
+		return &common.ErrorResponse{
+			Code:    409,
+			Message: "Another test instance operation is in progress",
+		}
+	}
+
+	return nil
+}
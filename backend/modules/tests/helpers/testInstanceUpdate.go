@@ -7,6 +7,7 @@ import (
 	"elogika.vsb.cz/backend/models"
 	"elogika.vsb.cz/backend/modules/common"
 	"elogika.vsb.cz/backend/modules/common/enums"
+	"elogika.vsb.cz/backend/utils/tiptap"
 	"gorm.io/gorm"
 )
 
@@ -16,11 +17,13 @@ type TestInstanceAnswer struct {
 }
 
 type TestInstanceQuestion struct {
-	QuestionID           uint                  `json:"id" binding:"required"`
-	TextAnswer           *models.TipTapContent `json:"textAnswer"`
-	TextAnswerPercentage *float64              `json:"textAnswerPercentage"` // Only for teacher endpoint
-	TextAnswerReviewed   *bool                 `json:"textAnswerReviewed"`   // Only for teacher endpoint
-	Answers              []TestInstanceAnswer  `json:"answers"`
+	QuestionID           uint                     `json:"id" binding:"required"`
+	TextAnswer           *models.TipTapContent    `json:"textAnswer"`
+	TextAnswerPercentage *float64                 `json:"textAnswerPercentage"` // Only for teacher endpoint
+	TextAnswerReviewed   *bool                    `json:"textAnswerReviewed"`   // Only for teacher endpoint
+	RubricSelections     []models.RubricSelection `json:"rubricSelections"`     // Only for teacher endpoint, overrides percentage
+	Feedback             *models.TipTapContent    `json:"feedback"`             // Only for teacher endpoint
+	Answers              []TestInstanceAnswer     `json:"answers"`
 }
 
 func UpdateOpenQuestion(ti_q *models.TestInstanceQuestion, rd_q *TestInstanceQuestion, transaction *gorm.DB, userId uint, isTutor bool, events *[]*models.TestInstanceEvent) *common.ErrorResponse {
@@ -56,6 +59,13 @@ func UpdateOpenQuestion(ti_q *models.TestInstanceQuestion, rd_q *TestInstanceQue
 			ti_q.TextAnswerPercentage = *rd_q.TextAnswerPercentage
 		}
 
+		if isTutor && rd_q.RubricSelections != nil {
+			if err := ApplyRubricSelections(transaction, ti_q, ti_q.TestQuestion.Question.RubricID, rd_q.RubricSelections); err != nil {
+				return err
+			}
+			ti_q.TextAnswerReviewedByID = &userId
+		}
+
 		// TODO check why is it not saving objects inside objects
 		if err := transaction.Save(&ti_q).Error; err != nil {
 			return &common.ErrorResponse{
@@ -71,7 +81,7 @@ func UpdateOpenQuestion(ti_q *models.TestInstanceQuestion, rd_q *TestInstanceQue
 		})
 
 		*events = append(*events, &models.TestInstanceEvent{
-			TestInstanceID: ti_q.TestInstanceID,
+			TestInstanceID: &ti_q.TestInstanceID,
 			UserID:         userId,
 			OccuredAt:      time.Now(),
 			ReceivedAt:     time.Now(),
@@ -113,7 +123,7 @@ func UpdateTestQuestion(ti_q *models.TestInstanceQuestion, rd_q *TestInstanceQue
 			})
 
 			*events = append(*events, &models.TestInstanceEvent{
-				TestInstanceID: ti_q.TestInstanceID,
+				TestInstanceID: &ti_q.TestInstanceID,
 				UserID:         userId,
 				OccuredAt:      time.Now(),
 				ReceivedAt:     time.Now(),
@@ -146,3 +156,49 @@ func FindQuestion(ti *models.TestInstance, q_id uint) *models.TestInstanceQuesti
 
 	return nil
 }
+
+func QuestionSwitchedEvent(ti *models.TestInstance, from *models.TestInstanceQuestion, to *models.TestInstanceQuestion, userId uint) *models.TestInstanceEvent {
+	data := map[string]interface{}{
+		"ToQuestionOrder": to.TestQuestion.Order,
+	}
+	if from != nil {
+		data["FromQuestionOrder"] = from.TestQuestion.Order
+	}
+	eventData, _ := json.Marshal(data)
+
+	return &models.TestInstanceEvent{
+		TestInstanceID: &ti.ID,
+		UserID:         userId,
+		OccuredAt:      time.Now(),
+		ReceivedAt:     time.Now(),
+		EventSource:    enums.TestInstanceEventSourceServer,
+		EventType:      enums.TestInstanceEventTypeQuestionSwitched,
+		EventData:      eventData,
+	}
+}
+
+// UpdateQuestionFeedback stores tutor feedback of question. Returns true when feedback with
+// some text was added or changed, so student can be notified.
+func UpdateQuestionFeedback(transaction *gorm.DB, ti_q *models.TestInstanceQuestion, feedback *models.TipTapContent, userId uint) (bool, *common.ErrorResponse) {
+	if feedback == nil || ti_q.Feedback.Equal(feedback) {
+		return false, nil
+	}
+
+	if err := tiptap.FindAndSaveRelations(transaction, userId, feedback, ti_q, "FeedbackFiles"); err != nil {
+		return false, err
+	}
+
+	ti_q.Feedback = feedback
+	if err := transaction.
+		Model(ti_q).
+		Select("feedback").
+		Updates(ti_q).Error; err != nil {
+		return false, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to save feedback for question",
+			Details: err.Error(),
+		}
+	}
+
+	return feedback.HasText(), nil
+}
@@ -23,9 +23,27 @@ func RegisterRoutes(rg *gin.RouterGroup) {
 	rg.POST("courses/:courseId/tests/prepare", wrappers.WithUserDataRole(handlers.TestInstancePrepare))
 	rg.POST("courses/:courseId/tests/evaluate", wrappers.WithUserDataRole(handlers.TestEvaluate))
 
+	rg.GET("courses/:courseId/tests/:courseItemId/:termId/seb", wrappers.WithUserDataRole(handlers.SafeExamBrowserConfig))
+
+	rg.GET("courses/:courseId/tests/:courseItemId/:termId/invalidations", wrappers.WithUserDataRole(handlers.QuestionInvalidationList))
+	rg.POST("courses/:courseId/tests/:courseItemId/:termId/invalidations", wrappers.WithUserDataRole(handlers.QuestionInvalidationInsert))
+	rg.DELETE("courses/:courseId/tests/:courseItemId/:termId/invalidations/:invalidationId", wrappers.WithUserDataRole(handlers.QuestionInvalidationDelete))
+	rg.GET("courses/:courseId/tests/:courseItemId/reevaluations/:jobId", wrappers.WithUserDataRole(handlers.ReevaluationJobGet))
+
+	rg.GET("courses/:courseId/tests/:courseItemId/:termId/grading", wrappers.WithUserDataRole(handlers.GradingList))
+	rg.GET("courses/:courseId/tests/:courseItemId/:termId/grading/:questionId", wrappers.WithUserDataRole(handlers.GradingQueue))
+	rg.POST("courses/:courseId/tests/:courseItemId/:termId/grading/:questionId/split", wrappers.WithUserDataRole(handlers.GradingSplit))
+	rg.PUT("courses/:courseId/tests/:courseItemId/:termId/grading/:questionId/:instanceQuestionId", wrappers.WithUserDataRole(handlers.GradingSave))
+
+	rg.GET("courses/:courseId/tests/:courseItemId/:termId/moderation", wrappers.WithUserDataRole(handlers.ModerationList))
+	rg.POST("courses/:courseId/tests/:courseItemId/:termId/moderation/sample", wrappers.WithUserDataRole(handlers.ModerationSample))
+	rg.PUT("courses/:courseId/tests/:courseItemId/:termId/moderation/:moderationId/mark", wrappers.WithUserDataRole(handlers.ModerationMarkSave))
+	rg.PUT("courses/:courseId/tests/:courseItemId/:termId/moderation/:moderationId/resolve", wrappers.WithUserDataRole(handlers.ModerationResolve))
+
 	rg.GET("tests/:instanceId", wrappers.WithUserDataRole(handlers.TestInstanceGet))
 	rg.PUT("tests/:instanceId/start", wrappers.WithUserDataRole(handlers.TestInstanceStart))
 	rg.PUT("tests/:instanceId/save", wrappers.WithUserDataRole(handlers.TestInstanceSave))
+	rg.PUT("tests/:instanceId/next", wrappers.WithUserDataRole(handlers.TestInstanceNext))
 	rg.PUT("tests/:instanceId/finish", wrappers.WithUserDataRole(handlers.TestInstanceFinish))
 	rg.POST("tests/:instanceId/telemetry", wrappers.WithUserDataRole(handlers.TestInstanceTelemetry))
 }
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"strconv"
+
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"gorm.io/gorm"
+)
+
+type RubricRepository struct{}
+
+func NewRubricRepository() *RubricRepository {
+	return &RubricRepository{}
+}
+
+func (r *RubricRepository) GetRubricByID(
+	dbRef *gorm.DB,
+	courseID uint,
+	rubricID uint,
+	version *uint,
+) (*models.Rubric, *common.ErrorResponse) {
+	var rubric *models.Rubric
+	if err := dbRef.
+		Where("id = ?", rubricID).
+		Where("course_id = ?", courseID).
+		First(&rubric).Error; err != nil {
+		return nil, &common.ErrorResponse{
+			Code:    404,
+			Message: "Failed to load rubric",
+			Details: err.Error(),
+		}
+	}
+
+	if version != nil {
+		if rubric.Version != *version {
+			return nil, &common.ErrorResponse{
+				Code:    409,
+				Message: "Version mismatched",
+				Details: strconv.Itoa(int(*version)) + " " + strconv.Itoa(int(rubric.Version)),
+			}
+		}
+	}
+
+	return rubric, nil
+}
+
+func (r *RubricRepository) ListRubrics(
+	dbRef *gorm.DB,
+	courseID uint,
+	searchParams *common.SearchRequest,
+) ([]*models.Rubric, int64, *common.ErrorResponse) {
+	var err *common.ErrorResponse
+	query := dbRef.
+		Model(models.Rubric{}).
+		Where("course_id = ?", courseID)
+
+	// Apply filters, sorting, pagination
+	if searchParams != nil {
+		query, err = models.Rubric{}.ApplyFilters(query, searchParams.ColumnFilters, models.Rubric{}, map[string]interface{}{}, "")
+		if err != nil {
+			return nil, 0, err
+		}
+		query = models.Rubric{}.ApplySorting(query, searchParams.Sorting, "id DESC")
+	}
+	totalCount := models.Rubric{}.GetCount(query) // Gets count before pagination
+	if searchParams != nil {
+		query = models.Rubric{}.ApplyPagination(query, searchParams.Pagination)
+	}
+
+	var rubrics []*models.Rubric
+	if err := query.
+		Find(&rubrics).Error; err != nil {
+		return nil, 0, &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to fetch rubrics",
+			Details: err.Error(),
+		}
+	}
+
+	return rubrics, totalCount, nil
+}
+
+// ValidateRubricID checks that optional rubric attached to question or activity belongs to course
+func (r *RubricRepository) ValidateRubricID(dbRef *gorm.DB, courseID uint, rubricID *uint) *common.ErrorResponse {
+	if rubricID == nil {
+		return nil
+	}
+
+	var count int64
+	if err := dbRef.
+		Model(&models.Rubric{}).
+		Where("id = ?", *rubricID).
+		Where("course_id = ?", courseID).
+		Count(&count).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load rubric",
+			Details: err.Error(),
+		}
+	}
+	if count == 0 {
+		return &common.ErrorResponse{
+			Code:    422,
+			Message: "Validation failed",
+			Details: "Rubric does not exist in this course",
+			FormErrors: common.ErrorObject{
+				"rubricId": "Rubric does not exist in this course",
+			},
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common"
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"gorm.io/gorm"
+)
+
+type NotificationService struct{}
+
+func NewNotificationService() *NotificationService {
+	return &NotificationService{}
+}
+
+// QueueFeedbackNotification queues email about new tutor feedback. Students without
+// results notifications enabled are skipped.
+func (r *NotificationService) QueueFeedbackNotification(
+	dbRef *gorm.DB,
+	studentID uint,
+	courseItemName string,
+) *common.ErrorResponse {
+	return r.queueResultsNotification(
+		dbRef,
+		studentID,
+		fmt.Sprintf("New feedback: %s", courseItemName),
+		fmt.Sprintf("Your tutor left new feedback on your result of %s.", courseItemName),
+	)
+}
+
+// QueueAppealDecisionNotification queues email about decided regrade request. Students without
+// results notifications enabled are skipped.
+func (r *NotificationService) QueueAppealDecisionNotification(
+	dbRef *gorm.DB,
+	studentID uint,
+	courseItemName string,
+	status enums.AppealStatusEnum,
+) *common.ErrorResponse {
+	decision := "rejected"
+	if status == enums.AppealStatusAccepted {
+		decision = "accepted"
+	}
+
+	return r.queueResultsNotification(
+		dbRef,
+		studentID,
+		fmt.Sprintf("Regrade request %s: %s", decision, courseItemName),
+		fmt.Sprintf("Your regrade request of %s was %s.", courseItemName, decision),
+	)
+}
+
+// QueueReleaseNotification queues email about released visibility levels of a test. Students without
+// results notifications enabled are skipped.
+func (r *NotificationService) QueueReleaseNotification(
+	dbRef *gorm.DB,
+	studentID uint,
+	courseItemName string,
+	levels []enums.ReleaseLevelEnum,
+) *common.ErrorResponse {
+	released := make([]string, len(levels))
+	for i, level := range levels {
+		switch level {
+		case enums.ReleaseLevelResults:
+			released[i] = "results"
+		case enums.ReleaseLevelTest:
+			released[i] = "test content"
+		case enums.ReleaseLevelCorrectness:
+			released[i] = "correct answers"
+		}
+	}
+
+	return r.queueResultsNotification(
+		dbRef,
+		studentID,
+		fmt.Sprintf("Results released: %s", courseItemName),
+		fmt.Sprintf("Your tutor released %s of %s.", strings.Join(released, ", "), courseItemName),
+	)
+}
+
+func (r *NotificationService) queueResultsNotification(
+	dbRef *gorm.DB,
+	studentID uint,
+	subject string,
+	message string,
+) *common.ErrorResponse {
+	var student models.User
+	if err := dbRef.
+		Select("id", "email", "notification").
+		First(&student, studentID).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to load student",
+			Details: err.Error(),
+		}
+	}
+
+	if !student.Notification.Email.Level.Results || student.Email == "" {
+		return nil
+	}
+
+	email := models.Email{
+		ToEmail: student.Email,
+		Subject: subject,
+		Body: fmt.Sprintf(
+			"%s\n\nYou can read it at %s",
+			message,
+			strings.TrimSuffix(initializers.GlobalAppConfig.FRONTEND_URL, "/"),
+		),
+		Status: enums.EmailQueueStatusPending,
+	}
+	if err := dbRef.Create(&email).Error; err != nil {
+		return &common.ErrorResponse{
+			Code:    500,
+			Message: "Failed to queue notification",
+			Details: err.Error(),
+		}
+	}
+
+	return nil
+}
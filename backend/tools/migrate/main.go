@@ -47,13 +47,29 @@ func main() {
 		&models.TestInstanceQuestion{},
 		&models.TestInstanceQuestionAnswer{},
 
+		&models.QuestionInvalidation{},
+		&models.ReevaluationJob{},
+		&models.Rubric{},
+		&models.Moderation{},
+		&models.ModerationMark{},
+		&models.ModerationSample{},
+		&models.TermSeat{},
+		&models.ResultRelease{},
+		&models.PointScaling{},
+		&models.SimilarityJob{},
+		&models.PrintJob{},
+		&models.RenderCache{},
+
 		&models.CourseItemResult{},
 		&models.Class{},
 		&models.ClassStudent{},
 		&models.ClassTutor{},
 		&models.TestInstanceEvent{},
 		&models.ActivityInstance{},
+		&models.Appeal{},
+		&models.AppealComment{},
 		&models.Email{},
+		&models.RecognizerBatch{},
 		&models.RecognizerFile{},
 		&models.LogAccess{},
 		&models.LogError{},
@@ -0,0 +1,140 @@
+// Integration check of concurrent test instance starts against running API and its database.
+//
+// Prepare at least two READY online instances of one student, then run:
+//
+//	go run tools/racecheck/main.go -token at_... -instances 12,13 -workers 20 -reset
+//
+// Check fails when more than one start succeeds or participant ends with more than one ACTIVE instance.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"elogika.vsb.cz/backend/modules/common/enums"
+)
+
+func init() {
+	initializers.LoadEnvVariables()
+	initializers.ConnectToDB(false)
+}
+
+func main() {
+	apiURL := flag.String("api", "http://localhost:8000", "Base URL of running API")
+	token := flag.String("token", "", "Access token of the student owning the instances (without Bearer prefix)")
+	accessCode := flag.String("accesscode", "", "Access code of the term, when required")
+	instancesFlag := flag.String("instances", "", "Comma separated IDs of READY online instances of one student")
+	workers := flag.Int("workers", 10, "Parallel start requests per instance")
+	reset := flag.Bool("reset", false, "Return instances to READY state after check, so it can be repeated")
+	flag.Parse()
+
+	if *token == "" || *instancesFlag == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	instanceIDs := make([]uint, 0)
+	for _, value := range strings.Split(*instancesFlag, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid instance ID %q", value)
+		}
+		instanceIDs = append(instanceIDs, uint(id))
+	}
+
+	var instances []models.TestInstance
+	if err := initializers.DB.Find(&instances, instanceIDs).Error; err != nil {
+		log.Fatal("Failed to load instances: ", err)
+	}
+	if len(instances) != len(instanceIDs) {
+		log.Fatal("Some instances do not exist")
+	}
+	participantID := instances[0].ParticipantID
+	for _, instance := range instances {
+		if instance.ParticipantID != participantID {
+			log.Fatal("All instances must belong to the same participant")
+		}
+		if instance.State != enums.TestInstanceStateReady {
+			log.Fatalf("Instance %d is not in READY state", instance.ID)
+		}
+	}
+
+	body := []byte(fmt.Sprintf(`{"accessCode":%q}`, *accessCode))
+	start := make(chan struct{})
+	statuses := make(map[int]int)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, instanceID := range instanceIDs {
+		for range *workers {
+			wg.Add(1)
+			go func(instanceID uint) {
+				defer wg.Done()
+
+				req, _ := http.NewRequest("PUT", fmt.Sprintf("%s/api/v2/tests/%d/start", strings.TrimSuffix(*apiURL, "/"), instanceID), bytes.NewReader(body))
+				req.Header.Set("Authorization", "Bearer "+*token)
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("X-AS-ROLE", string(enums.CourseUserRoleStudent))
+
+				<-start
+				status := 0
+				res, err := http.DefaultClient.Do(req)
+				if err == nil {
+					status = res.StatusCode
+					res.Body.Close()
+				}
+
+				mutex.Lock()
+				statuses[status]++
+				mutex.Unlock()
+			}(instanceID)
+		}
+	}
+
+	close(start)
+	wg.Wait()
+
+	var activeCount int64
+	if err := initializers.DB.
+		Model(&models.TestInstance{}).
+		Where("participant_id = ?", participantID).
+		Where("state = ?", enums.TestInstanceStateActive).
+		Count(&activeCount).Error; err != nil {
+		log.Fatal("Failed to count active instances: ", err)
+	}
+
+	fmt.Println("Responses by status:", statuses)
+	fmt.Println("Active instances of participant:", activeCount)
+
+	if *reset {
+		if err := initializers.DB.
+			Model(&models.TestInstance{}).
+			Where("id IN ?", instanceIDs).
+			Updates(map[string]interface{}{
+				"state":               enums.TestInstanceStateReady,
+				"current_question_id": nil,
+			}).Error; err != nil {
+			log.Fatal("Failed to reset instances: ", err)
+		}
+	}
+
+	if statuses[200] > 1 || activeCount > 1 {
+		fmt.Println("FAILED: test instance was started more than once")
+		os.Exit(1)
+	}
+	if statuses[200] == 0 {
+		fmt.Println("FAILED: no start succeeded, check token and instance setup")
+		os.Exit(1)
+	}
+
+	fmt.Println("Concurrent starts are safe")
+}
@@ -43,6 +43,12 @@ import (
 
 	supportHandlers "elogika.vsb.cz/backend/modules/support/handlers"
 
+	rubricHandlers "elogika.vsb.cz/backend/modules/rubrics/handlers"
+
+	similarityHandlers "elogika.vsb.cz/backend/modules/similarity/handlers"
+
+	appealHandlers "elogika.vsb.cz/backend/modules/appeals/handlers"
+
 	"github.com/hypersequent/zen"
 	"github.com/tkrajina/typescriptify-golang-structs/typescriptify"
 )
@@ -133,6 +139,11 @@ func main() {
 		Add(courseItemsHandlers.StudentCourseItemListResponse{}).
 		Add(courseItemsHandlers.CourseItemListResultsResponse{}).
 		Add(courseItemsHandlers.CourseItemSelectResultResponse{}).
+		Add(courseItemsHandlers.ScalingRequest{}).
+		Add(courseItemsHandlers.ScalingListResponse{}).
+		Add(courseItemsHandlers.ScalingPreviewResponse{}).
+		Add(courseItemsHandlers.ScalingApplyResponse{}).
+		Add(courseItemsHandlers.ScalingRevertResponse{}).
 		Add(termsHandlers.TermsInsertRequest{}).
 		Add(termsHandlers.TermsInsertResponse{}).
 		Add(termsHandlers.TermsUpdateRequest{}).
@@ -145,12 +156,34 @@ func main() {
 		Add(termsHandlers.TermsLeaveResponse{}).
 		Add(termsHandlers.TermsListRecursiveResponse{}).
 		Add(termsHandlers.ListJoinedStudentsResponse{}).
+		Add(termsHandlers.TermsAccessCodeResponse{}).
 		Add(testHandlers.ListAvailableTestsResponse{}).
 		Add(testHandlers.TestInstancePrepareRequest{}).
 		Add(testHandlers.TestInstancePrepareResponse{}).
+		Add(testHandlers.TestInstanceStartRequest{}).
 		Add(testHandlers.TestInstanceStartResponse{}).
 		Add(testHandlers.TestInstanceGetResponse{}).
 		Add(testHandlers.TestInstanceSaveResponse{}).
+		Add(testHandlers.TestInstanceNextRequest{}).
+		Add(testHandlers.TestInstanceNextResponse{}).
+		Add(testHandlers.QuestionInvalidationInsertRequest{}).
+		Add(testHandlers.QuestionInvalidationInsertResponse{}).
+		Add(testHandlers.QuestionInvalidationListResponse{}).
+		Add(testHandlers.QuestionInvalidationDeleteResponse{}).
+		Add(testHandlers.ReevaluationJobGetResponse{}).
+		Add(testHandlers.GradingListResponse{}).
+		Add(testHandlers.GradingQueueResponse{}).
+		Add(testHandlers.GradingSaveRequest{}).
+		Add(testHandlers.GradingSaveResponse{}).
+		Add(testHandlers.GradingSplitRequest{}).
+		Add(testHandlers.GradingSplitResponse{}).
+		Add(testHandlers.ModerationListResponse{}).
+		Add(testHandlers.ModerationSampleRequest{}).
+		Add(testHandlers.ModerationSampleResponse{}).
+		Add(testHandlers.ModerationMarkRequest{}).
+		Add(testHandlers.ModerationMarkResponse{}).
+		Add(testHandlers.ModerationResolveRequest{}).
+		Add(testHandlers.ModerationResolveResponse{}).
 		Add(testHandlers.TestInstanceTutorSaveRequest{}).
 		Add(testHandlers.TestInstanceTutorSaveResponse{}).
 		Add(testHandlers.TestListResponse{}).
@@ -184,6 +217,9 @@ func main() {
 		Add(classHandlers.RemoveTutorResponse{}).
 		Add(classHandlers.ClassImportStudentsResponse{}).
 		Add(printHandlers.PrintTestRequest{}).
+		Add(printHandlers.PrintTestResponse{}).
+		Add(printHandlers.PrintJobListResponse{}).
+		Add(printHandlers.PrintJobGetResponse{}).
 		Add(activityHandlers.ListAvailableActivitiesResponse{}).
 		Add(activityHandlers.ActivityInstanceGetResponse{}).
 		Add(activityHandlers.ActivityInstanceSaveRequest{}).
@@ -201,6 +237,25 @@ func main() {
 		Add(supportHandlers.SupportTicketGetByIdResponse{}).
 		Add(supportHandlers.SupportTicketCommentInsertRequest{}).
 		Add(supportHandlers.SupportTicketCommentInsertResponse{}).
+		Add(rubricHandlers.RubricListResponse{}).
+		Add(rubricHandlers.RubricGetByIdResponse{}).
+		Add(rubricHandlers.RubricInsertRequest{}).
+		Add(rubricHandlers.RubricInsertResponse{}).
+		Add(rubricHandlers.RubricUpdateRequest{}).
+		Add(rubricHandlers.RubricUpdateResponse{}).
+		Add(rubricHandlers.RubricDeleteResponse{}).
+		Add(similarityHandlers.SimilarityInsertRequest{}).
+		Add(similarityHandlers.SimilarityInsertResponse{}).
+		Add(similarityHandlers.SimilarityListResponse{}).
+		Add(similarityHandlers.SimilarityGetByIdResponse{}).
+		Add(appealHandlers.AppealListResponse{}).
+		Add(appealHandlers.AppealGetByIdResponse{}).
+		Add(appealHandlers.AppealInsertRequest{}).
+		Add(appealHandlers.AppealInsertResponse{}).
+		Add(appealHandlers.AppealCommentInsertRequest{}).
+		Add(appealHandlers.AppealCommentInsertResponse{}).
+		Add(appealHandlers.AppealDecisionRequest{}).
+		Add(appealHandlers.AppealDecisionResponse{}).
 		Add(common.ErrorResponse{})
 
 	// TODO: maybe remove once handlers exists
@@ -225,7 +280,16 @@ func main() {
 		AddEnum(enums.WeekDayEnumAll).
 		AddEnum(enums.WeekParityEnumAll).
 		AddEnum(enums.TestInstanceFormEnumAll).
-		AddEnum(enums.EvaluateByAttemptEnumAll)
+		AddEnum(enums.EvaluateByAttemptEnumAll).
+		AddEnum(enums.TestNavigationModeEnumAll).
+		AddEnum(enums.ScoringStrategyEnumAll).
+		AddEnum(enums.QuestionInvalidationModeEnumAll).
+		AddEnum(enums.JobStatusEnumAll).
+		AddEnum(enums.ModerationStatusEnumAll).
+		AddEnum(enums.AppealStatusEnumAll).
+		AddEnum(enums.ReleaseModeEnumAll).
+		AddEnum(enums.ReleaseLevelEnumAll).
+		AddEnum(enums.ScalingMethodEnumAll)
 
 	err := converter.ConvertToFile(frontendPath + "/src/lib/api_types.ts")
 	if err != nil {
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"log"
+	"time"
+
+	"elogika.vsb.cz/backend/modules/common/enums"
+	"gorm.io/gorm"
+)
+
+// Background jobs (print, reevaluation, similarity, recognizer) are rows with status, error and updated_at columns.
+// They are started right after the request creates them, jobs left pending are picked up by cron.
+
+// Running job which was not updated for this long was interrupted, e.g. by server restart
+const StaleJobAge = 3 * time.Hour
+
+// ClaimJob switches pending job to running. Only one caller succeeds, so job started both by request and cron
+// runs once.
+func ClaimJob(dbRef *gorm.DB, model any, jobID uint) bool {
+	result := dbRef.
+		Model(model).
+		Where("id = ?", jobID).
+		Where("status = ?", enums.JobStatusPending).
+		Update("status", enums.JobStatusRunning)
+	return result.Error == nil && result.RowsAffected == 1
+}
+
+// PendingJobIDs returns IDs of jobs waiting to be started in given order
+func PendingJobIDs(dbRef *gorm.DB, model any, order string) []uint {
+	var jobIDs []uint
+	if err := dbRef.
+		Model(model).
+		Where("status = ?", enums.JobStatusPending).
+		Order(order).
+		Pluck("id", &jobIDs).Error; err != nil {
+		log.Println("Failed to load pending jobs", err)
+		return nil
+	}
+	return jobIDs
+}
+
+// FailStaleJobs fails running jobs which were interrupted, so they do not stay running forever
+func FailStaleJobs(dbRef *gorm.DB, model any, message string) {
+	now := time.Now()
+	values := map[string]any{
+		"status": enums.JobStatusFailed,
+		"error":  message,
+	}
+
+	statement := &gorm.Statement{DB: dbRef}
+	if err := statement.Parse(model); err == nil && statement.Schema.LookUpField("FinishedAt") != nil {
+		values["finished_at"] = now
+	}
+
+	if err := dbRef.
+		Model(model).
+		Where("status = ?", enums.JobStatusRunning).
+		Where("updated_at < ?", now.Add(-StaleJobAge)).
+		Updates(values).Error; err != nil {
+		log.Println("Failed to fail interrupted jobs", err)
+	}
+}
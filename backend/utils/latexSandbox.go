@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"github.com/google/uuid"
+)
+
+const (
+	// Directory of sandbox containing copied assets referenced by LaTeX source
+	latexAssetDir = "assets"
+	// Comment marking start of part of generated LaTeX code, compile errors are reported with the nearest marker
+	latexSourceMarker = "% source: "
+	// Number of lines from the end of compile log kept in error
+	latexLogTail = 30
+)
+
+var (
+	latexFileLineError = regexp.MustCompile(`^(?:\./)?document\.tex:(\d+): (.*)$`)
+	latexError         = regexp.MustCompile(`^! (.*)$`)
+	latexErrorContext  = regexp.MustCompile(`^l\.(\d+) (.*)$`)
+)
+
+// LaTeXError is structured compile log of failed LaTeX compilation
+type LaTeXError struct {
+	Message string   `json:"message"` // First error reported by LaTeX
+	Line    int      `json:"line"`    // Line of generated source, 0 when unknown
+	Context string   `json:"context"` // Part of the line where compilation stopped
+	Source  string   `json:"source"`  // Part of the document (e.g. question) containing the line
+	Timeout bool     `json:"timeout"`
+	Log     []string `json:"log"` // End of compile log
+}
+
+func (e *LaTeXError) Error() string {
+	message := "LaTeX compilation failed"
+	if e.Source != "" {
+		message += " in " + e.Source
+	}
+	if e.Line > 0 {
+		message += " at line " + strconv.Itoa(e.Line)
+	}
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+	return message
+}
+
+// LaTeXSourceMarker returns comment marking start of part of generated LaTeX code, e.g. question. When compilation
+// fails, the error names the part containing the offending line.
+func LaTeXSourceMarker(label string) string {
+	label = strings.Join(strings.Fields(label), " ")
+	return "\n" + latexSourceMarker + label + "\n"
+}
+
+// LaTeXAssetPath returns path under which LaTeX source references file of asset directory
+func LaTeXAssetPath(name string) string {
+	return latexAssetDir + "/" + name
+}
+
+// RunLaTeX compiles LaTeX source with given engine (pdflatex or latex) in throwaway directory. Files of asset directory
+// referenced by LaTeXAssetPath are copied to the directory, so compilation can neither read nor write anything outside
+// of it. Compilation runs without shell escape and is limited in time and memory. Produced file with given extension
+// is moved to output directory, its path is returned. Compile errors are returned as *LaTeXError.
+func RunLaTeX(engine string, source string, assetDir string, outputDir string, outputExt string) (string, error) {
+	sandboxDir, err := os.MkdirTemp(outputDir, "latex-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(sandboxDir)
+
+	if err := os.WriteFile(filepath.Join(sandboxDir, "document.tex"), []byte(source), 0644); err != nil {
+		return "", err
+	}
+
+	if assetDir != "" {
+		if err := copyLaTeXAssets(source, assetDir, filepath.Join(sandboxDir, latexAssetDir)); err != nil {
+			return "", err
+		}
+	}
+
+	// Paranoid mode denies absolute paths and parent directories for both reading and writing
+	env := append(os.Environ(),
+		"openin_any=p",
+		"openout_any=p",
+		"shell_escape=f",
+		"max_print_line=10000",
+	)
+
+	err = RunSandboxed(sandboxDir, env, engine,
+		"-interaction=batchmode",
+		"-halt-on-error",
+		"-file-line-error",
+		"-no-shell-escape",
+		"document.tex",
+	)
+	if err != nil {
+		latexErr := parseLaTeXLog(filepath.Join(sandboxDir, "document.log"), source)
+		latexErr.Timeout = errors.Is(err, context.DeadlineExceeded)
+		if latexErr.Timeout {
+			latexErr.Message = "compilation timed out"
+		} else if latexErr.Message == "" {
+			latexErr.Message = err.Error()
+		}
+		return "", latexErr
+	}
+
+	outputPath := filepath.Join(outputDir, "output"+uuid.NewString()+outputExt)
+	if err := os.Rename(filepath.Join(sandboxDir, "document"+outputExt), outputPath); err != nil {
+		return "", err
+	}
+
+	return outputPath, nil
+}
+
+// copyLaTeXAssets copies files of asset directory referenced by source. Files are copied, not linked, so that writes
+// of one compilation cannot change assets shared with others.
+func copyLaTeXAssets(source string, assetDir string, destination string) error {
+	entries, err := os.ReadDir(assetDir)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(destination, 0755); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.Contains(source, LaTeXAssetPath(entry.Name())) {
+			continue
+		}
+		if err := CopyFile(filepath.Join(assetDir, entry.Name()), filepath.Join(destination, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunSandboxed runs external program in given directory with timeout and, except for Windows, CPU and memory limits.
+// Returns context.DeadlineExceeded when the program was killed for timeout.
+func RunSandboxed(dir string, env []string, name string, args ...string) error {
+	timeout := 2 * time.Minute
+	memoryMB := int64(2048)
+	if initializers.GlobalAppConfig != nil {
+		if initializers.GlobalAppConfig.LATEX_TIMEOUT > 0 {
+			timeout = initializers.GlobalAppConfig.LATEX_TIMEOUT
+		}
+		if initializers.GlobalAppConfig.LATEX_MEMORY_MB > 0 {
+			memoryMB = initializers.GlobalAppConfig.LATEX_MEMORY_MB
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, name, args...)
+	} else {
+		// Limits are set by shell which is then replaced by the program, so they apply only to it
+		cpuSeconds := int64(timeout.Seconds()) + 1
+		cmd = exec.CommandContext(ctx, "sh", append([]string{
+			"-c", `ulimit -v "$1" && ulimit -t "$2" && shift 2 && exec "$@"`,
+			"sandbox",
+			strconv.FormatInt(memoryMB*1024, 10),
+			strconv.FormatInt(cpuSeconds, 10),
+			name,
+		}, args...)...)
+	}
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = nil
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	cmd.WaitDelay = 5 * time.Second
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+	return nil
+}
+
+// parseLaTeXLog finds the first error in compile log and the part of source containing it
+func parseLaTeXLog(logPath string, source string) *LaTeXError {
+	latexErr := &LaTeXError{
+		Log: []string{},
+	}
+
+	file, err := os.Open(logPath)
+	if err != nil {
+		return latexErr
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		latexErr.Log = append(latexErr.Log, line)
+		if len(latexErr.Log) > latexLogTail {
+			latexErr.Log = latexErr.Log[1:]
+		}
+
+		if latexErr.Message == "" {
+			if match := latexFileLineError.FindStringSubmatch(line); match != nil {
+				latexErr.Line, _ = strconv.Atoi(match[1])
+				latexErr.Message = match[2]
+				continue
+			}
+			if match := latexError.FindStringSubmatch(line); match != nil {
+				latexErr.Message = match[1]
+				continue
+			}
+		} else if latexErr.Context == "" {
+			if match := latexErrorContext.FindStringSubmatch(line); match != nil {
+				if latexErr.Line == 0 {
+					latexErr.Line, _ = strconv.Atoi(match[1])
+				}
+				latexErr.Context = strings.TrimSpace(match[2])
+			}
+		}
+	}
+
+	latexErr.Source = latexSourceAt(source, latexErr.Line)
+	return latexErr
+}
+
+// latexSourceAt returns label of the nearest source marker before the line
+func latexSourceAt(source string, line int) string {
+	if line <= 0 {
+		return ""
+	}
+
+	label := ""
+	for i, sourceLine := range strings.Split(source, "\n") {
+		if i+1 > line {
+			break
+		}
+		if strings.HasPrefix(strings.TrimSpace(sourceLine), latexSourceMarker) {
+			label = strings.TrimPrefix(strings.TrimSpace(sourceLine), latexSourceMarker)
+		}
+	}
+	return label
+}
@@ -3,14 +3,24 @@ package utils
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
-	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
 	"gorm.io/gorm"
 )
 
-func ConvertCodeToImage(dbRef *gorm.DB, code string) (string, int64, error) {
+// ConvertCodeToImage renders LaTeX code to SVG stored in uploads. Identical code is rendered only once, further calls
+// return the cached file.
+func ConvertCodeToImage(dbRef *gorm.DB, userID uint, code string) (*models.File, error) {
+	contentHash, err := models.TipTapContent{Type: "text", Text: code}.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	if cached := GetCachedRender(dbRef, RenderKindSVG, contentHash, SVGTemplateVersion); cached != nil {
+		return cached.File, nil
+	}
+
 	const latexTemplate = `
 	\documentclass[varwidth, border=4mm]{standalone}
 	\usepackage[utf8]{inputenc}
@@ -34,59 +44,37 @@ func ConvertCodeToImage(dbRef *gorm.DB, code string) (string, int64, error) {
 	\end{document}
 	`
 
-	latexCode := fmt.Sprintf(latexTemplate, code)
+	latexCode := fmt.Sprintf(latexTemplate, LaTeXSourceMarker("code block")+code)
 
 	// Create temp folder
 	workDir, err := os.Getwd()
 	if err != nil {
-		return "", 0, err
+		return nil, err
 	}
 
 	tmpDir, err := CreateTmpFolder(workDir)
 	if err != nil {
-		return "", 0, err
+		return nil, err
 	}
 
-	// Write LaTeX code to file
-	texFile := tmpDir + "/output.tex"
-	err = os.WriteFile(texFile, []byte(latexCode), 0644)
-	if err != nil {
-		return "", 0, err
-	}
+	defer os.RemoveAll(tmpDir)
 
-	// Run pdflatex
-	cmd := exec.Command("latex", "-interaction=batchmode", "-output-directory="+tmpDir+"/", texFile)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err = cmd.Run()
+	// Compile in sandbox
+	dviPath, err := RunLaTeX("latex", latexCode, "", tmpDir, ".dvi")
 	if err != nil {
-		return "", 0, err
+		return nil, err
 	}
 
 	// Convert to SVG
-	cmd = exec.Command("dvisvgm", "--no-fonts", tmpDir+"/output.dvi", "-o", tmpDir+"/output.svg")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err = cmd.Run()
-	if err != nil {
-		return "", 0, err
-	}
-
-	//Rename and copy to uploads folder
-	newFileName, err := GenerateFileName(dbRef, ".svg")
-	if err != nil {
-		return "", 0, err
-	}
-
-	err = CopyFile(tmpDir+"/output.svg", filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, newFileName))
-	if err != nil {
-		return "", 0, err
+	svgPath := filepath.Join(tmpDir, "output.svg")
+	if err := RunSandboxed(tmpDir, os.Environ(), "dvisvgm", "--no-fonts", dviPath, "-o", svgPath); err != nil {
+		return nil, err
 	}
 
-	fileInfo, err := os.Stat(filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, newFileName))
+	cached, err := StoreCachedRender(dbRef, RenderKindSVG, contentHash, SVGTemplateVersion, svgPath, "image/svg", 0, userID)
 	if err != nil {
-		return "", 0, err
+		return nil, err
 	}
 
-	return newFileName, fileInfo.Size(), nil
+	return cached.File, nil
 }
@@ -0,0 +1,168 @@
+// Package omr reads scanned paper forms. It binarizes page images, finds printed registration
+// marks, measures how much of a mark box is filled and decodes QR codes. It has no external
+// dependencies besides the standard image decoders, so recognition runs fully inside the backend.
+package omr
+
+import (
+	"image"
+	"math"
+)
+
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Bitmap is binarized image, true marks dark pixel
+type Bitmap struct {
+	Width  int
+	Height int
+	Pix    []bool
+}
+
+// Binarize converts image to grayscale and splits pixels to dark and light by Otsu threshold
+func Binarize(img image.Image) *Bitmap {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	gray := make([]uint8, w*h)
+
+	switch src := img.(type) {
+	case *image.Gray:
+		for y := 0; y < h; y++ {
+			copy(gray[y*w:(y+1)*w], src.Pix[y*src.Stride:y*src.Stride+w])
+		}
+	case *image.YCbCr:
+		for y := 0; y < h; y++ {
+			copy(gray[y*w:(y+1)*w], src.Y[y*src.YStride:y*src.YStride+w])
+		}
+	default:
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+				gray[y*w+x] = uint8((19595*r + 38470*g + 7471*b + 1<<15) >> 24)
+			}
+		}
+	}
+
+	threshold := otsuThreshold(gray)
+	bitmap := &Bitmap{
+		Width:  w,
+		Height: h,
+		Pix:    make([]bool, w*h),
+	}
+	for i, v := range gray {
+		bitmap.Pix[i] = v <= threshold
+	}
+
+	return bitmap
+}
+
+func otsuThreshold(gray []uint8) uint8 {
+	var histogram [256]int
+	for _, v := range gray {
+		histogram[v]++
+	}
+
+	total := float64(len(gray))
+	sum := float64(0)
+	for i, count := range histogram {
+		sum += float64(i * count)
+	}
+
+	best := uint8(127)
+	bestVariance := float64(-1)
+	sumBackground := float64(0)
+	weightBackground := float64(0)
+	for i, count := range histogram {
+		weightBackground += float64(count)
+		if weightBackground == 0 {
+			continue
+		}
+		weightForeground := total - weightBackground
+		if weightForeground == 0 {
+			break
+		}
+
+		sumBackground += float64(i * count)
+		meanBackground := sumBackground / weightBackground
+		meanForeground := (sum - sumBackground) / weightForeground
+
+		variance := weightBackground * weightForeground * (meanBackground - meanForeground) * (meanBackground - meanForeground)
+		if variance > bestVariance {
+			bestVariance = variance
+			best = uint8(i)
+		}
+	}
+
+	return best
+}
+
+// Dark reports whether pixel is dark, pixels outside of bitmap are light
+func (b *Bitmap) Dark(x int, y int) bool {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return false
+	}
+	return b.Pix[y*b.Width+x]
+}
+
+// DarkAt reports whether pixel containing point is dark
+func (b *Bitmap) DarkAt(p Point) bool {
+	return b.Dark(int(math.Floor(p.X)), int(math.Floor(p.Y)))
+}
+
+// Crop returns copy of bitmap limited to rectangle
+func (b *Bitmap) Crop(r image.Rectangle) *Bitmap {
+	r = r.Intersect(image.Rect(0, 0, b.Width, b.Height))
+	cropped := &Bitmap{
+		Width:  r.Dx(),
+		Height: r.Dy(),
+		Pix:    make([]bool, r.Dx()*r.Dy()),
+	}
+	for y := 0; y < cropped.Height; y++ {
+		start := (r.Min.Y+y)*b.Width + r.Min.X
+		copy(cropped.Pix[y*cropped.Width:(y+1)*cropped.Width], b.Pix[start:start+cropped.Width])
+	}
+
+	return cropped
+}
+
+// Fill returns share of dark pixels inside rectangle of source plane, the rectangle is sampled in n x n grid
+// mapped to the bitmap by transform
+func (b *Bitmap) Fill(t Transform, x float64, y float64, w float64, h float64, n int) float64 {
+	dark := 0
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			p := t.Apply(Point{
+				X: x + (float64(j)+0.5)*w/float64(n),
+				Y: y + (float64(i)+0.5)*h/float64(n),
+			})
+			if b.DarkAt(p) {
+				dark++
+			}
+		}
+	}
+
+	return float64(dark) / float64(n*n)
+}
+
+// Bounds returns smallest rectangle of bitmap containing rectangle of source plane mapped by transform
+func (b *Bitmap) Bounds(t Transform, x float64, y float64, w float64, h float64) image.Rectangle {
+	corners := []Point{
+		t.Apply(Point{X: x, Y: y}),
+		t.Apply(Point{X: x + w, Y: y}),
+		t.Apply(Point{X: x + w, Y: y + h}),
+		t.Apply(Point{X: x, Y: y + h}),
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, c := range corners {
+		minX = math.Min(minX, c.X)
+		minY = math.Min(minY, c.Y)
+		maxX = math.Max(maxX, c.X)
+		maxY = math.Max(maxY, c.Y)
+	}
+
+	return image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY))).
+		Intersect(image.Rect(0, 0, b.Width, b.Height))
+}
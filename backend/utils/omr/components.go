@@ -0,0 +1,92 @@
+package omr
+
+import "image"
+
+// Component is connected group of dark pixels
+type Component struct {
+	Bounds image.Rectangle
+	Pixels int
+
+	// Extreme pixels, for rectangular marks these are their corners
+	TopLeft     Point
+	TopRight    Point
+	BottomRight Point
+	BottomLeft  Point
+}
+
+// Components finds 8-connected groups of dark pixels with at least minPixels pixels
+func Components(b *Bitmap, minPixels int) []Component {
+	visited := make([]bool, len(b.Pix))
+	stack := make([]int, 0, 1024)
+	result := make([]Component, 0)
+
+	for start, dark := range b.Pix {
+		if !dark || visited[start] {
+			continue
+		}
+
+		visited[start] = true
+		stack = append(stack[:0], start)
+
+		x0, y0 := start%b.Width, start/b.Width
+		component := Component{
+			Bounds: image.Rect(x0, y0, x0+1, y0+1),
+		}
+		var tl, tr, br, bl Point
+		tlScore, trScore, brScore, blScore := x0+y0, x0-y0, x0+y0, x0-y0
+		tl = Point{X: float64(x0) + 0.5, Y: float64(y0) + 0.5}
+		tr, br, bl = tl, tl, tl
+
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			x, y := i%b.Width, i/b.Width
+			p := Point{X: float64(x) + 0.5, Y: float64(y) + 0.5}
+
+			component.Pixels++
+			component.Bounds = component.Bounds.Union(image.Rect(x, y, x+1, y+1))
+			if x+y < tlScore {
+				tlScore, tl = x+y, p
+			}
+			if x+y > brScore {
+				brScore, br = x+y, p
+			}
+			if x-y > trScore {
+				trScore, tr = x-y, p
+			}
+			if x-y < blScore {
+				blScore, bl = x-y, p
+			}
+
+			for dy := -1; dy <= 1; dy++ {
+				ny := y + dy
+				if ny < 0 || ny >= b.Height {
+					continue
+				}
+				for dx := -1; dx <= 1; dx++ {
+					nx := x + dx
+					if nx < 0 || nx >= b.Width {
+						continue
+					}
+					n := ny*b.Width + nx
+					if b.Pix[n] && !visited[n] {
+						visited[n] = true
+						stack = append(stack, n)
+					}
+				}
+			}
+		}
+
+		if component.Pixels < minPixels {
+			continue
+		}
+
+		component.TopLeft = tl
+		component.TopRight = tr
+		component.BottomRight = br
+		component.BottomLeft = bl
+		result = append(result, component)
+	}
+
+	return result
+}
@@ -0,0 +1,139 @@
+package omr
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	_ "golang.org/x/image/tiff"
+)
+
+// LoadPages decodes scanned pages from PDF or single image file. Scanned PDFs contain one image per page,
+// the largest image of every page is used and pages without image are returned as nil.
+func LoadPages(data []byte, fileName string) ([]image.Image, error) {
+	ext := strings.ToLower(filepath.Ext(fileName))
+
+	if ext != ".pdf" {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode image: %w", err)
+		}
+		return []image.Image{img}, nil
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationRelaxed
+	pageImages, err := api.ExtractImagesRaw(bytes.NewReader(data), nil, conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pdf: %w", err)
+	}
+
+	pages := make([]image.Image, len(pageImages))
+	for i, images := range pageImages {
+		for _, raw := range images {
+			img, _, err := image.Decode(raw)
+			if err != nil {
+				continue
+			}
+			if pages[i] == nil || area(img.Bounds()) > area(pages[i].Bounds()) {
+				pages[i] = img
+			}
+		}
+	}
+
+	return pages, nil
+}
+
+// PageFile is single scanned page split from larger upload, Ext is extension of its file format
+type PageFile struct {
+	Data []byte
+	Ext  string
+}
+
+// SplitPages splits multi-page PDF to single page PDFs or zip archive to contained images ordered by name.
+// Any other file is returned as single page.
+func SplitPages(data []byte, fileName string) ([]PageFile, error) {
+	ext := strings.ToLower(filepath.Ext(fileName))
+
+	switch ext {
+	case ".pdf":
+		conf := model.NewDefaultConfiguration()
+		conf.ValidationMode = model.ValidationRelaxed
+		spans, err := api.SplitRaw(bytes.NewReader(data), 1, conf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to split pdf: %w", err)
+		}
+
+		pages := make([]PageFile, len(spans))
+		for i, span := range spans {
+			pageData, err := io.ReadAll(span.Reader)
+			if err != nil {
+				return nil, fmt.Errorf("failed to split pdf: %w", err)
+			}
+			pages[i] = PageFile{Data: pageData, Ext: ext}
+		}
+		return pages, nil
+	case ".zip":
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zip: %w", err)
+		}
+
+		entries := make([]*zip.File, 0, len(archive.File))
+		for _, entry := range archive.File {
+			if entry.FileInfo().IsDir() || strings.HasPrefix(filepath.Base(entry.Name), ".") {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+
+		pages := make([]PageFile, 0, len(entries))
+		for _, entry := range entries {
+			entryExt := strings.ToLower(filepath.Ext(entry.Name))
+			if entryExt == ".zip" {
+				continue
+			}
+
+			reader, err := entry.Open()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read zip entry %s: %w", entry.Name, err)
+			}
+			pageData, err := io.ReadAll(reader)
+			reader.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read zip entry %s: %w", entry.Name, err)
+			}
+
+			// Scans stored as PDFs inside the archive are split as well
+			if entryExt == ".pdf" {
+				split, err := SplitPages(pageData, entry.Name)
+				if err != nil {
+					return nil, err
+				}
+				pages = append(pages, split...)
+				continue
+			}
+
+			pages = append(pages, PageFile{Data: pageData, Ext: entryExt})
+		}
+		return pages, nil
+	default:
+		return []PageFile{{Data: data, Ext: ext}}, nil
+	}
+}
+
+func area(r image.Rectangle) int {
+	return r.Dx() * r.Dy()
+}
@@ -0,0 +1,379 @@
+package omr
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+var ErrQRNotFound = errors.New("QR code not found")
+var ErrQRUnreadable = errors.New("QR code could not be decoded")
+
+// DecodeQR finds single QR code in bitmap and returns its content, the bitmap should be cropped around the code
+func DecodeQR(b *Bitmap) (string, error) {
+	found, ok := selectFinders(findFinders(b))
+	if !ok {
+		return "", ErrQRNotFound
+	}
+
+	moduleSize := (found[0].moduleSize + found[1].moduleSize + found[2].moduleSize) / 3
+	modules := (distance(found[0].center, found[1].center) + distance(found[0].center, found[2].center)) / 2 / moduleSize
+	estimated := int(math.Round((modules + 7 - 17) / 4))
+
+	// Module size estimate is not exact, so neighbouring versions are tried as well
+	for _, version := range []int{estimated, estimated + 1, estimated - 1, estimated + 2, estimated - 2} {
+		if version < 1 || version > 40 {
+			continue
+		}
+
+		content, err := decodeQRVersion(b, found, version)
+		if err == nil {
+			return content, nil
+		}
+	}
+
+	return "", ErrQRUnreadable
+}
+
+func decodeQRVersion(b *Bitmap, found [3]*finder, version int) (string, error) {
+	modules := sampleQR(b, found, version)
+	if modules == nil {
+		return "", ErrQRUnreadable
+	}
+
+	// Larger symbols carry their version, prefer it over the estimate
+	if version >= 7 {
+		if encoded, ok := readQRVersion(modules); ok && encoded != version {
+			modules = sampleQR(b, found, encoded)
+			if modules == nil {
+				return "", ErrQRUnreadable
+			}
+			version = encoded
+		}
+	}
+
+	level, mask, ok := readQRFormat(modules)
+	if !ok {
+		return "", ErrQRUnreadable
+	}
+
+	codewords := readQRCodewords(modules, version, mask)
+	data, ok := correctQRCodewords(codewords, version, level)
+	if !ok {
+		return "", ErrQRUnreadable
+	}
+
+	return parseQRData(data, version)
+}
+
+func sampleQR(b *Bitmap, found [3]*finder, version int) [][]bool {
+	transform := qrGridTransform(b, found, version)
+	if transform == nil {
+		return nil
+	}
+
+	size := version*4 + 17
+	modules := make([][]bool, size)
+	for y := 0; y < size; y++ {
+		modules[y] = make([]bool, size)
+		for x := 0; x < size; x++ {
+			modules[y][x] = b.DarkAt(transform.Apply(Point{X: float64(x) + 0.5, Y: float64(y) + 0.5}))
+		}
+	}
+
+	return modules
+}
+
+// readQRFormat reads error correction level and mask from either copy of format information
+func readQRFormat(modules [][]bool) (int, int, bool) {
+	size := len(modules)
+	bit := func(x int, y int, value int) int {
+		value <<= 1
+		if modules[y][x] {
+			value |= 1
+		}
+		return value
+	}
+
+	first := 0
+	for x := 0; x < 6; x++ {
+		first = bit(x, 8, first)
+	}
+	first = bit(7, 8, first)
+	first = bit(8, 8, first)
+	first = bit(8, 7, first)
+	for y := 5; y >= 0; y-- {
+		first = bit(8, y, first)
+	}
+
+	second := 0
+	for y := size - 1; y >= size-7; y-- {
+		second = bit(8, y, second)
+	}
+	for x := size - 8; x < size; x++ {
+		second = bit(x, 8, second)
+	}
+
+	best, bestDistance := 0, 16
+	for data, code := range qrFormatCodes {
+		for _, read := range []int{first, second} {
+			if d := bits.OnesCount(uint(read ^ code)); d < bestDistance {
+				best, bestDistance = data, d
+			}
+		}
+	}
+	if bestDistance > 3 {
+		return 0, 0, false
+	}
+
+	return qrLevelFromFormat[best>>3], best & 7, true
+}
+
+// readQRVersion reads version information stored next to top right and bottom left finder
+func readQRVersion(modules [][]bool) (int, bool) {
+	size := len(modules)
+
+	topRight, bottomLeft := 0, 0
+	for i := 5; i >= 0; i-- {
+		for j := size - 9; j >= size-11; j-- {
+			topRight <<= 1
+			if modules[i][j] {
+				topRight |= 1
+			}
+			bottomLeft <<= 1
+			if modules[j][i] {
+				bottomLeft |= 1
+			}
+		}
+	}
+
+	best, bestDistance := 0, 19
+	for version := 7; version <= 40; version++ {
+		for _, read := range []int{topRight, bottomLeft} {
+			if d := bits.OnesCount(uint(read ^ qrVersionCodes[version])); d < bestDistance {
+				best, bestDistance = version, d
+			}
+		}
+	}
+	if bestDistance > 3 {
+		return 0, false
+	}
+
+	return best, true
+}
+
+// readQRCodewords reads data modules in two column wide zigzag from bottom right corner and removes mask
+func readQRCodewords(modules [][]bool, version int, mask int) []byte {
+	size := len(modules)
+	function := qrFunctionPattern(version)
+
+	result := make([]byte, 0, size*size/8)
+	current, count := byte(0), 0
+	up := true
+	for x := size - 1; x > 0; x -= 2 {
+		if x == 6 {
+			x--
+		}
+		for i := 0; i < size; i++ {
+			y := i
+			if up {
+				y = size - 1 - i
+			}
+			for col := 0; col < 2; col++ {
+				if function[y][x-col] {
+					continue
+				}
+				current <<= 1
+				if modules[y][x-col] != qrMasked(mask, y, x-col) {
+					current |= 1
+				}
+				count++
+				if count == 8 {
+					result = append(result, current)
+					current, count = 0, 0
+				}
+			}
+		}
+		up = !up
+	}
+
+	return result
+}
+
+// correctQRCodewords splits interleaved codewords to blocks, corrects them and joins their data codewords
+func correctQRCodewords(codewords []byte, version int, level int) ([]byte, bool) {
+	spec := qrBlocks[version-1][level]
+	blockCount := spec.count1 + spec.count2
+
+	dataLengths := make([]int, blockCount)
+	total := 0
+	for i := range dataLengths {
+		dataLengths[i] = spec.data1
+		if i >= spec.count1 {
+			dataLengths[i] = spec.data2
+		}
+		total += dataLengths[i] + spec.ecPerBlock
+	}
+	if len(codewords) < total {
+		return nil, false
+	}
+
+	blocks := make([][]byte, blockCount)
+	for i := range blocks {
+		blocks[i] = make([]byte, 0, dataLengths[i]+spec.ecPerBlock)
+	}
+
+	// Data codewords are interleaved first, longer blocks of second group have one extra codeword
+	offset := 0
+	maxData := max(spec.data1, spec.data2)
+	for i := 0; i < maxData; i++ {
+		for j := range blocks {
+			if i < dataLengths[j] {
+				blocks[j] = append(blocks[j], codewords[offset])
+				offset++
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for j := range blocks {
+			blocks[j] = append(blocks[j], codewords[offset])
+			offset++
+		}
+	}
+
+	data := make([]byte, 0, total)
+	for j, block := range blocks {
+		if !rsCorrect(block, spec.ecPerBlock) {
+			return nil, false
+		}
+		data = append(data, block[:dataLengths[j]]...)
+	}
+
+	return data, true
+}
+
+type qrBitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *qrBitReader) available() int {
+	return len(r.data)*8 - r.pos
+}
+
+func (r *qrBitReader) read(n int) int {
+	value := 0
+	for i := 0; i < n && r.pos < len(r.data)*8; i++ {
+		value <<= 1
+		if r.data[r.pos/8]&(0x80>>(r.pos%8)) != 0 {
+			value |= 1
+		}
+		r.pos++
+	}
+	return value
+}
+
+const qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// parseQRData decodes numeric, alphanumeric and byte segments, kanji mode is not supported
+func parseQRData(data []byte, version int) (string, error) {
+	sizeClass := 0
+	if version >= 27 {
+		sizeClass = 2
+	} else if version >= 10 {
+		sizeClass = 1
+	}
+	numericBits := [3]int{10, 12, 14}[sizeClass]
+	alphanumericBits := [3]int{9, 11, 13}[sizeClass]
+	byteBits := [3]int{8, 16, 16}[sizeClass]
+
+	r := &qrBitReader{data: data}
+	var result strings.Builder
+	for r.available() >= 4 {
+		mode := r.read(4)
+		switch mode {
+		case 0x0:
+			return result.String(), nil
+		case 0x1:
+			if r.available() < numericBits {
+				return "", ErrQRUnreadable
+			}
+			count := r.read(numericBits)
+			for count > 0 {
+				digits, width := 3, 10
+				if count == 2 {
+					digits, width = 2, 7
+				} else if count == 1 {
+					digits, width = 1, 4
+				}
+				if r.available() < width {
+					return "", ErrQRUnreadable
+				}
+				value := r.read(width)
+				for d := digits - 1; d >= 0; d-- {
+					result.WriteByte(byte('0' + value/int(math.Pow10(d))%10))
+				}
+				count -= digits
+			}
+		case 0x2:
+			if r.available() < alphanumericBits {
+				return "", ErrQRUnreadable
+			}
+			count := r.read(alphanumericBits)
+			for ; count >= 2; count -= 2 {
+				if r.available() < 11 {
+					return "", ErrQRUnreadable
+				}
+				value := r.read(11)
+				if value >= 45*45 {
+					return "", ErrQRUnreadable
+				}
+				result.WriteByte(qrAlphanumeric[value/45])
+				result.WriteByte(qrAlphanumeric[value%45])
+			}
+			if count == 1 {
+				if r.available() < 6 {
+					return "", ErrQRUnreadable
+				}
+				value := r.read(6)
+				if value >= 45 {
+					return "", ErrQRUnreadable
+				}
+				result.WriteByte(qrAlphanumeric[value])
+			}
+		case 0x4:
+			if r.available() < byteBits {
+				return "", ErrQRUnreadable
+			}
+			count := r.read(byteBits)
+			if r.available() < count*8 {
+				return "", ErrQRUnreadable
+			}
+			for i := 0; i < count; i++ {
+				result.WriteByte(byte(r.read(8)))
+			}
+		case 0x7:
+			// ECI designator, content is expected to be UTF-8 anyway
+			if r.available() < 8 {
+				return "", ErrQRUnreadable
+			}
+			first := r.read(8)
+			if first&0xC0 == 0x80 {
+				r.read(8)
+			} else if first&0xE0 == 0xC0 {
+				r.read(16)
+			}
+		case 0x3:
+			// Structured append header
+			r.read(16)
+		case 0x5:
+		case 0x9:
+			r.read(8)
+		default:
+			return "", ErrQRUnreadable
+		}
+	}
+
+	return result.String(), nil
+}
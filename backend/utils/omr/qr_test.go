@@ -0,0 +1,142 @@
+package omr
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// distort draws source image on white canvas of given size, centered and mapped by inverse of rotation and shear
+func distort(src image.Image, size int, angle float64, shear float64) *image.Gray {
+	bounds := src.Bounds()
+	sx, sy := float64(bounds.Dx())/2, float64(bounds.Dy())/2
+	cos, sin := math.Cos(-angle), math.Sin(-angle)
+
+	dst := image.NewGray(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx, dy := float64(x)-float64(size)/2, float64(y)-float64(size)/2
+			rx, ry := dx*cos-dy*sin, dx*sin+dy*cos
+			rx -= shear * ry
+
+			px, py := int(math.Floor(rx+sx)), int(math.Floor(ry+sy))
+			value := uint8(255)
+			if px >= 0 && py >= 0 && px < bounds.Dx() && py < bounds.Dy() {
+				value = color.GrayModel.Convert(src.At(bounds.Min.X+px, bounds.Min.Y+py)).(color.Gray).Y
+			}
+			dst.SetGray(x, y, color.Gray{Y: value})
+		}
+	}
+	return dst
+}
+
+// addNoise adds gaussian noise with given deviation like scanner does, the seed is fixed so failures are reproducible
+func addNoise(img *image.Gray, deviation float64) {
+	random := rand.New(rand.NewPCG(1, 2))
+	for i, v := range img.Pix {
+		img.Pix[i] = uint8(math.Max(0, math.Min(255, float64(v)+random.NormFloat64()*deviation)))
+	}
+}
+
+func TestDecodeQR(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		level   qrcode.RecoveryLevel
+		angle   float64 // Rotation in degrees
+		shear   float64
+		noise   float64
+	}{
+		{"plain", "ELOGIKA:1:42", qrcode.High, 0, 0, 0},
+		{"low level", "ELOGIKA:1:42", qrcode.Low, 0, 0, 0},
+		{"numeric", "1234567890123", qrcode.Medium, 0, 0, 0},
+		{"long content", "V2:123456:789012:3:abcdefghijklmnopqrstuvwxyz0123456789", qrcode.High, 0, 0, 0},
+		{"version 7 and more", "V2:123456:789012:3:abcdefghijklmnopqrstuvwxyz0123456789abcdefghijklmnopqrstuvwxyz0123456789abcdefghijklmnopqrstuvwxyz", qrcode.High, 0, 0, 0},
+		{"slightly rotated", "ELOGIKA:1:42", qrcode.High, 4, 0, 0},
+		{"rotated", "ELOGIKA:1:42", qrcode.High, 30, 0, 0},
+		{"rotated 90", "ELOGIKA:1:42", qrcode.High, 90, 0, 0},
+		{"upside down", "ELOGIKA:1:42", qrcode.High, 180, 0, 0},
+		{"rotated 270", "ELOGIKA:1:42", qrcode.High, 270, 0, 0},
+		{"skewed", "ELOGIKA:1:42", qrcode.High, 0, 0.08, 0},
+		{"rotated and skewed", "ELOGIKA:1:42", qrcode.High, -3, 0.05, 0},
+		{"noise", "ELOGIKA:1:42", qrcode.High, 0, 0, 35},
+		{"noise and rotation", "ELOGIKA:1:42", qrcode.High, 2, 0, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := qrcode.New(tt.content, tt.level)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			img := distort(code.Image(360), 520, tt.angle*math.Pi/180, tt.shear)
+			if tt.noise > 0 {
+				addNoise(img, tt.noise)
+			}
+
+			content, err := DecodeQR(Binarize(img))
+			if err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if content != tt.content {
+				t.Fatalf("content: got %q, want %q", content, tt.content)
+			}
+		})
+	}
+}
+
+func TestDecodeQRNotFound(t *testing.T) {
+	blank := image.NewGray(image.Rect(0, 0, 200, 200))
+	for i := range blank.Pix {
+		blank.Pix[i] = 255
+	}
+	addNoise(blank, 20)
+
+	if _, err := DecodeQR(Binarize(blank)); err != ErrQRNotFound {
+		t.Fatalf("error: got %v, want %v", err, ErrQRNotFound)
+	}
+}
+
+func TestDecodeQRDamaged(t *testing.T) {
+	code, err := qrcode.New("ELOGIKA:1:42", qrcode.High)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := distort(code.Image(360), 520, 0, 0)
+
+	tests := []struct {
+		name    string
+		damage  image.Rectangle // Area painted white, relative to image center
+		wantErr error
+	}{
+		{"small damage is corrected", image.Rect(20, 20, 50, 50), nil},
+		{"data area destroyed", image.Rect(-40, -40, 120, 120), ErrQRUnreadable},
+		{"finder destroyed", image.Rect(-180, -180, -80, -80), ErrQRNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			damaged := image.NewGray(img.Rect)
+			copy(damaged.Pix, img.Pix)
+			area := tt.damage.Add(image.Pt(260, 260))
+			for y := area.Min.Y; y < area.Max.Y; y++ {
+				for x := area.Min.X; x < area.Max.X; x++ {
+					damaged.SetGray(x, y, color.Gray{Y: 255})
+				}
+			}
+
+			content, err := DecodeQR(Binarize(damaged))
+			if err != tt.wantErr {
+				t.Fatalf("error: got %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && content != "ELOGIKA:1:42" {
+				t.Fatalf("content: got %q, want %q", content, "ELOGIKA:1:42")
+			}
+		})
+	}
+}
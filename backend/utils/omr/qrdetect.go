@@ -0,0 +1,330 @@
+package omr
+
+import (
+	"math"
+	"sort"
+)
+
+// finder is candidate center of QR finder pattern (the 7x7 square in three corners)
+type finder struct {
+	center     Point
+	moduleSize float64
+	count      int
+}
+
+// finderRatio checks 1:1:3:1:1 ratio of dark-light-dark-light-dark runs
+func finderRatio(counts [5]int) bool {
+	total := 0
+	for _, c := range counts {
+		if c == 0 {
+			return false
+		}
+		total += c
+	}
+	if total < 7 {
+		return false
+	}
+
+	module := float64(total) / 7
+	variance := module / 2
+	return math.Abs(module-float64(counts[0])) < variance &&
+		math.Abs(module-float64(counts[1])) < variance &&
+		math.Abs(3*module-float64(counts[2])) < 3*variance &&
+		math.Abs(module-float64(counts[3])) < variance &&
+		math.Abs(module-float64(counts[4])) < variance
+}
+
+// crossCheck measures finder runs along line through (x, y) in direction (dx, dy),
+// it returns center offset along the line and total run length
+func crossCheck(b *Bitmap, x int, y int, dx int, dy int, maxCount int, originalTotal int) (float64, int, bool) {
+	var counts [5]int
+	dark := func(k int) bool {
+		return b.Dark(x+k*dx, y+k*dy)
+	}
+	inside := func(k int) bool {
+		px, py := x+k*dx, y+k*dy
+		return px >= 0 && py >= 0 && px < b.Width && py < b.Height
+	}
+
+	k := 0
+	for inside(k) && dark(k) {
+		counts[2]++
+		k--
+	}
+	if !inside(k) {
+		return 0, 0, false
+	}
+	for inside(k) && !dark(k) && counts[1] <= maxCount {
+		counts[1]++
+		k--
+	}
+	if !inside(k) || counts[1] > maxCount {
+		return 0, 0, false
+	}
+	for inside(k) && dark(k) && counts[0] <= maxCount {
+		counts[0]++
+		k--
+	}
+	if counts[0] > maxCount {
+		return 0, 0, false
+	}
+
+	k = 1
+	for inside(k) && dark(k) {
+		counts[2]++
+		k++
+	}
+	if !inside(k) {
+		return 0, 0, false
+	}
+	for inside(k) && !dark(k) && counts[3] <= maxCount {
+		counts[3]++
+		k++
+	}
+	if !inside(k) || counts[3] > maxCount {
+		return 0, 0, false
+	}
+	for inside(k) && dark(k) && counts[4] <= maxCount {
+		counts[4]++
+		k++
+	}
+	if counts[4] > maxCount {
+		return 0, 0, false
+	}
+
+	total := counts[0] + counts[1] + counts[2] + counts[3] + counts[4]
+	if 5*absInt(total-originalTotal) >= 2*originalTotal || !finderRatio(counts) {
+		return 0, 0, false
+	}
+
+	return float64(k-counts[4]-counts[3]) - float64(counts[2])/2, total, true
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// findFinders scans bitmap rows for finder patterns and confirms them in vertical direction
+func findFinders(b *Bitmap) []*finder {
+	finders := make([]*finder, 0)
+
+	check := func(counts [5]int, x int, y int) {
+		total := counts[0] + counts[1] + counts[2] + counts[3] + counts[4]
+		centerX := float64(x-counts[4]-counts[3]) - float64(counts[2])/2
+
+		offsetY, totalY, ok := crossCheck(b, int(centerX), y, 0, 1, counts[2], total)
+		if !ok {
+			return
+		}
+		centerY := float64(y) + offsetY
+
+		offsetX, totalX, ok := crossCheck(b, int(centerX), int(centerY), 1, 0, counts[2], total)
+		if !ok {
+			return
+		}
+		centerX = float64(int(centerX)) + offsetX
+
+		moduleSize := float64(totalX+totalY) / 14
+		for _, f := range finders {
+			if math.Abs(f.center.X-centerX) <= moduleSize && math.Abs(f.center.Y-centerY) <= moduleSize &&
+				math.Abs(f.moduleSize-moduleSize) <= math.Max(1, f.moduleSize/2) {
+				n := float64(f.count)
+				f.center = Point{X: (f.center.X*n + centerX) / (n + 1), Y: (f.center.Y*n + centerY) / (n + 1)}
+				f.moduleSize = (f.moduleSize*n + moduleSize) / (n + 1)
+				f.count++
+				return
+			}
+		}
+		finders = append(finders, &finder{center: Point{X: centerX, Y: centerY}, moduleSize: moduleSize, count: 1})
+	}
+
+	for y := 0; y < b.Height; y++ {
+		var counts [5]int
+		state := 0
+		for x := 0; x < b.Width; x++ {
+			if b.Dark(x, y) {
+				if state&1 == 1 {
+					state++
+				}
+				counts[state]++
+				continue
+			}
+
+			if state&1 == 1 {
+				counts[state]++
+				continue
+			}
+			if state != 4 {
+				state++
+				counts[state]++
+				continue
+			}
+
+			if finderRatio(counts) {
+				check(counts, x, y)
+			}
+			counts = [5]int{counts[2], counts[3], counts[4], 1, 0}
+			state = 3
+		}
+		if state == 4 && finderRatio(counts) {
+			check(counts, b.Width, y)
+		}
+	}
+
+	return finders
+}
+
+// selectFinders picks three finders forming right isosceles triangle and orders them top left, top right, bottom left
+func selectFinders(finders []*finder) ([3]*finder, bool) {
+	confirmed := make([]*finder, 0, len(finders))
+	for _, f := range finders {
+		if f.count >= 2 {
+			confirmed = append(confirmed, f)
+		}
+	}
+	if len(confirmed) >= 3 {
+		finders = confirmed
+	}
+	if len(finders) < 3 {
+		return [3]*finder{}, false
+	}
+
+	sort.Slice(finders, func(i, j int) bool {
+		return finders[i].count > finders[j].count
+	})
+	if len(finders) > 10 {
+		finders = finders[:10]
+	}
+
+	var best [3]*finder
+	bestScore := math.Inf(1)
+	for i := 0; i < len(finders); i++ {
+		for j := i + 1; j < len(finders); j++ {
+			for k := j + 1; k < len(finders); k++ {
+				a, b, c := finders[i], finders[j], finders[k]
+				sizes := []float64{a.moduleSize, b.moduleSize, c.moduleSize}
+				sort.Float64s(sizes)
+				if sizes[2] > 1.5*sizes[0] {
+					continue
+				}
+
+				sides := []float64{
+					distance(a.center, b.center),
+					distance(b.center, c.center),
+					distance(a.center, c.center),
+				}
+				sort.Float64s(sides)
+				if sides[0] < 7*sizes[0] {
+					continue
+				}
+
+				score := math.Abs(sides[1]-sides[0])/sides[1] +
+					math.Abs(sides[2]-math.Hypot(sides[0], sides[1]))/sides[2] +
+					(sizes[2]-sizes[0])/sizes[2]
+				if score < bestScore {
+					bestScore = score
+					best = [3]*finder{a, b, c}
+				}
+			}
+		}
+	}
+	if best[0] == nil || bestScore > 0.5 {
+		return [3]*finder{}, false
+	}
+
+	// Top left finder is opposite to the longest side
+	a, b, c := best[0], best[1], best[2]
+	ab, bc, ac := distance(a.center, b.center), distance(b.center, c.center), distance(a.center, c.center)
+	var topLeft, p1, p2 *finder
+	switch {
+	case bc >= ab && bc >= ac:
+		topLeft, p1, p2 = a, b, c
+	case ac >= ab && ac >= bc:
+		topLeft, p1, p2 = b, a, c
+	default:
+		topLeft, p1, p2 = c, a, b
+	}
+
+	// In image coordinates (y down) top right is clockwise from bottom left
+	cross := (p1.center.X-topLeft.center.X)*(p2.center.Y-topLeft.center.Y) -
+		(p1.center.Y-topLeft.center.Y)*(p2.center.X-topLeft.center.X)
+	if cross < 0 {
+		p1, p2 = p2, p1
+	}
+
+	return [3]*finder{topLeft, p1, p2}, true
+}
+
+func distance(a Point, b Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+// qrGridTransform maps module coordinates of symbol with given version to bitmap, it uses alignment pattern
+// when the symbol has one and it can be found
+func qrGridTransform(b *Bitmap, finders [3]*finder, version int) Transform {
+	size := float64(version*4 + 17)
+	src := []Point{{X: 3.5, Y: 3.5}, {X: size - 3.5, Y: 3.5}, {X: 3.5, Y: size - 3.5}}
+	dst := []Point{finders[0].center, finders[1].center, finders[2].center}
+
+	affine, ok := FitAffine(src, dst)
+	if !ok {
+		return nil
+	}
+	if version == 1 {
+		return affine
+	}
+
+	// Search alignment pattern around its expected position
+	expectedModule := Point{X: size - 6.5, Y: size - 6.5}
+	expected := affine.Apply(expectedModule)
+	origin := affine.Apply(Point{})
+	ex := affine.Apply(Point{X: 1})
+	ey := affine.Apply(Point{Y: 1})
+	ex = Point{X: ex.X - origin.X, Y: ex.Y - origin.Y}
+	ey = Point{X: ey.X - origin.X, Y: ey.Y - origin.Y}
+	moduleSize := math.Hypot(ex.X, ex.Y)
+
+	radius := int(math.Ceil(4 * moduleSize))
+	bestScore := -1
+	bestDistance := math.Inf(1)
+	var best Point
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			center := Point{X: expected.X + float64(dx), Y: expected.Y + float64(dy)}
+			score := 0
+			for v := -2; v <= 2; v++ {
+				for u := -2; u <= 2; u++ {
+					p := Point{
+						X: center.X + float64(u)*ex.X + float64(v)*ey.X,
+						Y: center.Y + float64(u)*ex.Y + float64(v)*ey.Y,
+					}
+					ring := max(absInt(u), absInt(v))
+					if b.DarkAt(p) == (ring != 1) {
+						score++
+					}
+				}
+			}
+			d := math.Hypot(float64(dx), float64(dy))
+			if score > bestScore || (score == bestScore && d < bestDistance) {
+				bestScore = score
+				bestDistance = d
+				best = center
+			}
+		}
+	}
+	if bestScore < 23 {
+		return affine
+	}
+
+	perspective, ok := FitPerspective(
+		[4]Point{src[0], src[1], src[2], expectedModule},
+		[4]Point{dst[0], dst[1], dst[2], best},
+	)
+	if !ok {
+		return affine
+	}
+	return perspective
+}
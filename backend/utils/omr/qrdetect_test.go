@@ -0,0 +1,87 @@
+package omr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFinderRatio(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts [5]int
+		want   bool
+	}{
+		{"exact", [5]int{4, 4, 12, 4, 4}, true},
+		{"single pixel modules", [5]int{1, 1, 3, 1, 1}, true},
+		{"blurred edges", [5]int{5, 3, 13, 3, 5}, true},
+		{"too small", [5]int{1, 1, 2, 1, 1}, false},
+		{"missing run", [5]int{4, 0, 12, 4, 4}, false},
+		{"center too thin", [5]int{4, 4, 4, 4, 4}, false},
+		{"outer run too wide", [5]int{12, 4, 12, 4, 4}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := finderRatio(tt.counts); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectFinders(t *testing.T) {
+	// Finders of upright code, the order of input and rotation of the code must not matter
+	topLeft, topRight, bottomLeft := Point{X: 100, Y: 100}, Point{X: 300, Y: 100}, Point{X: 100, Y: 300}
+	rotate := func(p Point, degrees float64) Point {
+		angle := degrees * math.Pi / 180
+		return Point{
+			X: 200 + (p.X-200)*math.Cos(angle) - (p.Y-200)*math.Sin(angle),
+			Y: 200 + (p.X-200)*math.Sin(angle) + (p.Y-200)*math.Cos(angle),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		angle  float64
+		order  [3]int // Input order of top left, top right and bottom left finder
+		extra  []Point
+		wantOK bool
+	}{
+		{"upright", 0, [3]int{0, 1, 2}, nil, true},
+		{"shuffled", 0, [3]int{2, 0, 1}, nil, true},
+		{"rotated 90", 90, [3]int{1, 2, 0}, nil, true},
+		{"upside down", 180, [3]int{0, 2, 1}, nil, true},
+		{"slightly rotated", 7, [3]int{0, 1, 2}, nil, true},
+		{"with false candidate", 0, [3]int{0, 1, 2}, []Point{{X: 180, Y: 230}}, true},
+		{"two finders only", 0, [3]int{0, 1, -1}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := [3]Point{rotate(topLeft, tt.angle), rotate(topRight, tt.angle), rotate(bottomLeft, tt.angle)}
+
+			candidates := []*finder{}
+			for _, i := range tt.order {
+				if i >= 0 {
+					candidates = append(candidates, &finder{center: expected[i], moduleSize: 4, count: 3})
+				}
+			}
+			for _, p := range tt.extra {
+				candidates = append(candidates, &finder{center: p, moduleSize: 4, count: 1})
+			}
+
+			found, ok := selectFinders(candidates)
+			if ok != tt.wantOK {
+				t.Fatalf("found: got %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			for i, f := range found {
+				if distance(f.center, expected[i]) > 0.001 {
+					t.Fatalf("finder %d: got %v, want %v", i, f.center, expected[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package omr
+
+// Reed-Solomon error correction over GF(256) with primitive polynomial 0x11D, as used by QR codes.
+// Polynomials are stored lowest degree first.
+
+var gfExp [512]byte
+var gfLog [256]int
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	for i := 255; i < 512; i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMul(a byte, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+gfLog[b]]
+}
+
+func gfDiv(a byte, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+255-gfLog[b]]
+}
+
+func gfPow(a byte, n int) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[(gfLog[a]*n)%255]
+}
+
+func polyEval(p []byte, x byte) byte {
+	result := byte(0)
+	for i := len(p) - 1; i >= 0; i-- {
+		result = gfMul(result, x) ^ p[i]
+	}
+	return result
+}
+
+// rsSyndromes evaluates codeword at powers of generator, codeword is stored highest degree first
+func rsSyndromes(codeword []byte, ecCount int) ([]byte, bool) {
+	syndromes := make([]byte, ecCount)
+	clean := true
+	for j := 0; j < ecCount; j++ {
+		s := byte(0)
+		x := gfExp[j]
+		for _, c := range codeword {
+			s = gfMul(s, x) ^ c
+		}
+		syndromes[j] = s
+		if s != 0 {
+			clean = false
+		}
+	}
+	return syndromes, clean
+}
+
+// rsCorrect fixes up to ecCount/2 damaged bytes of codeword in place
+func rsCorrect(codeword []byte, ecCount int) bool {
+	syndromes, clean := rsSyndromes(codeword, ecCount)
+	if clean {
+		return true
+	}
+
+	// Berlekamp-Massey finds error locator polynomial
+	locator := []byte{1}
+	previous := []byte{1}
+	errors := 0
+	shift := 1
+	lastDiscrepancy := byte(1)
+	for n := 0; n < ecCount; n++ {
+		d := syndromes[n]
+		for i := 1; i <= errors && i < len(locator); i++ {
+			d ^= gfMul(locator[i], syndromes[n-i])
+		}
+
+		if d == 0 {
+			shift++
+			continue
+		}
+
+		coef := gfDiv(d, lastDiscrepancy)
+		updated := make([]byte, max(len(locator), len(previous)+shift))
+		copy(updated, locator)
+		for i, p := range previous {
+			updated[i+shift] ^= gfMul(coef, p)
+		}
+
+		if 2*errors <= n {
+			previous = locator
+			errors = n + 1 - errors
+			lastDiscrepancy = d
+			shift = 1
+		} else {
+			shift++
+		}
+		locator = updated
+	}
+
+	for len(locator) > 1 && locator[len(locator)-1] == 0 {
+		locator = locator[:len(locator)-1]
+	}
+	if errors != len(locator)-1 || 2*errors > ecCount {
+		return false
+	}
+
+	// Chien search, error at position k has locator X = alpha^(n-1-k)
+	n := len(codeword)
+	positions := make([]int, 0, errors)
+	locators := make([]byte, 0, errors)
+	for k := 0; k < n; k++ {
+		x := gfExp[(n-1-k)%255]
+		if polyEval(locator, gfDiv(1, x)) == 0 {
+			positions = append(positions, k)
+			locators = append(locators, x)
+		}
+	}
+	if len(positions) != errors {
+		return false
+	}
+
+	// Forney algorithm, evaluator = syndromes * locator mod x^ecCount
+	evaluator := make([]byte, ecCount)
+	for i, s := range syndromes {
+		for j, l := range locator {
+			if i+j < ecCount {
+				evaluator[i+j] ^= gfMul(s, l)
+			}
+		}
+	}
+	derivative := make([]byte, len(locator))
+	for i := 1; i < len(locator); i += 2 {
+		derivative[i-1] = locator[i]
+	}
+
+	for i, k := range positions {
+		xInverse := gfDiv(1, locators[i])
+		denominator := polyEval(derivative, xInverse)
+		if denominator == 0 {
+			return false
+		}
+		codeword[k] ^= gfMul(locators[i], gfDiv(polyEval(evaluator, xInverse), denominator))
+	}
+
+	_, clean = rsSyndromes(codeword, ecCount)
+	return clean
+}
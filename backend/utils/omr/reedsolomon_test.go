@@ -0,0 +1,84 @@
+package omr
+
+import (
+	"bytes"
+	"testing"
+)
+
+// rsEncode appends ecCount error correction codewords to data, codeword is stored highest degree first
+func rsEncode(data []byte, ecCount int) []byte {
+	generator := []byte{1}
+	for j := 0; j < ecCount; j++ {
+		next := make([]byte, len(generator)+1)
+		for i, g := range generator {
+			next[i] ^= g
+			next[i+1] ^= gfMul(g, gfExp[j])
+		}
+		generator = next
+	}
+
+	remainder := make([]byte, len(data)+ecCount)
+	copy(remainder, data)
+	for i := range data {
+		coef := remainder[i]
+		if coef == 0 {
+			continue
+		}
+		for j, g := range generator {
+			remainder[i+j] ^= gfMul(g, coef)
+		}
+	}
+
+	return append(append([]byte{}, data...), remainder[len(data):]...)
+}
+
+func TestGF(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		if got := gfMul(gfDiv(1, byte(a)), byte(a)); got != 1 {
+			t.Fatalf("inverse of %d: got %d, want 1", a, got)
+		}
+		if got := gfPow(byte(a), 255); got != 1 {
+			t.Fatalf("%d^255: got %d, want 1", a, got)
+		}
+	}
+}
+
+func TestRSCorrect(t *testing.T) {
+	data := []byte("ELOGIKA:1:42:1234567890")
+
+	tests := []struct {
+		name    string
+		ecCount int
+		errors  []int // Positions of damaged codewords
+		want    bool
+	}{
+		{"clean", 10, nil, true},
+		{"one error in data", 10, []int{3}, true},
+		{"one error in ec", 10, []int{25}, true},
+		{"first and last codeword", 10, []int{0, 32}, true},
+		{"full capacity", 10, []int{1, 5, 9, 17, 30}, true},
+		{"full capacity odd ec", 7, []int{2, 11, 20}, true},
+		{"adjacent errors", 16, []int{4, 5, 6, 7, 8, 9, 10, 11}, true},
+		{"over capacity", 10, []int{1, 5, 9, 17, 21, 30}, false},
+		{"all data damaged", 10, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := rsEncode(data, tt.ecCount)
+			if _, clean := rsSyndromes(original, tt.ecCount); !clean {
+				t.Fatal("encoded codeword has non-zero syndromes")
+			}
+
+			codeword := append([]byte{}, original...)
+			for i, position := range tt.errors {
+				codeword[position] ^= byte(0x5A + i*17)
+			}
+
+			ok := rsCorrect(codeword, tt.ecCount) && bytes.Equal(codeword, original)
+			if ok != tt.want {
+				t.Fatalf("corrected: got %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package omr
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Resample renders rectangle of the source plane upright, t maps source plane to pixels of img and scale
+// gives number of output pixels per source unit. Area outside of img is white.
+func Resample(img image.Image, t Transform, x float64, y float64, w float64, h float64, scale float64) *image.Gray {
+	bounds := img.Bounds()
+	out := image.NewGray(image.Rect(0, 0, int(math.Ceil(w*scale)), int(math.Ceil(h*scale))))
+
+	for oy := 0; oy < out.Rect.Dy(); oy++ {
+		for ox := 0; ox < out.Rect.Dx(); ox++ {
+			p := t.Apply(Point{X: x + (float64(ox)+0.5)/scale, Y: y + (float64(oy)+0.5)/scale})
+			px := bounds.Min.X + int(math.Floor(p.X))
+			py := bounds.Min.Y + int(math.Floor(p.Y))
+
+			value := uint8(255)
+			if image.Pt(px, py).In(bounds) {
+				value = color.GrayModel.Convert(img.At(px, py)).(color.Gray).Y
+			}
+			out.Pix[oy*out.Stride+ox] = value
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,149 @@
+package omr
+
+import "math"
+
+// Transform maps points from one plane to another
+type Transform interface {
+	Apply(p Point) Point
+}
+
+// Affine is transform x' = A*x + B*y + C, y' = D*x + E*y + F, it covers shift, scale, rotation and skew of scanned page
+type Affine struct {
+	A, B, C float64
+	D, E, F float64
+}
+
+func (a Affine) Apply(p Point) Point {
+	return Point{
+		X: a.A*p.X + a.B*p.Y + a.C,
+		Y: a.D*p.X + a.E*p.Y + a.F,
+	}
+}
+
+// FitAffine finds affine transform mapping src points to dst points with least squares error
+func FitAffine(src []Point, dst []Point) (Affine, bool) {
+	if len(src) < 3 || len(src) != len(dst) {
+		return Affine{}, false
+	}
+
+	m := make([][]float64, 3)
+	for i := range m {
+		m[i] = make([]float64, 3)
+	}
+	bx := make([]float64, 3)
+	by := make([]float64, 3)
+	for i, s := range src {
+		row := []float64{s.X, s.Y, 1}
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 3; c++ {
+				m[r][c] += row[r] * row[c]
+			}
+			bx[r] += row[r] * dst[i].X
+			by[r] += row[r] * dst[i].Y
+		}
+	}
+
+	x, ok := solve(cloneMatrix(m), bx)
+	if !ok {
+		return Affine{}, false
+	}
+	y, ok := solve(cloneMatrix(m), by)
+	if !ok {
+		return Affine{}, false
+	}
+
+	return Affine{
+		A: x[0], B: x[1], C: x[2],
+		D: y[0], E: y[1], F: y[2],
+	}, true
+}
+
+// Residual returns largest distance between mapped src points and dst points
+func Residual(t Transform, src []Point, dst []Point) float64 {
+	residual := float64(0)
+	for i, s := range src {
+		p := t.Apply(s)
+		residual = math.Max(residual, math.Hypot(p.X-dst[i].X, p.Y-dst[i].Y))
+	}
+	return residual
+}
+
+// Perspective is projective transform, it is used when four reference points are known
+type Perspective struct {
+	m [8]float64
+}
+
+func (t Perspective) Apply(p Point) Point {
+	w := t.m[6]*p.X + t.m[7]*p.Y + 1
+	return Point{
+		X: (t.m[0]*p.X + t.m[1]*p.Y + t.m[2]) / w,
+		Y: (t.m[3]*p.X + t.m[4]*p.Y + t.m[5]) / w,
+	}
+}
+
+// FitPerspective finds projective transform mapping four src points exactly to four dst points
+func FitPerspective(src [4]Point, dst [4]Point) (Perspective, bool) {
+	m := make([][]float64, 8)
+	b := make([]float64, 8)
+	for i := 0; i < 4; i++ {
+		s, d := src[i], dst[i]
+		m[2*i] = []float64{s.X, s.Y, 1, 0, 0, 0, -s.X * d.X, -s.Y * d.X}
+		b[2*i] = d.X
+		m[2*i+1] = []float64{0, 0, 0, s.X, s.Y, 1, -s.X * d.Y, -s.Y * d.Y}
+		b[2*i+1] = d.Y
+	}
+
+	x, ok := solve(m, b)
+	if !ok {
+		return Perspective{}, false
+	}
+
+	var t Perspective
+	copy(t.m[:], x)
+	return t, true
+}
+
+func cloneMatrix(m [][]float64) [][]float64 {
+	clone := make([][]float64, len(m))
+	for i, row := range m {
+		clone[i] = append([]float64{}, row...)
+	}
+	return clone
+}
+
+// solve solves linear system by Gaussian elimination with partial pivoting, inputs are modified
+func solve(m [][]float64, b []float64) ([]float64, bool) {
+	n := len(b)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(m[r][col]) > math.Abs(m[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-12 {
+			return nil, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for r := col + 1; r < n; r++ {
+			f := m[r][col] / m[col][col]
+			for c := col; c < n; c++ {
+				m[r][c] -= f * m[col][c]
+			}
+			b[r] -= f * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		sum := b[r]
+		for c := r + 1; c < n; c++ {
+			sum -= m[r][c] * x[c]
+		}
+		x[r] = sum / m[r][r]
+	}
+
+	return x, true
+}
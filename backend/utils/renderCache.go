@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"elogika.vsb.cz/backend/initializers"
+	"elogika.vsb.cz/backend/models"
+	"gorm.io/gorm"
+)
+
+const (
+	RenderKindSVG = "SVG" // LaTeX code blocks rendered for editor
+	RenderKindPDF = "PDF" // Printed documents
+)
+
+// Template versions are part of cache key. Bump them whenever LaTeX templates or conversion of content change,
+// so that renders made by older templates are not used anymore.
+const (
+	SVGTemplateVersion = uint(1)
+	PDFTemplateVersion = uint(1)
+)
+
+// GetCachedRender returns cached render of content with preloaded file, or nil when content was not rendered yet.
+// Entries whose file is missing in uploads are removed.
+func GetCachedRender(dbRef *gorm.DB, kind string, contentHash string, templateVersion uint) *models.RenderCache {
+	var cached models.RenderCache
+	if err := dbRef.
+		Joins("File").
+		Where("render_caches.kind = ?", kind).
+		Where("render_caches.content_hash = ?", contentHash).
+		Where("render_caches.template_version = ?", templateVersion).
+		Limit(1).
+		Find(&cached).Error; err != nil || cached.ID == 0 {
+		return nil
+	}
+
+	if cached.File == nil {
+		removeCachedRender(dbRef, &cached)
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, cached.File.StoredName)); err != nil {
+		removeCachedRender(dbRef, &cached)
+		return nil
+	}
+
+	dbRef.Model(&cached).Update("last_used_at", time.Now())
+
+	return &cached
+}
+
+// StoreCachedRender copies rendered file to uploads and stores it as cached render of content. When the same content
+// was stored concurrently, the existing entry is returned.
+func StoreCachedRender(dbRef *gorm.DB, kind string, contentHash string, templateVersion uint, sourcePath string, mimeType string, pages int, userID uint) (*models.RenderCache, error) {
+	newFileName, err := GenerateFileName(dbRef, filepath.Ext(sourcePath))
+	if err != nil {
+		return nil, err
+	}
+
+	storedPath := filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, newFileName)
+	if err := CopyFile(sourcePath, storedPath); err != nil {
+		return nil, err
+	}
+
+	fileInfo, err := os.Stat(storedPath)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cached := &models.RenderCache{
+		LastUsedAt:      now,
+		Kind:            kind,
+		ContentHash:     contentHash,
+		TemplateVersion: templateVersion,
+		Pages:           pages,
+		File: &models.File{
+			UserID:       userID,
+			OriginalName: "GENERATED",
+			StoredName:   newFileName,
+			MIMEType:     mimeType,
+			SizeBytes:    fileInfo.Size(),
+			UploadedAt:   now,
+		},
+	}
+
+	// Nested transaction falls back to savepoint, so conflicting insert does not break caller's transaction
+	if err := dbRef.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(cached).Error
+	}); err != nil {
+		os.Remove(storedPath)
+
+		if existing := GetCachedRender(dbRef, kind, contentHash, templateVersion); existing != nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return cached, nil
+}
+
+// CleanupRenderCache removes entries rendered by outdated templates and entries not used for given duration. Files of
+// PDF renders belong only to cache and are removed as well, SVG files stay as they are referenced by edited content.
+func CleanupRenderCache(dbRef *gorm.DB, unusedFor time.Duration) {
+	var entries []*models.RenderCache
+	if err := dbRef.
+		Preload("File").
+		Where("last_used_at < ?", time.Now().Add(-unusedFor)).
+		Or("kind = ? AND template_version <> ?", RenderKindSVG, SVGTemplateVersion).
+		Or("kind = ? AND template_version <> ?", RenderKindPDF, PDFTemplateVersion).
+		Find(&entries).Error; err != nil {
+		log.Println("Failed to load outdated render cache", err)
+		return
+	}
+
+	for _, entry := range entries {
+		removeCachedRender(dbRef, entry)
+	}
+}
+
+func removeCachedRender(dbRef *gorm.DB, entry *models.RenderCache) {
+	if err := dbRef.Delete(entry).Error; err != nil {
+		log.Println("Failed to remove render cache entry", entry.ID, err)
+		return
+	}
+
+	if entry.Kind != RenderKindPDF || entry.File == nil {
+		return
+	}
+
+	if err := dbRef.Delete(entry.File).Error; err != nil {
+		log.Println("Failed to remove rendered file", entry.FileID, err)
+		return
+	}
+	os.Remove(filepath.Join(initializers.GlobalAppConfig.UPLOADS_DESTINATION, entry.File.StoredName))
+}
@@ -0,0 +1,164 @@
+package seb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	HeaderConfigKeyHash = "X-SafeExamBrowser-ConfigKeyHash"
+	HeaderRequestHash   = "X-SafeExamBrowser-RequestHash"
+)
+
+// Config is a Safe Exam Browser settings dictionary.
+// Supported value types are string, bool, int, []any and Config.
+type Config map[string]any
+
+// ConfigKey calculates SEB Config Key as described in SEB developer documentation:
+// SHA256 of settings serialized into JSON with keys sorted case-insensitively,
+// without whitespaces and without "originatorVersion" key.
+func (c Config) ConfigKey() (string, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, c, true); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Plist serializes settings into unencrypted XML .seb file
+func (c Config) Plist() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	buf.WriteString(`<plist version="1.0">` + "\n")
+	if err := writePlist(&buf, c); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n</plist>\n")
+	return buf.Bytes(), nil
+}
+
+// VerifyHash checks header hash sent by SEB, which is SHA256 of absolute request URL concatenated with key
+func VerifyHash(absoluteURL string, key string, hash string) bool {
+	if i := strings.Index(absoluteURL, "#"); i >= 0 {
+		absoluteURL = absoluteURL[:i]
+	}
+	sum := sha256.Sum256([]byte(absoluteURL + key))
+	expected := hex.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(hash))) == 1
+}
+
+// HashQuitPassword returns quit password in form expected by "hashedQuitPassword" setting
+func HashQuitPassword(password string) string {
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
+
+func sortedKeys(c Config) []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.ToLower(keys[i]) < strings.ToLower(keys[j])
+	})
+	return keys
+}
+
+func writeJSON(buf *bytes.Buffer, value any, root bool) error {
+	switch v := value.(type) {
+	case Config:
+		buf.WriteByte('{')
+		first := true
+		for _, k := range sortedKeys(v) {
+			if root && k == "originatorVersion" {
+				continue
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			writeJSONString(buf, k)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, v[k], false); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item, false); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case string:
+		writeJSONString(buf, v)
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case int:
+		buf.WriteString(strconv.Itoa(v))
+	default:
+		return fmt.Errorf("unsupported SEB setting type %T", value)
+	}
+	return nil
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	buf.Truncate(buf.Len() - 1) // Encoder always appends newline
+}
+
+func writePlist(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case Config:
+		buf.WriteString("<dict>")
+		for _, k := range sortedKeys(v) {
+			buf.WriteString("<key>")
+			xml.EscapeText(buf, []byte(k))
+			buf.WriteString("</key>")
+			if err := writePlist(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</dict>")
+	case []any:
+		buf.WriteString("<array>")
+		for _, item := range v {
+			if err := writePlist(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</array>")
+	case string:
+		buf.WriteString("<string>")
+		xml.EscapeText(buf, []byte(v))
+		buf.WriteString("</string>")
+	case bool:
+		if v {
+			buf.WriteString("<true/>")
+		} else {
+			buf.WriteString("<false/>")
+		}
+	case int:
+		buf.WriteString("<integer>" + strconv.Itoa(v) + "</integer>")
+	default:
+		return fmt.Errorf("unsupported SEB setting type %T", value)
+	}
+	return nil
+}
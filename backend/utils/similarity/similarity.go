@@ -0,0 +1,128 @@
+// Package similarity compares texts by word n-grams (shingles). It has no external dependencies,
+// so it can run fully offline inside the backend.
+package similarity
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// Passage is overlapping part of two texts. Offsets are UTF-16 code unit positions in compared texts, the same as
+// string indexes of the TypeScript client.
+type Passage struct {
+	StartA int `json:"startA"`
+	EndA   int `json:"endA"`
+	StartB int `json:"startB"`
+	EndB   int `json:"endB"`
+}
+
+// token is a word of the text, start and end are byte offsets, start16 and end16 are UTF-16 offsets
+type token struct {
+	start   int
+	end     int
+	start16 int
+	end16   int
+}
+
+type Document struct {
+	Text     string
+	tokens   []token
+	shingles []uint64
+	set      map[uint64]struct{}
+}
+
+// NewDocument splits text into lowercase words and hashes every n consecutive words
+func NewDocument(text string, n int) *Document {
+	doc := &Document{
+		Text: text,
+		set:  make(map[uint64]struct{}),
+	}
+
+	start, start16, i16 := -1, 0, 0
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start, start16 = i, i16
+		} else if !isWord && start >= 0 {
+			doc.tokens = append(doc.tokens, token{start: start, end: i, start16: start16, end16: i16})
+			start = -1
+		}
+		i16 += utf16.RuneLen(r)
+	}
+	if start >= 0 {
+		doc.tokens = append(doc.tokens, token{start: start, end: len(text), start16: start16, end16: i16})
+	}
+
+	for i := 0; i+n <= len(doc.tokens); i++ {
+		hash := fnv.New64a()
+		for _, t := range doc.tokens[i : i+n] {
+			hash.Write([]byte(strings.ToLower(text[t.start:t.end])))
+			hash.Write([]byte{0})
+		}
+		shingle := hash.Sum64()
+		doc.shingles = append(doc.shingles, shingle)
+		doc.set[shingle] = struct{}{}
+	}
+
+	return doc
+}
+
+// Empty reports if document is too short to contain a single shingle
+func (d *Document) Empty() bool {
+	return len(d.shingles) == 0
+}
+
+// Jaccard returns ratio of shared shingles to all distinct shingles of both documents
+func Jaccard(a *Document, b *Document) float64 {
+	if a.Empty() || b.Empty() {
+		return 0
+	}
+
+	shared := 0
+	for shingle := range a.set {
+		if _, ok := b.set[shingle]; ok {
+			shared++
+		}
+	}
+	union := len(a.set) + len(b.set) - shared
+	return float64(shared) / float64(union)
+}
+
+// Passages finds maximal runs of shingles shared by both documents in order of first document
+func Passages(a *Document, b *Document, n int) []Passage {
+	positions := make(map[uint64][]int)
+	for j, shingle := range b.shingles {
+		positions[shingle] = append(positions[shingle], j)
+	}
+
+	passages := make([]Passage, 0)
+	for i := 0; i < len(a.shingles); {
+		bestLength, bestJ := 0, 0
+		for _, j := range positions[a.shingles[i]] {
+			length := 0
+			for i+length < len(a.shingles) && j+length < len(b.shingles) && a.shingles[i+length] == b.shingles[j+length] {
+				length++
+			}
+			if length > bestLength {
+				bestLength, bestJ = length, j
+			}
+		}
+		if bestLength == 0 {
+			i++
+			continue
+		}
+
+		// Run of k shingles covers k+n-1 words
+		passages = append(passages, Passage{
+			StartA: a.tokens[i].start16,
+			EndA:   a.tokens[i+bestLength+n-2].end16,
+			StartB: b.tokens[bestJ].start16,
+			EndB:   b.tokens[bestJ+bestLength+n-2].end16,
+		})
+		i += bestLength
+	}
+
+	return passages
+}
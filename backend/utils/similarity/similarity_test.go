@@ -0,0 +1,95 @@
+package similarity
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+// utf16Slice returns part of text between UTF-16 offsets, the same as substring of the TypeScript client
+func utf16Slice(text string, start int, end int) string {
+	return string(utf16.Decode(utf16.Encode([]rune(text))[start:end]))
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		n    int
+		want float64
+	}{
+		{"identical", "one two three four", "one two three four", 2, 1},
+		{"case and punctuation are ignored", "One, two; THREE four!", "one two three four", 2, 1},
+		{"disjoint", "one two three four", "five six seven eight", 2, 0},
+		{"half shared", "one two three", "two three four", 2, 1.0 / 3},
+		{"too short", "one", "one", 2, 0},
+		{"empty", "", "one two", 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Jaccard(NewDocument(tt.a, tt.n), NewDocument(tt.b, tt.n))
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPassages(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		n     int
+		wantA []string // Overlapping parts of text A
+		wantB []string // Overlapping parts of text B
+	}{
+		{
+			"no overlap",
+			"alpha beta gamma delta", "one two three four", 2,
+			[]string{}, []string{},
+		},
+		{
+			"single passage",
+			"intro alpha beta gamma delta outro", "other alpha beta gamma delta end", 2,
+			[]string{"alpha beta gamma delta"}, []string{"alpha beta gamma delta"},
+		},
+		{
+			"passages in different order",
+			"alpha beta gamma xx one two three", "one two three yy alpha beta gamma", 3,
+			[]string{"alpha beta gamma", "one two three"}, []string{"alpha beta gamma", "one two three"},
+		},
+		{
+			"czech diacritics",
+			"Žluťoučký kůň úpěl ďábelské ódy", "Příliš žluťoučký kůň úpěl ďábelské ódy", 3,
+			[]string{"Žluťoučký kůň úpěl ďábelské ódy"}, []string{"žluťoučký kůň úpěl ďábelské ódy"},
+		},
+		{
+			"characters outside basic plane",
+			"😀 emoji 😀 first second third", "𝔘 first second third 𝔘", 3,
+			[]string{"first second third"}, []string{"first second third"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := NewDocument(tt.a, tt.n), NewDocument(tt.b, tt.n)
+
+			gotA, gotB := []string{}, []string{}
+			for _, passage := range Passages(a, b, tt.n) {
+				gotA = append(gotA, utf16Slice(tt.a, passage.StartA, passage.EndA))
+				gotB = append(gotB, utf16Slice(tt.b, passage.StartB, passage.EndB))
+			}
+
+			if !reflect.DeepEqual(gotA, tt.wantA) {
+				t.Fatalf("passages of A: got %q, want %q", gotA, tt.wantA)
+			}
+			if !reflect.DeepEqual(gotB, tt.wantB) {
+				t.Fatalf("passages of B: got %q, want %q", gotB, tt.wantB)
+			}
+		})
+	}
+}
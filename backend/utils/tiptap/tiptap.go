@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"time"
 
 	"elogika.vsb.cz/backend/models"
 	"elogika.vsb.cz/backend/modules/common"
@@ -172,28 +171,14 @@ func (ttc *TipTapParser) HandleCodeBlock(dbRef *gorm.DB, userId uint, node *mode
 		return nil
 	}
 
-	// If file hash does not match. Render new image
-	fileName, size, err := utils.ConvertCodeToImage(dbRef, code)
+	// If file hash does not match. Render new image, identical code is taken from render cache
+	newFile, err := utils.ConvertCodeToImage(dbRef, userId, code)
 	if err != nil {
 		return err
 	}
 
 	node.Attrs["hash"] = hash
-	node.Attrs["filename"] = fileName
-
-	newFile := models.File{
-		UserID:       userId,
-		OriginalName: "GENERATED",
-		StoredName:   fileName,
-		MIMEType:     "image/svg",
-		SizeBytes:    size,
-		UploadedAt:   time.Now(),
-	}
-
-	if err := dbRef.Save(&newFile).Error; err != nil {
-		return errors.New("failed to save file info to database")
-	}
-
+	node.Attrs["filename"] = newFile.StoredName
 	node.Attrs["id"] = newFile.ID
 
 	ttc.FileIDs = append(ttc.FileIDs, newFile.ID)